@@ -6,39 +6,164 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/textproc"
 )
 
 // Client 代表 GPT-SoVITS API 客户端
+// Client 的方法可被多个 goroutine 同时调用；导出字段与 Use 注册的中间件应在首次发起请求前设置完毕，之后不应再修改
+// 异步调度器、模型管理器与能力探测结果在首次使用时惰性初始化，初始化是并发安全的；不再使用时调用 Close 释放资源
 type Client struct {
 	BaseURL    string       // API基础URL
 	HTTPClient *http.Client // HTTP客户端
+
+	// Transport 为自定义传输层，为 nil 时使用 HTTPClient 发送请求
+	Transport Transport
+
+	UploadURL string // 参考音频上传端点，为空时使用 BaseURL + "/upload_ref_audio"
+
+	GPTWeightsListURL    string // GPT 权重列表端点，为空时使用 BaseURL + "/gpt_weights_list"
+	SoVITSWeightsListURL string // SoVITS 权重列表端点，为空时使用 BaseURL + "/sovits_weights_list"
+
+	// SupportsRefAudioData 表示服务器是否支持在请求体中以 base64 内嵌参考音频
+	SupportsRefAudioData bool
+
+	// RawSampleRate 为 raw 格式输出的采样率，取决于服务器加载的模型，为0时按 32000 处理
+	RawSampleRate int
+
+	middlewares  []Middleware    // 请求/响应中间件链
+	faults       *FaultInjector  // 故障注入器，位于中间件链最内层
+	transport    *http.Transport // 默认传输层，供客户端可选项调整
+	transportSet bool            // 可选项是否调整过默认传输层
+
+	asyncConcurrency  int           // 异步调度器并发数
+	starvationTimeout time.Duration // 异步任务的最长排队时间
+	dispatcher        *dispatcher   // 异步请求调度器
+	dispatcherOnce    sync.Once
+
+	defaults   *TTSRequest // 默认 TTS 参数
+	defaultsMu sync.RWMutex
+
+	textProcessor textproc.Processor // 合成前的文本预处理
+
+	minAudioDuration time.Duration    // 成功响应的最短音频时长
+	voices           *VoiceRegistry   // 按名称查找音色的注册表
+	usage            *UsageTracker    // 用量统计器
+	tenant           string           // 用量记录中的租户名称
+	quotas           []Quota          // 配额
+	transcripts      *transcriptCache // 参考音频转写器与转写结果
+	apiVersion       APIVersion       // 服务器的 API 版本
+	caps             *Capabilities    // 服务器支持的请求字段，为 nil 时视为全部支持
+	autoWeights      *AutoWeights     // 模型未加载时自动设置的权重
+	bodyEncoder      BodyEncoder      // 合成请求体的编码方式，为 nil 时使用 JSON
+	gzipMinSize      int              // 压缩请求体的最小字节数，0 表示不压缩
+	noCompression    bool             // 是否禁用压缩
+	rangeResumes     int              // 读取响应体中断时的最大续传次数
+	cache            Cache            // 合成结果缓存
+	singleflight     bool             // 是否合并相同的请求
+	flights          flightGroup      // 进行中的合并请求
+	rtf              rtfStats         // 近期合成的实时率统计
+	models           *ModelManager    // 会话共享的模型权重管理器
+	maxTextLength    int              // 单次请求的最大字符数，<=0 表示不限制
+	textLimitPolicy  TextLimitPolicy  // 文本超过限制时的处理策略
+	contentFilters   []ContentFilter  // 合成前的内容过滤器
+	voicePolicy      VoicePolicy      // 合成前的音色授权检查
+	auditSink        AuditSink        // 审计记录的接收端
+	auditOptions     *AuditOptions    // 审计日志的脱敏选项
+	modelsOnce       sync.Once
+	capsMu           sync.Mutex
+
+	gate      requestGate // 进行中请求的跟踪
+	closeOnce sync.Once
+
+	shutdownStore JobStore    // 关闭时保存未执行的异步任务
+	queues        []*JobQueue // 以该客户端创建的任务队列，关闭时一并关闭
+	queuesMu      sync.Mutex
 }
 
 // TTSRequest 代表 TTS 请求载荷
 type TTSRequest struct {
-	Text          string `json:"text"`           // str.(必填) 需要合成的文本
-	TextLang      string `json:"text_lang"`      // str.(必填) 待合成文本的语言
-	RefAudioPath  string `json:"ref_audio_path"` // str.(必填) 参考音频路径
-	PromptText    string `json:"prompt_text"`    // str.(可选) 参考音频的提示文本
-	PromptLang    string `json:"prompt_lang"`    // str.(必填) 参考音频提示文本的语言
-	MediaType     string `json:"media_type"`     // str. 输出音频媒体类型，支持 "wav", "raw", "ogg", "aac"
-	StreamingMode bool   `json:"streaming_mode"` // bool. 是否返回流式响应
+	Text              string      `json:"text"`                          // str.(必填) 需要合成的文本
+	TextLang          string      `json:"text_lang"`                     // str.(必填) 待合成文本的语言
+	RefAudioPath      string      `json:"ref_audio_path"`                // str.(必填) 参考音频路径
+	RefAudioData      []byte      `json:"ref_audio_base64,omitempty"`    // bytes.(可选) 内嵌的参考音频数据，序列化时自动进行 base64 编码
+	AuxRefAudioPaths  []string    `json:"aux_ref_audio_paths,omitempty"` // list.(可选) 用于多说话人音色融合的辅助参考音频路径
+	PromptText        string      `json:"prompt_text"`                   // str.(可选) 参考音频的提示文本
+	PromptLang        string      `json:"prompt_lang"`                   // str.(必填) 参考音频提示文本的语言
+	TopK              int         `json:"top_k,omitempty"`               // int. top k 采样
+	TopP              float64     `json:"top_p,omitempty"`               // float. top p 采样
+	Temperature       float64     `json:"temperature,omitempty"`         // float. 采样温度
+	TextSplitMethod   SplitMethod `json:"text_split_method,omitempty"`   // str. 文本切分方法，如 Cut5
+	BatchSize         int         `json:"batch_size,omitempty"`          // int. 推理批大小
+	BatchThreshold    float64     `json:"batch_threshold,omitempty"`     // float. 批切分阈值
+	SplitBucket       *bool       `json:"split_bucket,omitempty"`        // bool. 是否将批次按长度分桶
+	SpeedFactor       float64     `json:"speed_factor,omitempty"`        // float. 控制合成音频的语速
+	FragmentInterval  float64     `json:"fragment_interval,omitempty"`   // float. 控制音频片段间隔
+	Seed              int         `json:"seed,omitempty"`                // int. 随机种子，用于复现结果
+	ParallelInfer     *bool       `json:"parallel_infer,omitempty"`      // bool. 是否使用并行推理
+	RepetitionPenalty float64     `json:"repetition_penalty,omitempty"`  // float. T2S 模型的重复惩罚
+	SampleSteps       int         `json:"sample_steps,omitempty"`        // int. VITS V3 模型的采样步数
+	SuperSampling     bool        `json:"super_sampling,omitempty"`      // bool. 使用 VITS V3 模型时是否对音频进行超采样
+	MediaType         MediaType   `json:"media_type"`                    // str. 输出音频媒体类型，支持 "wav", "raw", "ogg", "aac"
+	StreamingMode     bool        `json:"streaming_mode"`                // bool. 是否返回流式响应
+}
+
+// Bool 返回指向给定布尔值的指针，便于设置 TTSRequest 中的可选布尔字段
+func Bool(v bool) *bool {
+	return &v
 }
 
 // TTSResponse 代表 TTS 响应
 type TTSResponse struct {
-	StatusCode int    // HTTP状态码
-	AudioData  []byte // 音频数据
-	Error      error  // 错误信息
+	StatusCode  int       // HTTP状态码
+	AudioData   []byte    // 音频数据
+	Error       error     // 错误信息
+	ContentType string    // 响应的 Content-Type
+	Format      MediaType // 根据音频数据检测到的实际格式
+	SampleRate  int       // 采样率，wav 从文件头解析，raw 取客户端配置的 RawSampleRate
+	Channels    int       // 声道数，wav 从文件头解析，raw 固定为单声道
+
+	Header       http.Header   // 响应头
+	RequestID    string        // 请求 ID，优先取服务器回显的值
+	Elapsed      time.Duration // 从发起调用到读取完响应体的耗时
+	UpstreamTime time.Duration // 响应头报告的服务器处理耗时，未报告时为0
+	Seed         int           // 实际发送的随机种子，使用相同种子可复现本次结果
+
+	TTFB     time.Duration // 从发起调用到收到响应头的耗时
+	Duration time.Duration // 音频时长，仅 wav 与 raw 格式可计算
+	Samples  int           // 每声道的采样帧数，仅 wav 与 raw 格式可计算
+	RTF      float64       // 实时率，Elapsed / Duration，小于 1 表示快于实时，无法计算时长时为0
+
+	SHA256 string // 音频数据的 SHA-256 十六进制摘要，仅在使用 WithChecksum 时计算
+
+	FromCache bool // 结果是否来自缓存
+	Shared    bool // 结果是否与同时发起的相同请求共享
+
+	Timing *Timing // 服务器随音频返回的时间对齐信息，未返回时为 nil
+
+	TextLimit TextLimitPolicy // 文本超过 WithMaxTextLength 的限制时触发的策略，未触发时为空
+}
+
+// Err 返回请求失败的原因：请求过程中的错误或非200状态码（通常为 *APIError），成功时返回 nil
+func (r *TTSResponse) Err() error {
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.StatusCode != http.StatusOK {
+		return fmt.Errorf("TTS请求失败，状态码 %d: %s", r.StatusCode, string(r.AudioData))
+	}
+	return nil
 }
 
 // ControlRequest 代表控制请求载荷
 type ControlRequest struct {
-	Command string `json:"command"` // "restart" 或 "exit"
+	Command ControlCommand `json:"command"` // "restart" 或 "exit"
 }
 
 // SetWeightsRequest 代表模型权重更新请求
@@ -47,51 +172,258 @@ type SetWeightsRequest struct {
 }
 
 // NewClient 创建一个新的 GPT-SoVITS API 客户端
-func NewClient(baseURL string) *Client {
-	return &Client{
+func NewClient(baseURL string, opts ...ClientOption) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	c := &Client{
 		BaseURL: baseURL,
 		HTTPClient: &http.Client{
-			Timeout: 60 * time.Second, // 根据需要调整超时时间
+			Timeout:   60 * time.Second, // 根据需要调整超时时间
+			Transport: transport,
 		},
+		transport: transport,
 	}
+
+	// 应用客户端可选项
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // TTS 发送文本转语音请求并返回音频响应
-func (c *Client) TTS(ctx context.Context, req TTSRequest) (*TTSResponse, error) {
-	// 将请求序列化为JSON
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return &TTSResponse{Error: fmt.Errorf("请求序列化失败: %w", err)}, nil
+// 设置了 WithCache 或 WithSingleflight 时先查询缓存并合并相同的请求
+func (c *Client) TTS(ctx context.Context, req TTSRequest, opts ...CallOption) (*TTSResponse, error) {
+	if c.textLimit(req.Text) == TextLimitChunk {
+		return c.ttsChunked(ctx, req, opts)
 	}
 
-	// 构建请求URL
-	url := fmt.Sprintf("%s/tts", c.BaseURL)
-	// 创建带上下文的HTTP请求
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+	o := newCallOptions(opts)
+	resp, err := c.ttsAuthorized(ctx, req, o, opts)
+	c.audit(ctx, req, o, resp, err, false)
+	return resp, err
+}
+
+// ttsAuthorized 完成授权检查后经过缓存或直接发送 TTS 请求
+func (c *Client) ttsAuthorized(ctx context.Context, req TTSRequest, o *callOptions, opts []CallOption) (*TTSResponse, error) {
+	// 授权检查须在读取缓存前完成
+	if err := c.authorizeVoice(ctx, c.applyDefaults(req), o); err != nil {
+		return &TTSResponse{Error: err}, nil
+	}
+
+	if (c.cache != nil || c.singleflight) && !o.noCache {
+		return c.ttsShared(ctx, req, opts)
+	}
+	return c.tts(ctx, req, opts...)
+}
+
+// tts 发送文本转语音请求，不经过缓存
+func (c *Client) tts(ctx context.Context, req TTSRequest, opts ...CallOption) (*TTSResponse, error) {
+	o := newCallOptions(opts)
+	start := time.Now()
+
+	// 应用单次调用超时
+	ctx, cancel := o.withTimeout(ctx)
+	defer cancel()
+
+	// 构建HTTP请求
+	httpReq, sent, err := c.newTTSRequest(ctx, req)
 	if err != nil {
-		return &TTSResponse{Error: fmt.Errorf("创建请求失败: %w", err)}, nil
+		return &TTSResponse{Error: err, TextLimit: c.textLimit(req.Text)}, nil
+	}
+	if err := c.checkQuota(sent, o); err != nil {
+		return &TTSResponse{Error: err}, nil
 	}
 
-	// 设置请求头
-	httpReq.Header.Set("Content-Type", "application/json")
+	ttsResp := c.sendTTSWithAutoWeights(httpReq, o, start)
+	ttsResp.Seed = sent.Seed
+	ttsResp.TextLimit = c.textLimit(req.Text)
+	c.recordUsage(sent, o, ttsResp, false)
+	if ttsResp.Err() == nil {
+		c.rtf.observe(sent.Text, ttsResp)
+	}
+	return ttsResp, nil
+}
+
+// sendTTS 发送已构建的 TTS 请求并读取完整的音频响应
+func (c *Client) sendTTS(httpReq *http.Request, o *callOptions, start time.Time) *TTSResponse {
+	// 登记进行中的请求，切换模型期间在此等待
+	if err := c.gate.enter(httpReq.Context()); err != nil {
+		return &TTSResponse{Error: fmt.Errorf("请求失败: %w", err)}
+	}
+	defer c.gate.leave()
+
+	// 附加自定义请求头与请求 ID
+	setCallHeaders(httpReq, o)
+	requestID := setRequestID(httpReq, o)
 
 	// 发送请求
-	resp, err := c.HTTPClient.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
-		return &TTSResponse{Error: fmt.Errorf("请求失败: %w", err)}, nil
+		// 熔断器在本地拒绝请求，不属于传输错误
+		if errors.Is(err, ErrCircuitOpen) {
+			return &TTSResponse{Error: fmt.Errorf("请求失败: %w", err), RequestID: requestID, Elapsed: time.Since(start)}
+		}
+		return &TTSResponse{Error: fmt.Errorf("请求失败: %w: %w", ErrTransport, err), RequestID: requestID, Elapsed: time.Since(start)}
 	}
 	defer resp.Body.Close()
+	ttfb := time.Since(start)
+
+	// 非200响应的响应体为错误信息而非音频
+	if resp.StatusCode != http.StatusOK {
+		rid := responseRequestID(resp.Header, requestID)
+		return &TTSResponse{
+			StatusCode: resp.StatusCode,
+			Error:      readAPIError(resp, rid),
+			Header:     resp.Header,
+			RequestID:  rid,
+			Elapsed:    time.Since(start),
+			TTFB:       ttfb,
+		}
+	}
 
 	// 读取响应体
-	audioData, err := io.ReadAll(resp.Body)
+	audioData, err := io.ReadAll(wrapProgress(resp.Body, o.progress, start))
 	if err != nil {
-		return &TTSResponse{Error: fmt.Errorf("读取响应体失败: %w", err)}, nil
+		// 网关支持范围请求时续传剩余部分
+		audioData, err = c.resumeBody(httpReq, resp, audioData, err, o, start)
+	}
+	if err != nil {
+		// 成功响应中途中断时保留已接收的音频
+		readErr := fmt.Errorf("读取响应体失败: %w", err)
+		if len(audioData) > 0 {
+			readErr = &PartialError{Audio: audioData, Bytes: int64(len(audioData)), Err: err}
+		}
+		return &TTSResponse{
+			StatusCode: resp.StatusCode,
+			AudioData:  audioData,
+			Error:      readErr,
+			Header:     resp.Header,
+			RequestID:  responseRequestID(resp.Header, requestID),
+			Elapsed:    time.Since(start),
+			TTFB:       ttfb,
+		}
+	}
+
+	ttsResp := &TTSResponse{
+		StatusCode:   resp.StatusCode,
+		AudioData:    audioData,
+		Header:       resp.Header,
+		RequestID:    responseRequestID(resp.Header, requestID),
+		Elapsed:      time.Since(start),
+		UpstreamTime: parseUpstreamTime(resp.Header),
+		TTFB:         ttfb,
+	}
+	c.fillMediaInfo(ttsResp, resp)
+	if ttsResp.Error != nil {
+		return ttsResp
+	}
+
+	// 计算音频时长与实时率
+	ttsResp.Samples, ttsResp.Duration, _ = audioLength(ttsResp)
+	if ttsResp.Duration > 0 {
+		ttsResp.RTF = ttsResp.Elapsed.Seconds() / ttsResp.Duration.Seconds()
+	}
+	if o.checksum {
+		fillChecksum(ttsResp)
+	}
+	// 服务器推理静默失败时可能返回空音频
+	ttsResp.Error = c.checkAudio(ttsResp)
+
+	return ttsResp
+}
+
+// newTTSRequest 将 TTS 请求序列化为带上下文的 POST 请求，同时返回合并默认参数后实际发送的请求
+func (c *Client) newTTSRequest(ctx context.Context, req TTSRequest) (*http.Request, TTSRequest, error) {
+	req, err := c.prepareRequest(ctx, req)
+	if err != nil {
+		return nil, req, err
+	}
+	if c.apiVersion == APIV1 {
+		httpReq, err := c.newV1Request(ctx, req, false)
+		return httpReq, req, err
 	}
 
-	return &TTSResponse{
-		StatusCode: resp.StatusCode,
-		AudioData:  audioData,
-	}, nil
+	// 按配置的编码方式序列化请求，默认为JSON
+	body, contentType, err := c.encodeBody(req)
+	if err != nil {
+		return nil, req, err
+	}
+
+	// 构建请求URL
+	url := fmt.Sprintf("%s/tts", c.BaseURL)
+	// 创建带上下文的HTTP请求
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, req, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	// 设置请求头
+	httpReq.Header.Set("Content-Type", contentType)
+
+	return httpReq, req, nil
+}
+
+// prepareRequest 合并默认参数、补全提示文本、预处理文本并校验请求
+func (c *Client) prepareRequest(ctx context.Context, req TTSRequest) (TTSRequest, error) {
+	// 合并客户端默认参数
+	req = c.applyDefaults(req)
+
+	// 按策略处理过长的文本
+	if err := c.limitText(&req); err != nil {
+		return req, err
+	}
+
+	// 内容过滤
+	if err := c.filterContent(ctx, req.Text, req.TextLang); err != nil {
+		return req, err
+	}
+
+	// 缺少提示文本时转写参考音频
+	if err := c.fillPromptText(ctx, &req); err != nil {
+		return req, err
+	}
+
+	// 未指定随机种子时在客户端生成，使响应中可以返回实际使用的种子
+	if req.Seed <= 0 {
+		req.Seed = randomSeed()
+	}
+
+	// 文本预处理
+	if c.textProcessor != nil {
+		text, err := c.textProcessor.Process(req.Text, req.TextLang)
+		if err != nil {
+			return req, err
+		}
+		req.Text = text
+	}
+
+	// 去除服务器不支持的可选字段
+	if c.apiVersion == APIV2 {
+		c.knownCapabilities().Strip(&req)
+	}
+
+	// 内嵌参考音频需要服务器支持
+	if len(req.RefAudioData) > 0 && !c.SupportsRefAudioData {
+		return req, fmt.Errorf("服务器不支持内嵌参考音频，请设置 SupportsRefAudioData 或改用 RefAudioPath")
+	}
+
+	// 校验输出媒体类型
+	if req.MediaType != "" {
+		if err := req.MediaType.Validate(); err != nil {
+			return req, err
+		}
+	}
+
+	// 校验文本切分方法
+	if req.TextSplitMethod != "" {
+		if err := req.TextSplitMethod.Validate(); err != nil {
+			return req, err
+		}
+	}
+
+	return req, nil
 }
 
 // TTSSimple 提供简化的 TTS GET 接口
@@ -103,7 +435,7 @@ func (c *Client) TTSSimple(ctx context.Context, text, textLang, refAudioPath, pr
 		RefAudioPath:  refAudioPath,
 		PromptLang:    promptLang,
 		PromptText:    promptText,
-		MediaType:     "wav",
+		MediaType:     MediaTypeWAV,
 		StreamingMode: false,
 	}
 
@@ -111,7 +443,7 @@ func (c *Client) TTSSimple(ctx context.Context, text, textLang, refAudioPath, pr
 }
 
 // Control 向服务器发送控制命令
-func (c *Client) Control(ctx context.Context, command string) error {
+func (c *Client) Control(ctx context.Context, command ControlCommand) error {
 	// 创建控制请求对象
 	controlReq := ControlRequest{Command: command}
 
@@ -133,7 +465,7 @@ func (c *Client) Control(ctx context.Context, command string) error {
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	// 发送请求
-	resp, err := c.HTTPClient.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
 		return fmt.Errorf("控制请求失败: %w", err)
 	}
@@ -150,6 +482,10 @@ func (c *Client) Control(ctx context.Context, command string) error {
 
 // SetGPTWeights 更新 GPT 模型权重
 func (c *Client) SetGPTWeights(ctx context.Context, weightsPath string) error {
+	if c.apiVersion == APIV1 {
+		return c.setModelV1(ctx, weightsPath, "")
+	}
+
 	// 创建权重请求对象
 	weightsReq := SetWeightsRequest{WeightsPath: weightsPath}
 
@@ -171,7 +507,7 @@ func (c *Client) SetGPTWeights(ctx context.Context, weightsPath string) error {
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	// 发送请求
-	resp, err := c.HTTPClient.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
 		return fmt.Errorf("设置GPT权重请求失败: %w", err)
 	}
@@ -188,6 +524,10 @@ func (c *Client) SetGPTWeights(ctx context.Context, weightsPath string) error {
 
 // SetSoVITSWeights 更新 SoVITS 模型权重
 func (c *Client) SetSoVITSWeights(ctx context.Context, weightsPath string) error {
+	if c.apiVersion == APIV1 {
+		return c.setModelV1(ctx, "", weightsPath)
+	}
+
 	// 创建权重请求对象
 	weightsReq := SetWeightsRequest{WeightsPath: weightsPath}
 
@@ -209,7 +549,7 @@ func (c *Client) SetSoVITSWeights(ctx context.Context, weightsPath string) error
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	// 发送请求
-	resp, err := c.HTTPClient.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
 		return fmt.Errorf("设置SoVITS权重请求失败: %w", err)
 	}
@@ -225,6 +565,8 @@ func (c *Client) SetSoVITSWeights(ctx context.Context, weightsPath string) error
 }
 
 // GetTTSWithURLParams 使用URL参数提供 GET 接口
+//
+// Deprecated: 参数未经类型检查与转义，请使用 TTSGet
 func (c *Client) GetTTSWithURLParams(ctx context.Context, params map[string]string) (*TTSResponse, error) {
 	// 构建请求URL
 	url := fmt.Sprintf("%s/tts", c.BaseURL)
@@ -249,7 +591,7 @@ func (c *Client) GetTTSWithURLParams(ctx context.Context, params map[string]stri
 	}
 
 	// 发送请求
-	resp, err := c.HTTPClient.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
 		return &TTSResponse{Error: fmt.Errorf("请求失败: %w", err)}, nil
 	}
@@ -261,14 +603,17 @@ func (c *Client) GetTTSWithURLParams(ctx context.Context, params map[string]stri
 		return &TTSResponse{Error: fmt.Errorf("读取响应体失败: %w", err)}, nil
 	}
 
-	return &TTSResponse{
+	ttsResp := &TTSResponse{
 		StatusCode: resp.StatusCode,
 		AudioData:  audioData,
-	}, nil
+	}
+	c.fillMediaInfo(ttsResp, resp)
+
+	return ttsResp, nil
 }
 
 // ControlWithGet 提供控制命令的 GET 接口
-func (c *Client) ControlWithGet(ctx context.Context, command string) error {
+func (c *Client) ControlWithGet(ctx context.Context, command ControlCommand) error {
 	// 构建请求URL
 	url := fmt.Sprintf("%s/control?command=%s", c.BaseURL, command)
 
@@ -279,7 +624,7 @@ func (c *Client) ControlWithGet(ctx context.Context, command string) error {
 	}
 
 	// 发送请求
-	resp, err := c.HTTPClient.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
 		return fmt.Errorf("控制请求失败: %w", err)
 	}
@@ -296,6 +641,10 @@ func (c *Client) ControlWithGet(ctx context.Context, command string) error {
 
 // SetGPTWeightsWithGet 提供设置 GPT 权重的 GET 接口
 func (c *Client) SetGPTWeightsWithGet(ctx context.Context, weightsPath string) error {
+	if c.apiVersion == APIV1 {
+		return c.setModelV1(ctx, weightsPath, "")
+	}
+
 	// 构建请求URL
 	url := fmt.Sprintf("%s/set_gpt_weights?weights_path=%s", c.BaseURL, weightsPath)
 
@@ -306,7 +655,7 @@ func (c *Client) SetGPTWeightsWithGet(ctx context.Context, weightsPath string) e
 	}
 
 	// 发送请求
-	resp, err := c.HTTPClient.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
 		return fmt.Errorf("设置GPT权重请求失败: %w", err)
 	}
@@ -323,6 +672,10 @@ func (c *Client) SetGPTWeightsWithGet(ctx context.Context, weightsPath string) e
 
 // SetSoVITSWeightsWithGet 提供设置 SoVITS 权重的 GET 接口
 func (c *Client) SetSoVITSWeightsWithGet(ctx context.Context, weightsPath string) error {
+	if c.apiVersion == APIV1 {
+		return c.setModelV1(ctx, "", weightsPath)
+	}
+
 	// 构建请求URL
 	url := fmt.Sprintf("%s/set_sovits_weights?weights_path=%s", c.BaseURL, weightsPath)
 
@@ -333,7 +686,7 @@ func (c *Client) SetSoVITSWeightsWithGet(ctx context.Context, weightsPath string
 	}
 
 	// 发送请求
-	resp, err := c.HTTPClient.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
 		return fmt.Errorf("设置SoVITS权重请求失败: %w", err)
 	}
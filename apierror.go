@@ -0,0 +1,85 @@
+package gpt_sovits_go_sdk
+
+// 提供对服务器错误响应的解析，非200响应的 JSON 错误体不再被当作音频数据
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrAPI 表示服务器返回了非200状态码
+var ErrAPI = errors.New("服务器返回错误")
+
+// ErrTransport 表示请求未能送达服务器或未收到响应，如连接失败、DNS解析失败，不含熔断器的本地拒绝
+var ErrTransport = errors.New("请求未送达服务器")
+
+// maxErrorBody 为读取错误响应体的最大字节数
+const maxErrorBody = 64 << 10
+
+// APIError 代表服务器返回的错误响应，可用 errors.As 取得
+type APIError struct {
+	StatusCode int    // HTTP状态码
+	Message    string // 错误信息，取自 {"message": ...} 或 FastAPI 的 {"detail": ...}，无法解析时为响应体文本
+	Exception  string // 服务器报告的异常信息，取自 {"Exception": ...}
+	Body       []byte // 原始响应体
+	RequestID  string // 请求 ID
+}
+
+// Error 实现 error 接口
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString("TTS请求失败")
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, "（请求 ID %s）", e.RequestID)
+	}
+	fmt.Fprintf(&b, "，状态码 %d", e.StatusCode)
+	if e.Message != "" {
+		b.WriteString(": " + e.Message)
+	}
+	if e.Exception != "" {
+		b.WriteString(": " + e.Exception)
+	}
+	return b.String()
+}
+
+// Is 使 errors.Is(err, ErrAPI) 成立
+func (e *APIError) Is(target error) bool {
+	return target == ErrAPI
+}
+
+// readAPIError 读取非200响应的响应体并解析为 APIError
+func readAPIError(resp *http.Response, requestID string) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return parseAPIError(resp.StatusCode, body, requestID)
+}
+
+// parseAPIError 解析错误响应体，兼容 api_v2 的 {"message", "Exception"} 与 FastAPI 的 {"detail"}
+func parseAPIError(status int, body []byte, requestID string) *APIError {
+	e := &APIError{StatusCode: status, Body: body, RequestID: requestID}
+
+	var payload struct {
+		Message   string          `json:"message"`
+		Exception string          `json:"Exception"`
+		Detail    json.RawMessage `json:"detail"`
+	}
+	if json.Unmarshal(body, &payload) == nil && (payload.Message != "" || payload.Exception != "" || payload.Detail != nil) {
+		e.Message, e.Exception = payload.Message, payload.Exception
+		if e.Message == "" && payload.Detail != nil {
+			// detail 可能为字符串或校验错误列表
+			var detail string
+			if json.Unmarshal(payload.Detail, &detail) == nil {
+				e.Message = detail
+			} else {
+				e.Message = string(payload.Detail)
+			}
+		}
+		return e
+	}
+
+	e.Message = strings.TrimSpace(string(body))
+	return e
+}
@@ -0,0 +1,171 @@
+package gpt_sovits_go_sdk
+
+// 提供旧版 api.py（v1）接口的适配，使旧部署可以使用相同的客户端接口
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// APIVersion 代表服务器的 API 版本
+type APIVersion int
+
+const (
+	APIV2 APIVersion = iota // api_v2.py，默认
+	APIV1                   // 旧版 api.py，合成端点为 "/"，切换模型使用 "/set_model"
+)
+
+// String 返回 API 版本名称
+func (v APIVersion) String() string {
+	if v == APIV1 {
+		return "v1"
+	}
+	return "v2"
+}
+
+// WithAPIVersion 设置服务器的 API 版本
+// 使用 APIV1 时 TTS、TTSGet、TTSStream、SetGPTWeights、SetSoVITSWeights 等方法自动转换为 api.py 的端点与字段；
+// api.py 不支持的参数（如 batch_size、seed、media_type）会被忽略，输出格式与流式模式由服务器启动参数决定
+func WithAPIVersion(v APIVersion) ClientOption {
+	return func(c *Client) {
+		c.apiVersion = v
+	}
+}
+
+// TTSRequestV1 代表 api.py 的合成请求载荷
+type TTSRequestV1 struct {
+	ReferWavPath   string   `json:"refer_wav_path,omitempty"`  // 参考音频路径
+	PromptText     string   `json:"prompt_text,omitempty"`     // 参考音频的提示文本
+	PromptLanguage string   `json:"prompt_language,omitempty"` // 提示文本的语言
+	Text           string   `json:"text"`                      // 需要合成的文本
+	TextLanguage   string   `json:"text_language"`             // 待合成文本的语言
+	CutPunc        string   `json:"cut_punc,omitempty"`        // 按这些标点切分文本
+	TopK           int      `json:"top_k,omitempty"`           // top k 采样
+	TopP           float64  `json:"top_p,omitempty"`           // top p 采样
+	Temperature    float64  `json:"temperature,omitempty"`     // 采样温度
+	Speed          float64  `json:"speed,omitempty"`           // 语速
+	InpRefs        []string `json:"inp_refs,omitempty"`        // 用于音色融合的辅助参考音频
+	SampleSteps    int      `json:"sample_steps,omitempty"`    // V3 模型的采样步数
+	IfSR           bool     `json:"if_sr,omitempty"`           // V3 模型是否超采样
+}
+
+// v1CutPunc 为 text_split_method 对应的 cut_punc，未列出的切分方法不切分
+var v1CutPunc = map[SplitMethod]string{
+	Cut3: "。",
+	Cut4: ".",
+	Cut5: ",.;?!、，。？！;：…",
+}
+
+// V1 将请求转换为 api.py 的请求载荷
+func (r TTSRequest) V1() TTSRequestV1 {
+	return TTSRequestV1{
+		ReferWavPath:   r.RefAudioPath,
+		PromptText:     r.PromptText,
+		PromptLanguage: r.PromptLang,
+		Text:           r.Text,
+		TextLanguage:   r.TextLang,
+		CutPunc:        v1CutPunc[r.TextSplitMethod],
+		TopK:           r.TopK,
+		TopP:           r.TopP,
+		Temperature:    r.Temperature,
+		Speed:          r.SpeedFactor,
+		InpRefs:        r.AuxRefAudioPaths,
+		SampleSteps:    r.SampleSteps,
+		IfSR:           r.SuperSampling,
+	}
+}
+
+// QueryValues 将请求载荷映射为 GET / 的查询参数，可选字段为零值时省略
+func (r TTSRequestV1) QueryValues() url.Values {
+	v := url.Values{}
+	v.Set("text", r.Text)
+	v.Set("text_language", r.TextLanguage)
+	if r.ReferWavPath != "" {
+		v.Set("refer_wav_path", r.ReferWavPath)
+		v.Set("prompt_text", r.PromptText)
+		v.Set("prompt_language", r.PromptLanguage)
+	}
+	if r.CutPunc != "" {
+		v.Set("cut_punc", r.CutPunc)
+	}
+	setInt(v, "top_k", r.TopK)
+	setFloat(v, "top_p", r.TopP)
+	setFloat(v, "temperature", r.Temperature)
+	setFloat(v, "speed", r.Speed)
+	for _, p := range r.InpRefs {
+		v.Add("inp_refs", p)
+	}
+	setInt(v, "sample_steps", r.SampleSteps)
+	if r.IfSR {
+		v.Set("if_sr", strconv.FormatBool(r.IfSR))
+	}
+	return v
+}
+
+// newV1Request 构建 api.py 的合成请求，get 为 true 时使用 GET 查询参数
+func (c *Client) newV1Request(ctx context.Context, req TTSRequest, get bool) (*http.Request, error) {
+	if len(req.RefAudioData) > 0 {
+		return nil, fmt.Errorf("api.py 不支持内嵌参考音频，请改用 RefAudioPath")
+	}
+	body := req.V1()
+
+	if get {
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/?%s", c.BaseURL, body.QueryValues().Encode()), nil)
+		if err != nil {
+			return nil, fmt.Errorf("创建请求失败: %w", err)
+		}
+		return httpReq, nil
+	}
+
+	// 将请求序列化为JSON
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("请求序列化失败: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/", c.BaseURL), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	return httpReq, nil
+}
+
+// setModelV1 通过 api.py 的 /set_model 切换模型权重，路径为空的一项不发送
+// 部分 api.py 版本要求同时提供两个路径，此时应使用 ModelManager.SetWeights 一并设置
+func (c *Client) setModelV1(ctx context.Context, gpt, sovits string) error {
+	// 序列化请求
+	jsonData, err := json.Marshal(struct {
+		GPTModelPath    string `json:"gpt_model_path,omitempty"`
+		SoVITSModelPath string `json:"sovits_model_path,omitempty"`
+	}{gpt, sovits})
+	if err != nil {
+		return fmt.Errorf("权重请求序列化失败: %w", err)
+	}
+
+	// 创建HTTP请求
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/set_model", c.BaseURL), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("创建权重请求失败: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	// 发送请求
+	resp, err := c.do(httpReq)
+	if err != nil {
+		return fmt.Errorf("设置模型权重请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 检查响应状态
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("设置模型权重失败，状态码 %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package gpt_sovits_go_sdk
+
+// 提供基于 Future 的异步合成接口
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultAsyncConcurrency 为异步调度器的默认并发数
+const defaultAsyncConcurrency = 4
+
+// defaultStarvationTimeout 为低优先级任务被提前调度前的默认最长排队时间
+const defaultStarvationTimeout = 30 * time.Second
+
+// Priority 代表异步请求的优先级类别，高优先级任务先于低优先级任务发送
+type Priority int
+
+const (
+	// PriorityNormal 为默认优先级
+	PriorityNormal Priority = iota
+	// PriorityInteractive 用于聊天机器人等短文本交互，优先发送
+	PriorityInteractive
+	// PriorityBatch 用于有声书等长时间批量任务，在其他任务之后发送
+	PriorityBatch
+)
+
+// queueIndex 返回优先级对应的队列序号，序号越小越先调度
+func (p Priority) queueIndex() int {
+	switch p {
+	case PriorityInteractive:
+		return 0
+	case PriorityBatch:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// WithPriority 设置异步请求的优先级，仅对 TTSAsync 及基于其实现的调用有效
+func WithPriority(p Priority) CallOption {
+	return func(o *callOptions) {
+		o.priority = p
+	}
+}
+
+// TTSFuture 代表一个异步 TTS 请求的结果
+type TTSFuture struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	dequeue func() bool // 将尚在排队的任务移出队列，任务已被取出时返回 false
+	resp    *TTSResponse
+	err     error
+}
+
+// Done 返回在请求完成（成功、失败或取消）时关闭的通道
+func (f *TTSFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Result 阻塞等待请求完成并返回结果，返回值语义与 Client.TTS 相同
+func (f *TTSFuture) Result() (*TTSResponse, error) {
+	<-f.done
+	return f.resp, f.err
+}
+
+// Cancel 取消请求，尚在排队的请求立即移出队列并以 context.Canceled 完成，不会被发送
+func (f *TTSFuture) Cancel() {
+	f.cancel()
+	f.dequeue()
+}
+
+// asyncJob 代表调度器队列中的一个任务
+type asyncJob struct {
+	ctx    context.Context
+	req    TTSRequest
+	opts   []CallOption
+	future *TTSFuture
+
+	priority Priority  // 优先级
+	queued   time.Time // 入队时间
+}
+
+// dispatcher 代表异步请求的内部调度器，以固定数量的工作协程按优先级消费队列
+type dispatcher struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queues [3][]*asyncJob // 按优先级分类的队列
+
+	// maxWait 为任务的最长排队时间，超过后不论优先级优先调度，防止低优先级任务饿死
+	maxWait time.Duration
+
+	closed  bool                   // 是否已关闭，关闭后不再接受新任务
+	running map[*asyncJob]struct{} // 执行中的任务
+	workers sync.WaitGroup         // 工作协程
+}
+
+// WithAsyncConcurrency 设置异步调度器同时发送的最大请求数
+func WithAsyncConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		c.asyncConcurrency = n
+	}
+}
+
+// WithStarvationTimeout 设置异步任务的最长排队时间，默认 30 秒
+// 排队超过该时间的任务先于更高优先级的任务发送，<0 表示严格按优先级调度
+func WithStarvationTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.starvationTimeout = d
+	}
+}
+
+// TTSAsync 将 TTS 请求提交给内部调度器并立即返回 Future，调度顺序由 WithPriority 决定
+func (c *Client) TTSAsync(ctx context.Context, req TTSRequest, opts ...CallOption) *TTSFuture {
+	ctx, cancel := context.WithCancel(ctx)
+	f := &TTSFuture{
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+
+	job := &asyncJob{
+		ctx:      ctx,
+		req:      req,
+		opts:     opts,
+		future:   f,
+		priority: newCallOptions(opts).priority,
+		queued:   time.Now(),
+	}
+
+	// 排队期间上下文结束时立即移出队列并完成，不必等待工作协程取出
+	d := c.asyncDispatcher()
+	f.dequeue = func() bool {
+		if d == nil || !d.remove(job) {
+			return false
+		}
+		job.finish(&TTSResponse{Error: ctx.Err()}, nil)
+		return true
+	}
+
+	// 客户端关闭后提交的任务立即以 ErrClientClosed 完成
+	if d == nil || !d.push(job) {
+		job.finish(&TTSResponse{Error: ErrClientClosed}, nil)
+		return f
+	}
+	context.AfterFunc(ctx, func() { f.dequeue() })
+	return f
+}
+
+// finish 记录任务结果并通知等待者
+func (job *asyncJob) finish(resp *TTSResponse, err error) {
+	f := job.future
+	f.resp, f.err = resp, err
+	f.cancel()
+	close(f.done)
+}
+
+// asyncDispatcher 返回客户端的调度器，首次调用时启动工作协程，客户端在此之前已关闭时返回 nil
+func (c *Client) asyncDispatcher() *dispatcher {
+	c.dispatcherOnce.Do(func() {
+		d := &dispatcher{maxWait: c.starvationTimeout, running: make(map[*asyncJob]struct{})}
+		if d.maxWait == 0 {
+			d.maxWait = defaultStarvationTimeout
+		}
+		d.cond = sync.NewCond(&d.mu)
+
+		n := c.asyncConcurrency
+		if n <= 0 {
+			n = defaultAsyncConcurrency
+		}
+		d.workers.Add(n)
+		for i := 0; i < n; i++ {
+			go d.work(c)
+		}
+
+		c.dispatcher = d
+	})
+	return c.dispatcher
+}
+
+// push 将任务加入队列，调度器已关闭时返回 false
+func (d *dispatcher) push(job *asyncJob) bool {
+	i := job.priority.queueIndex()
+
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return false
+	}
+	d.queues[i] = append(d.queues[i], job)
+	d.mu.Unlock()
+	d.cond.Signal()
+	return true
+}
+
+// remove 将尚在排队的任务移出队列，任务不在队列中时返回 false
+func (d *dispatcher) remove(job *asyncJob) bool {
+	i := job.priority.queueIndex()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	q := d.queues[i]
+	for j, queued := range q {
+		if queued == job {
+			d.queues[i] = append(q[:j:j], q[j+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// close 关闭调度器：排队中的任务以 ErrClientClosed 完成，等待执行中的任务完成后工作协程退出
+func (d *dispatcher) close() {
+	d.mu.Lock()
+	d.closed = true
+	queues := d.queues
+	d.queues = [3][]*asyncJob{}
+	d.mu.Unlock()
+	d.cond.Broadcast()
+
+	for _, q := range queues {
+		for _, job := range q {
+			job.finish(&TTSResponse{Error: ErrClientClosed}, nil)
+		}
+	}
+	d.workers.Wait()
+}
+
+// pop 阻塞直到队列中有任务，并按优先级取出，调度器关闭且队列为空时返回 nil
+// 排队超时的任务中最早入队的优先，否则取最高优先级队列的队首
+func (d *dispatcher) pop() *asyncJob {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for {
+
+		next := -1
+		now := time.Now()
+		for i, q := range d.queues {
+			if len(q) == 0 {
+				continue
+			}
+			if next < 0 {
+				next = i
+			}
+			// 饿死保护，各队列内按入队顺序排列，只需检查队首
+			if d.maxWait > 0 && now.Sub(q[0].queued) >= d.maxWait && q[0].queued.Before(d.queues[next][0].queued) {
+				next = i
+			}
+		}
+
+		if next >= 0 {
+			q := d.queues[next]
+			job := q[0]
+			q[0] = nil
+			d.queues[next] = q[1:]
+			d.running[job] = struct{}{}
+			return job
+		}
+		if d.closed {
+			return nil
+		}
+		d.cond.Wait()
+	}
+}
+
+// work 持续消费队列并执行任务，调度器关闭后退出
+func (d *dispatcher) work(c *Client) {
+	defer d.workers.Done()
+
+	for {
+		job := d.pop()
+		if job == nil {
+			return
+		}
+
+		// 排队期间已取消的任务不再发送
+		if err := job.ctx.Err(); err != nil {
+			job.finish(&TTSResponse{Error: err}, nil)
+		} else {
+			job.finish(c.TTS(job.ctx, job.req, job.opts...))
+		}
+
+		d.mu.Lock()
+		delete(d.running, job)
+		d.mu.Unlock()
+	}
+}
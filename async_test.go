@@ -0,0 +1,171 @@
+package gpt_sovits_go_sdk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestDispatcher 创建一个不启动工作协程的调度器，便于直接检查出队顺序
+func newTestDispatcher(maxWait time.Duration) *dispatcher {
+	d := &dispatcher{maxWait: maxWait, running: make(map[*asyncJob]struct{})}
+	d.cond = sync.NewCond(&d.mu)
+	return d
+}
+
+func TestDispatcherOrder(t *testing.T) {
+	now := time.Now()
+
+	// 任务以 "名称" 标识，age 为入队时距今的时长
+	type queued struct {
+		name     string
+		priority Priority
+		age      time.Duration
+	}
+
+	tests := []struct {
+		name    string
+		maxWait time.Duration
+		jobs    []queued
+		want    []string
+	}{
+		{
+			name:    "按优先级调度，同一优先级按入队顺序",
+			maxWait: time.Minute,
+			jobs: []queued{
+				{"batch1", PriorityBatch, 3 * time.Second},
+				{"normal1", PriorityNormal, 2 * time.Second},
+				{"interactive1", PriorityInteractive, time.Second},
+				{"normal2", PriorityNormal, time.Second},
+				{"interactive2", PriorityInteractive, 0},
+			},
+			want: []string{"interactive1", "interactive2", "normal1", "normal2", "batch1"},
+		},
+		{
+			name:    "排队超时的低优先级任务被提前调度",
+			maxWait: time.Minute,
+			jobs: []queued{
+				{"batch1", PriorityBatch, 2 * time.Minute},
+				{"batch2", PriorityBatch, 0},
+				{"interactive1", PriorityInteractive, time.Second},
+			},
+			want: []string{"batch1", "interactive1", "batch2"},
+		},
+		{
+			name:    "多个超时任务中最早入队的优先",
+			maxWait: time.Minute,
+			jobs: []queued{
+				{"normal1", PriorityNormal, 2 * time.Minute},
+				{"batch1", PriorityBatch, 3 * time.Minute},
+				{"interactive1", PriorityInteractive, 0},
+			},
+			want: []string{"batch1", "normal1", "interactive1"},
+		},
+		{
+			name:    "maxWait<0 时严格按优先级调度",
+			maxWait: -1,
+			jobs: []queued{
+				{"batch1", PriorityBatch, time.Hour},
+				{"interactive1", PriorityInteractive, 0},
+			},
+			want: []string{"interactive1", "batch1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDispatcher(tt.maxWait)
+			names := make(map[*asyncJob]string)
+			for _, q := range tt.jobs {
+				job := &asyncJob{priority: q.priority, queued: now.Add(-q.age)}
+				names[job] = q.name
+				if !d.push(job) {
+					t.Fatal("push 失败")
+				}
+			}
+
+			for i, want := range tt.want {
+				if got := names[d.pop()]; got != want {
+					t.Fatalf("第 %d 个出队任务 = %s，期望 %s", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDispatcherRemove(t *testing.T) {
+	d := newTestDispatcher(time.Minute)
+	a := &asyncJob{priority: PriorityNormal, queued: time.Now()}
+	b := &asyncJob{priority: PriorityNormal, queued: time.Now()}
+	d.push(a)
+	d.push(b)
+
+	if !d.remove(a) {
+		t.Fatal("排队中的任务应可移出")
+	}
+	if d.remove(a) {
+		t.Fatal("已移出的任务不应再次移出")
+	}
+	if got := d.pop(); got != b {
+		t.Fatal("移出任务后应调度剩余任务")
+	}
+	if d.remove(b) {
+		t.Fatal("已取出的任务不应被移出")
+	}
+}
+
+func TestTTSFutureCancelQueued(t *testing.T) {
+	// 服务器在测试结束前不响应；未读取请求体时 net/http 无法感知客户端断开，须由测试显式放行
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c := NewClient(srv.URL, WithAsyncConcurrency(1))
+	defer c.Close()
+
+	// 第一个任务占用唯一的工作协程，第二个任务排队
+	first := c.TTSAsync(context.Background(), testRequest())
+	waitRunning(t, c.asyncDispatcher())
+	queued := c.TTSAsync(context.Background(), testRequest())
+
+	queued.Cancel()
+	select {
+	case <-queued.Done():
+	default:
+		t.Fatal("取消排队中的任务后 Done 应立即关闭")
+	}
+	resp, err := queued.Result()
+	if err != nil || !isContextError(resp.Error) {
+		t.Fatalf("结果 = %v, %v，期望 context.Canceled", resp.Error, err)
+	}
+
+	first.Cancel()
+	<-first.Done()
+}
+
+// waitRunning 等待调度器中有任务开始执行
+func waitRunning(t *testing.T, d *dispatcher) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		d.mu.Lock()
+		n := len(d.running)
+		d.mu.Unlock()
+		if n > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("等待任务开始执行超时")
+}
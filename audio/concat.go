@@ -0,0 +1,190 @@
+package audio
+
+// 提供多段音频的拼接
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ConcatOptions 代表拼接选项
+type ConcatOptions struct {
+	Gap time.Duration // 相邻片段之间插入的静音时长，负值按 0 处理
+
+	// Crossfade 为相邻片段的交叉淡化时长，用于消除硬切换的咔嗒声
+	// Gap 为0时相邻片段重叠该时长并以等功率曲线混合；Gap 非0时在片段与静音的交界处淡入淡出
+	// 时长超过片段长度时按较短片段截断
+	Crossfade time.Duration
+}
+
+// Span 代表片段在拼接结果中的时间位置
+type Span struct {
+	Start time.Duration // 起始时间
+	End   time.Duration // 结束时间
+}
+
+// Concat 按顺序拼接格式相同的多段音频
+func Concat(segments []*WAV, opts ConcatOptions) (*WAV, error) {
+	out, _, err := ConcatWithSpans(segments, opts)
+	return out, err
+}
+
+// ConcatWithSpans 按顺序拼接格式相同的多段音频，并返回每段在结果中的时间位置
+func ConcatWithSpans(segments []*WAV, opts ConcatOptions) (*WAV, []Span, error) {
+	if len(segments) == 0 {
+		return nil, nil, fmt.Errorf("没有可拼接的音频片段")
+	}
+
+	first := segments[0]
+	for i, seg := range segments {
+		if !seg.sameFormat(first) {
+			return nil, nil, fmt.Errorf("第 %d 段音频格式 (%dHz/%d声道/%d位) 与第 1 段 (%dHz/%d声道/%d位) 不一致",
+				i+1, seg.SampleRate, seg.Channels, seg.BitDepth, first.SampleRate, first.Channels, first.BitDepth)
+		}
+	}
+
+	if opts.Crossfade > 0 {
+		return crossfadeConcat(segments, opts)
+	}
+
+	out := &WAV{
+		SampleRate: first.SampleRate,
+		Channels:   first.Channels,
+		BitDepth:   first.BitDepth,
+	}
+
+	gap := make([]byte, out.bytesFor(opts.Gap))
+	spans := make([]Span, len(segments))
+	for i, seg := range segments {
+		if i > 0 {
+			out.Data = append(out.Data, gap...)
+		}
+		start := out.Duration()
+		out.Data = append(out.Data, seg.Data...)
+		spans[i] = Span{Start: start, End: out.Duration()}
+	}
+
+	return out, spans, nil
+}
+
+// crossfadeConcat 以交叉淡化方式拼接格式相同的多段音频
+func crossfadeConcat(segments []*WAV, opts ConcatOptions) (*WAV, []Span, error) {
+	first := segments[0]
+	ch := first.Channels
+	if ch <= 0 {
+		ch = 1
+	}
+	fadeFrames := int(opts.Crossfade * time.Duration(first.SampleRate) / time.Second)
+	gapFrames := int(opts.Gap * time.Duration(first.SampleRate) / time.Second)
+
+	var out []float64
+	spans := make([]Span, len(segments))
+	frameTime := func(frames int) time.Duration {
+		return time.Duration(frames) * time.Second / time.Duration(first.SampleRate)
+	}
+
+	prevFrames := 0
+	for i, seg := range segments {
+		s, err := seg.samples()
+		if err != nil {
+			return nil, nil, err
+		}
+		frames := len(s) / ch
+		outFrames := len(out) / ch
+
+		start := outFrames
+		switch {
+		case i == 0:
+			out = append(out, s...)
+		case gapFrames > 0:
+			// 与静音相接时，上一段结尾淡出、本段开头淡入
+			n := min(fadeFrames, prevFrames, frames)
+			fade(out[(outFrames-n)*ch:], n, ch, false)
+			fade(s, n, ch, true)
+			out = append(out, make([]float64, gapFrames*ch)...)
+			start = len(out) / ch
+			out = append(out, s...)
+		default:
+			// 重叠部分以等功率曲线混合
+			n := min(fadeFrames, prevFrames, frames)
+			start = outFrames - n
+			for f := 0; f < n; f++ {
+				gOut, gIn := fadeGain(f, n, false), fadeGain(f, n, true)
+				for c := 0; c < ch; c++ {
+					j := (start+f)*ch + c
+					out[j] = out[j]*gOut + s[f*ch+c]*gIn
+				}
+			}
+			out = append(out, s[n*ch:]...)
+		}
+
+		spans[i] = Span{Start: frameTime(start), End: frameTime(start + frames)}
+		prevFrames = frames
+	}
+
+	return first.withSamples(out), spans, nil
+}
+
+// fade 对 s 的前 n 帧应用等功率淡入或淡出
+func fade(s []float64, n, ch int, fadeIn bool) {
+	for f := 0; f < n; f++ {
+		g := fadeGain(f, n, fadeIn)
+		for c := 0; c < ch; c++ {
+			s[f*ch+c] *= g
+		}
+	}
+}
+
+// fadeGain 返回 n 帧等功率淡入或淡出曲线第 f 帧的增益
+func fadeGain(f, n int, fadeIn bool) float64 {
+	t := (float64(f) + 0.5) / float64(n)
+	if fadeIn {
+		return math.Sin(t * math.Pi / 2)
+	}
+	return math.Cos(t * math.Pi / 2)
+}
+
+// ConcatWAV 拼接多段 WAV 数据并返回带文件头的 WAV 数据
+func ConcatWAV(segments [][]byte, opts ConcatOptions) ([]byte, error) {
+	wavs := make([]*WAV, len(segments))
+	for i, data := range segments {
+		w, err := ParseWAV(data)
+		if err != nil {
+			return nil, fmt.Errorf("解析第 %d 段音频失败: %w", i+1, err)
+		}
+		wavs[i] = w
+	}
+
+	out, err := Concat(wavs, opts)
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// Silence 生成给定时长的静音 PCM 数据，时长为负时返回空数据
+func Silence(d time.Duration, sampleRate, channels, bitDepth int) []byte {
+	w := &WAV{SampleRate: sampleRate, Channels: channels, BitDepth: bitDepth}
+	return make([]byte, w.bytesFor(d))
+}
+
+// Slice 返回音频中 [start, end) 时间范围的片段，时间按最近的采样帧对齐，超出范围的部分被截断
+// 返回的片段共享 w 的 PCM 数据
+func Slice(w *WAV, start, end time.Duration) *WAV {
+	frame := w.frameSize()
+	frames := 0
+	if frame > 0 {
+		frames = len(w.Data) / frame
+	}
+	toFrame := func(d time.Duration) int {
+		f := int((d*time.Duration(w.SampleRate) + time.Second/2) / time.Second)
+		return max(0, min(f, frames))
+	}
+
+	from, to := toFrame(start), toFrame(end)
+	if to < from {
+		to = from
+	}
+	return &WAV{SampleRate: w.SampleRate, Channels: w.Channels, BitDepth: w.BitDepth, Data: w.Data[from*frame : to*frame]}
+}
@@ -0,0 +1,260 @@
+package audio
+
+// 提供可插拔的音频编码器与流式转码
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+)
+
+// Format 代表 PCM 音频格式
+type Format struct {
+	SampleRate int // 采样率
+	Channels   int // 声道数
+	BitDepth   int // 每个采样的位数
+}
+
+// Encoder 代表音频编码器，将 PCM 数据流编码为目标格式
+type Encoder interface {
+	// NewWriter 返回接收 PCM 数据的写入器，编码结果写入 dst，Close 时刷新剩余数据
+	NewWriter(dst io.Writer, f Format) (io.WriteCloser, error)
+	// ContentType 返回编码结果的 MIME 类型
+	ContentType() string
+}
+
+// TranscodeWAV 从 src 读取 WAV 数据流，边读边编码写入 dst
+// 兼容流式输出中 data 块长度未知的情况，此时读取直至数据流结束
+func TranscodeWAV(dst io.Writer, src io.Reader, enc Encoder) error {
+	br := bufio.NewReader(src)
+	f, size, err := readWAVHeader(br)
+	if err != nil {
+		return err
+	}
+
+	// data 块长度已知时只读取该长度
+	var pcm io.Reader = br
+	if size > 0 {
+		pcm = io.LimitReader(br, size)
+	}
+	return TranscodePCM(dst, pcm, f, enc)
+}
+
+// TranscodePCM 从 src 读取给定格式的 PCM 数据流（如 media_type=raw 的输出），边读边编码写入 dst
+func TranscodePCM(dst io.Writer, src io.Reader, f Format, enc Encoder) error {
+	w, err := enc.NewWriter(dst, f)
+	if err != nil {
+		return fmt.Errorf("创建编码器失败: %w", err)
+	}
+
+	if _, err := io.Copy(w, src); err != nil {
+		w.Close()
+		return fmt.Errorf("转码失败: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("转码失败: %w", err)
+	}
+	return nil
+}
+
+// readWAVHeader 从数据流读取 WAV 文件头直至 data 块起始位置，返回格式与 data 块长度（未知时为0）
+func readWAVHeader(r io.Reader) (Format, int64, error) {
+	var f Format
+
+	head := make([]byte, 12)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return f, 0, fmt.Errorf("读取 WAV 文件头失败: %w", err)
+	}
+	if string(head[0:4]) != "RIFF" || string(head[8:12]) != "WAVE" {
+		return f, 0, fmt.Errorf("不是有效的 WAV 数据")
+	}
+
+	gotFmt := false
+	chunk := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return f, 0, fmt.Errorf("WAV 数据中缺少 data 块: %w", err)
+		}
+		id := string(chunk[0:4])
+		size := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return f, 0, fmt.Errorf("WAV fmt 块不完整")
+			}
+			body := make([]byte, size+size%2)
+			if _, err := io.ReadFull(r, body); err != nil {
+				return f, 0, fmt.Errorf("WAV fmt 块不完整: %w", err)
+			}
+			if format := binary.LittleEndian.Uint16(body[0:2]); format != 1 && format != 0xFFFE {
+				return f, 0, fmt.Errorf("不支持的 WAV 编码格式 %d，仅支持 PCM", format)
+			}
+			f.Channels = int(binary.LittleEndian.Uint16(body[2:4]))
+			f.SampleRate = int(binary.LittleEndian.Uint32(body[4:8]))
+			f.BitDepth = int(binary.LittleEndian.Uint16(body[14:16]))
+			gotFmt = true
+		case "data":
+			if !gotFmt {
+				return f, 0, fmt.Errorf("WAV data 块位于 fmt 块之前")
+			}
+			// 流式输出常以 0 或 0xFFFFFFFF 表示长度未知
+			if size == 0xFFFFFFFF {
+				size = 0
+			}
+			return f, size, nil
+		default:
+			// 跳过其他块
+			if _, err := io.CopyN(io.Discard, r, size+size%2); err != nil {
+				return f, 0, fmt.Errorf("读取 WAV 文件头失败: %w", err)
+			}
+		}
+	}
+}
+
+// WAVEncoder 将 PCM 数据流封装为 WAV，文件头中的长度字段标记为未知，适用于边生成边播放
+type WAVEncoder struct{}
+
+// ContentType 实现 Encoder 接口
+func (WAVEncoder) ContentType() string { return "audio/wav" }
+
+// NewWriter 实现 Encoder 接口
+func (WAVEncoder) NewWriter(dst io.Writer, f Format) (io.WriteCloser, error) {
+	header := WrapPCMAsWAV(nil, f.SampleRate, f.Channels, f.BitDepth)
+	binary.LittleEndian.PutUint32(header[4:8], 0xFFFFFFFF)
+	binary.LittleEndian.PutUint32(header[40:44], 0xFFFFFFFF)
+	if _, err := dst.Write(header); err != nil {
+		return nil, err
+	}
+	return nopWriteCloser{dst}, nil
+}
+
+// nopWriteCloser 为 io.Writer 添加空操作的 Close
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close 实现 io.Closer 接口
+func (nopWriteCloser) Close() error { return nil }
+
+// CommandEncoder 通过外部 ffmpeg 进程编码为 MP3、Opus 等格式，无需 cgo
+type CommandEncoder struct {
+	Path      string   // ffmpeg 可执行文件路径，为空时从 PATH 查找
+	Format    string   // ffmpeg 输出容器格式，如 "mp3"、"ogg"、"webm"
+	Codec     string   // 音频编码器，如 "libmp3lame"、"libopus"，为空时由 ffmpeg 按格式选择
+	Bitrate   string   // 码率，如 "64k"，为空时使用编码器默认值
+	ExtraArgs []string // 追加在输出参数前的额外参数
+	MIMEType  string   // 编码结果的 MIME 类型
+}
+
+// MP3Encoder 返回输出 MP3 的编码器
+func MP3Encoder(bitrate string) *CommandEncoder {
+	return &CommandEncoder{Format: "mp3", Codec: "libmp3lame", Bitrate: bitrate, MIMEType: "audio/mpeg"}
+}
+
+// OpusEncoder 返回输出 Ogg Opus 的编码器，浏览器可直接播放
+func OpusEncoder(bitrate string) *CommandEncoder {
+	return &CommandEncoder{Format: "ogg", Codec: "libopus", Bitrate: bitrate, MIMEType: "audio/ogg; codecs=opus"}
+}
+
+// ContentType 实现 Encoder 接口
+func (e *CommandEncoder) ContentType() string {
+	if e.MIMEType != "" {
+		return e.MIMEType
+	}
+	return "application/octet-stream"
+}
+
+// NewWriter 实现 Encoder 接口，启动 ffmpeg 进程，Close 时等待进程退出
+func (e *CommandEncoder) NewWriter(dst io.Writer, f Format) (io.WriteCloser, error) {
+	if f.BitDepth != 16 {
+		return nil, fmt.Errorf("外部编码器仅支持 16 位 PCM，当前为 %d 位", f.BitDepth)
+	}
+	if e.Format == "" {
+		return nil, errors.New("未指定输出格式")
+	}
+
+	path := e.Path
+	if path == "" {
+		path = "ffmpeg"
+	}
+
+	// 输入为标准输入中的 16 位小端 PCM，输出写入标准输出
+	args := []string{
+		"-hide_banner", "-loglevel", "error",
+		"-f", "s16le", "-ar", strconv.Itoa(f.SampleRate), "-ac", strconv.Itoa(f.Channels),
+		"-i", "pipe:0",
+	}
+	if e.Codec != "" {
+		args = append(args, "-c:a", e.Codec)
+	}
+	if e.Bitrate != "" {
+		args = append(args, "-b:a", e.Bitrate)
+	}
+	args = append(args, e.ExtraArgs...)
+	args = append(args, "-f", e.Format, "pipe:1")
+
+	cmd := exec.Command(path, args...)
+	cmd.Stdout = dst
+	stderr := &limitedBuffer{max: 4096}
+	cmd.Stderr = stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("启动 %s 失败: %w", path, err)
+	}
+	return &commandWriter{stdin: stdin, cmd: cmd, stderr: stderr}, nil
+}
+
+// commandWriter 将 PCM 写入外部进程的标准输入
+type commandWriter struct {
+	stdin  io.WriteCloser
+	cmd    *exec.Cmd
+	stderr *limitedBuffer
+}
+
+// Write 实现 io.Writer 接口
+func (w *commandWriter) Write(p []byte) (int, error) {
+	return w.stdin.Write(p)
+}
+
+// Close 关闭标准输入并等待编码完成
+func (w *commandWriter) Close() error {
+	w.stdin.Close()
+	if err := w.cmd.Wait(); err != nil {
+		if msg := w.stderr.String(); msg != "" {
+			return fmt.Errorf("编码器退出异常: %w: %s", err, msg)
+		}
+		return fmt.Errorf("编码器退出异常: %w", err)
+	}
+	return nil
+}
+
+// limitedBuffer 只保留前 max 字节的输出，用于收集错误信息
+type limitedBuffer struct {
+	buf []byte
+	max int
+}
+
+// Write 实现 io.Writer 接口
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - len(b.buf); room > 0 {
+		if len(p) < room {
+			room = len(p)
+		}
+		b.buf = append(b.buf, p[:room]...)
+	}
+	return len(p), nil
+}
+
+// String 返回收集的输出
+func (b *limitedBuffer) String() string {
+	return string(b.buf)
+}
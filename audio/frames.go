@@ -0,0 +1,67 @@
+package audio
+
+// 提供将 PCM 数据切分为固定时长帧的迭代器，供 WebRTC、Discord 等实时音频管线使用
+
+import (
+	"errors"
+	"io"
+	"iter"
+	"time"
+)
+
+// FrameSize 返回 16 位 PCM 中给定时长的帧所占字节数，如 48kHz 双声道 20ms 为 3840 字节
+func FrameSize(frameDur time.Duration, sampleRate, channels int) int {
+	return int(int64(sampleRate)*int64(frameDur)/int64(time.Second)) * channels * 2
+}
+
+// Frames 将 16 位 PCM 数据切分为 frameDur 时长的帧，最后不足一帧的部分以静音补齐
+// 各帧共享 pcm 的底层数组（补齐帧除外），使用方需要修改时应自行复制；参数无效时不产生任何帧
+func Frames(pcm []byte, frameDur time.Duration, sampleRate, channels int) iter.Seq[[]byte] {
+	size := FrameSize(frameDur, sampleRate, channels)
+	return func(yield func([]byte) bool) {
+		if size <= 0 {
+			return
+		}
+		for off := 0; off < len(pcm); off += size {
+			if off+size > len(pcm) {
+				last := make([]byte, size)
+				copy(last, pcm[off:])
+				yield(last)
+				return
+			}
+			if !yield(pcm[off : off+size : off+size]) {
+				return
+			}
+		}
+	}
+}
+
+// StreamFrames 从 r 读取 16 位 PCM 数据流并逐帧产出，适用于边合成边播放
+// 每帧为新分配的切片；数据流结束时最后不足一帧的部分以静音补齐，读取出错时产出该错误并停止
+func StreamFrames(r io.Reader, frameDur time.Duration, sampleRate, channels int) iter.Seq2[[]byte, error] {
+	size := FrameSize(frameDur, sampleRate, channels)
+	return func(yield func([]byte, error) bool) {
+		if size <= 0 {
+			yield(nil, errors.New("帧长度无效"))
+			return
+		}
+		for {
+			frame := make([]byte, size)
+			n, err := io.ReadFull(r, frame)
+			switch {
+			case err == io.EOF:
+				return
+			case err == io.ErrUnexpectedEOF:
+				// 剩余部分已读入，其后为零值静音
+				yield(frame, nil)
+				return
+			case err != nil:
+				yield(frame[:n], err)
+				return
+			}
+			if !yield(frame, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package audio
+
+// 提供增益调整与响度归一化，用于统一不同音色模型的输出音量
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrSilent 表示音频为静音，无法归一化
+var ErrSilent = errors.New("音频为静音，无法归一化")
+
+// Gain 将音频整体调整 db 分贝，超出满刻度的采样被截断
+func Gain(w *WAV, db float64) (*WAV, error) {
+	s, err := w.samples()
+	if err != nil {
+		return nil, err
+	}
+
+	factor := dbToAmp(db)
+	for i := range s {
+		s[i] *= factor
+	}
+	return w.withSamples(s), nil
+}
+
+// PeakDB 返回采样峰值，单位为 dBFS，静音时返回负无穷
+func PeakDB(w *WAV) (float64, error) {
+	s, err := w.samples()
+	if err != nil {
+		return 0, err
+	}
+
+	peak := 0.0
+	for _, v := range s {
+		peak = math.Max(peak, math.Abs(v))
+	}
+	return ampToDB(peak), nil
+}
+
+// RMSDB 返回均方根电平，单位为 dBFS，静音时返回负无穷
+func RMSDB(w *WAV) (float64, error) {
+	s, err := w.samples()
+	if err != nil {
+		return 0, err
+	}
+	if len(s) == 0 {
+		return math.Inf(-1), nil
+	}
+
+	sum := 0.0
+	for _, v := range s {
+		sum += v * v
+	}
+	return ampToDB(math.Sqrt(sum / float64(len(s)))), nil
+}
+
+// LUFS 按 ITU-R BS.1770 计算积分响度（K 加权、400ms 块、绝对与相对门限），单位为 LUFS
+// 音频过短或全部低于门限时返回负无穷
+func LUFS(w *WAV) (float64, error) {
+	s, err := w.samples()
+	if err != nil {
+		return 0, err
+	}
+	if w.Channels <= 0 || w.SampleRate <= 0 {
+		return 0, errors.New("音频格式无效")
+	}
+
+	// 逐声道进行 K 加权滤波
+	frames := len(s) / w.Channels
+	weighted := make([][]float64, w.Channels)
+	for ch := range weighted {
+		x := make([]float64, frames)
+		for i := range x {
+			x[i] = s[i*w.Channels+ch]
+		}
+		weighted[ch] = kWeight(x, float64(w.SampleRate))
+	}
+
+	// 400ms 块，75% 重叠，计算各块的均方值（各声道求和）
+	block := int(0.4 * float64(w.SampleRate))
+	step := block / 4
+	if block == 0 || frames < block {
+		return math.Inf(-1), nil
+	}
+	var powers []float64
+	for start := 0; start+block <= frames; start += step {
+		p := 0.0
+		for ch := range weighted {
+			sum := 0.0
+			for _, v := range weighted[ch][start : start+block] {
+				sum += v * v
+			}
+			p += sum / float64(block)
+		}
+		powers = append(powers, p)
+	}
+
+	// 绝对门限 -70 LUFS
+	gated := gate(powers, lufsToPower(-70))
+	if len(gated) == 0 {
+		return math.Inf(-1), nil
+	}
+
+	// 相对门限：低于绝对门限后平均响度 10 LU 的块被排除
+	gated = gate(gated, mean(gated)/10)
+	if len(gated) == 0 {
+		return math.Inf(-1), nil
+	}
+	return powerToLUFS(mean(gated)), nil
+}
+
+// NormalizePeak 调整增益使采样峰值达到 targetDB（dBFS，如 -1）
+func NormalizePeak(w *WAV, targetDB float64) (*WAV, error) {
+	peak, err := PeakDB(w)
+	if err != nil {
+		return nil, err
+	}
+	return gainTo(w, peak, targetDB)
+}
+
+// NormalizeRMS 调整增益使均方根电平达到 targetDB（dBFS，如 -20），峰值超出满刻度时截断
+func NormalizeRMS(w *WAV, targetDB float64) (*WAV, error) {
+	rms, err := RMSDB(w)
+	if err != nil {
+		return nil, err
+	}
+	return gainTo(w, rms, targetDB)
+}
+
+// NormalizeLUFS 调整增益使积分响度达到 target（LUFS，语音常用 -16 至 -23），峰值超出满刻度时截断
+func NormalizeLUFS(w *WAV, target float64) (*WAV, error) {
+	l, err := LUFS(w)
+	if err != nil {
+		return nil, err
+	}
+	return gainTo(w, l, target)
+}
+
+// gainTo 按当前电平与目标电平之差调整增益
+func gainTo(w *WAV, current, target float64) (*WAV, error) {
+	if math.IsInf(current, -1) {
+		return nil, ErrSilent
+	}
+	return Gain(w, target-current)
+}
+
+// kWeight 对单声道信号应用 BS.1770 K 加权滤波（高架预滤波与 RLB 高通滤波）
+func kWeight(x []float64, fs float64) []float64 {
+	// 高架滤波器，按采样率由模拟原型经双线性变换得到
+	f0, g, q := 1681.974450955533, 3.999843853973347, 0.7071752369554196
+	k := math.Tan(math.Pi * f0 / fs)
+	vh := math.Pow(10, g/20)
+	vb := math.Pow(vh, 0.4996667741545416)
+	a0 := 1 + k/q + k*k
+	shelf := biquad{
+		b0: (vh + vb*k/q + k*k) / a0,
+		b1: 2 * (k*k - vh) / a0,
+		b2: (vh - vb*k/q + k*k) / a0,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+
+	// RLB 高通滤波器
+	f0, q = 38.13547087602444, 0.5003270373238773
+	k = math.Tan(math.Pi * f0 / fs)
+	a0 = 1 + k/q + k*k
+	highpass := biquad{
+		b0: 1,
+		b1: -2,
+		b2: 1,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+
+	return highpass.apply(shelf.apply(x))
+}
+
+// biquad 代表归一化的二阶 IIR 滤波器
+type biquad struct {
+	b0, b1, b2, a1, a2 float64
+}
+
+// apply 对信号进行滤波并返回新信号
+func (f biquad) apply(x []float64) []float64 {
+	y := make([]float64, len(x))
+	var x1, x2, y1, y2 float64
+	for i, v := range x {
+		out := f.b0*v + f.b1*x1 + f.b2*x2 - f.a1*y1 - f.a2*y2
+		x2, x1 = x1, v
+		y2, y1 = y1, out
+		y[i] = out
+	}
+	return y
+}
+
+// gate 返回大于门限的值
+func gate(powers []float64, threshold float64) []float64 {
+	var out []float64
+	for _, p := range powers {
+		if p > threshold {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// mean 返回平均值
+func mean(v []float64) float64 {
+	sum := 0.0
+	for _, x := range v {
+		sum += x
+	}
+	return sum / float64(len(v))
+}
+
+// lufsToPower 将响度换算为均方值
+func lufsToPower(l float64) float64 {
+	return math.Pow(10, (l+0.691)/10)
+}
+
+// powerToLUFS 将均方值换算为响度
+func powerToLUFS(p float64) float64 {
+	return -0.691 + 10*math.Log10(p)
+}
+
+// dbToAmp 将分贝换算为幅度倍数
+func dbToAmp(db float64) float64 {
+	return math.Pow(10, db/20)
+}
+
+// ampToDB 将幅度换算为分贝，0 返回负无穷
+func ampToDB(a float64) float64 {
+	if a <= 0 {
+		return math.Inf(-1)
+	}
+	return 20 * math.Log10(a)
+}
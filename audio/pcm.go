@@ -0,0 +1,67 @@
+package audio
+
+// 提供 PCM 采样与浮点数之间的转换
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// samples 将 PCM 数据解码为 [-1, 1] 区间的浮点采样（多声道交错存储）
+func (w *WAV) samples() ([]float64, error) {
+	size := w.BitDepth / 8
+	if err := checkBitDepth(w.BitDepth); err != nil {
+		return nil, err
+	}
+
+	n := len(w.Data) / size
+	out := make([]float64, n)
+	for i := 0; i < n; i++ {
+		b := w.Data[i*size : (i+1)*size]
+		switch w.BitDepth {
+		case 8:
+			out[i] = (float64(b[0]) - 128) / 128
+		case 16:
+			out[i] = float64(int16(binary.LittleEndian.Uint16(b))) / 32768
+		case 24:
+			v := int32(b[0]) | int32(b[1])<<8 | int32(int8(b[2]))<<16
+			out[i] = float64(v) / 8388608
+		case 32:
+			out[i] = float64(int32(binary.LittleEndian.Uint32(b))) / 2147483648
+		}
+	}
+	return out, nil
+}
+
+// withSamples 返回格式与 w 相同、数据为给定浮点采样的新音频，超出 [-1, 1] 的采样被截断
+func (w *WAV) withSamples(s []float64) *WAV {
+	size := w.BitDepth / 8
+	data := make([]byte, len(s)*size)
+	for i, v := range s {
+		v = math.Max(-1, math.Min(1, v))
+		b := data[i*size : (i+1)*size]
+		switch w.BitDepth {
+		case 8:
+			b[0] = uint8(math.Round(v*127) + 128)
+		case 16:
+			binary.LittleEndian.PutUint16(b, uint16(int16(math.Round(v*32767))))
+		case 24:
+			x := int32(math.Round(v * 8388607))
+			b[0], b[1], b[2] = byte(x), byte(x>>8), byte(x>>16)
+		case 32:
+			binary.LittleEndian.PutUint32(b, uint32(int32(math.Round(v*2147483647))))
+		}
+	}
+	return &WAV{SampleRate: w.SampleRate, Channels: w.Channels, BitDepth: w.BitDepth, Data: data}
+}
+
+// checkBitDepth 校验是否为支持处理的采样位数
+func checkBitDepth(bitDepth int) error {
+	switch bitDepth {
+	case 8, 16, 24, 32:
+		return nil
+	default:
+		return fmt.Errorf("不支持的采样位数 %d", bitDepth)
+	}
+}
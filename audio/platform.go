@@ -0,0 +1,133 @@
+package audio
+
+// 提供格式转换以及 Telegram、Discord 等平台所需的音频输出
+
+import (
+	"fmt"
+	"io"
+)
+
+// Telegram 语音消息与 Discord 语音通道的音频格式
+const (
+	TelegramSampleRate  = 48000 // Telegram 语音消息采样率
+	DiscordSampleRate   = 48000 // Discord 语音采样率
+	DiscordChannels     = 2     // Discord 语音声道数
+	DiscordFrameSamples = 960   // Discord 每帧（20ms）的单声道采样数
+)
+
+// Convert 将音频转换为给定的采样率、声道数与采样位数，零值字段保持原值
+// 多声道转单声道时取平均，单声道转多声道时复制到各声道
+func Convert(w *WAV, f Format, method ResampleMethod) (*WAV, error) {
+	if f.SampleRate == 0 {
+		f.SampleRate = w.SampleRate
+	}
+	if f.Channels == 0 {
+		f.Channels = w.Channels
+	}
+	if f.BitDepth == 0 {
+		f.BitDepth = w.BitDepth
+	}
+	if err := checkBitDepth(f.BitDepth); err != nil {
+		return nil, err
+	}
+	if f.Channels <= 0 || f.SampleRate <= 0 {
+		return nil, fmt.Errorf("目标格式无效: %d Hz，%d 声道", f.SampleRate, f.Channels)
+	}
+	if f == (Format{SampleRate: w.SampleRate, Channels: w.Channels, BitDepth: w.BitDepth}) {
+		return w, nil
+	}
+
+	s, err := w.samples()
+	if err != nil {
+		return nil, err
+	}
+
+	// 先调整声道，再重采样
+	s = remix(s, w.Channels, f.Channels)
+	if f.SampleRate != w.SampleRate {
+		s = resample(s, f.Channels, float64(f.SampleRate)/float64(w.SampleRate), method)
+	}
+
+	out := &WAV{SampleRate: f.SampleRate, Channels: f.Channels, BitDepth: f.BitDepth}
+	return out.withSamples(s), nil
+}
+
+// remix 在声道数之间转换交错存储的采样
+func remix(s []float64, from, to int) []float64 {
+	if from == to || from <= 0 {
+		return s
+	}
+
+	frames := len(s) / from
+	out := make([]float64, frames*to)
+	for i := 0; i < frames; i++ {
+		frame := s[i*from : (i+1)*from]
+		switch {
+		case to < from && to == 1:
+			// 下混为单声道
+			var sum float64
+			for _, v := range frame {
+				sum += v
+			}
+			out[i] = sum / float64(from)
+		case from == 1:
+			// 单声道复制到各声道
+			for c := 0; c < to; c++ {
+				out[i*to+c] = frame[0]
+			}
+		default:
+			// 其余情况按声道序号循环映射
+			for c := 0; c < to; c++ {
+				out[i*to+c] = frame[c%from]
+			}
+		}
+	}
+	return out
+}
+
+// TelegramVoiceEncoder 返回输出 Telegram 语音消息（Ogg Opus）的编码器
+func TelegramVoiceEncoder() *CommandEncoder {
+	return &CommandEncoder{
+		Format:    "ogg",
+		Codec:     "libopus",
+		Bitrate:   "32k",
+		ExtraArgs: []string{"-application", "voip"},
+		MIMEType:  "audio/ogg",
+	}
+}
+
+// TelegramVoice 将音频转换为 48kHz 单声道并编码为 Ogg Opus 写入 dst，可直接作为 sendVoice 的 voice 上传
+// 编码依赖 ffmpeg
+func TelegramVoice(dst io.Writer, w *WAV) error {
+	pcm, err := Convert(w, Format{SampleRate: TelegramSampleRate, Channels: 1, BitDepth: 16}, ResampleSinc)
+	if err != nil {
+		return err
+	}
+
+	enc, err := TelegramVoiceEncoder().NewWriter(dst, Format{SampleRate: pcm.SampleRate, Channels: pcm.Channels, BitDepth: pcm.BitDepth})
+	if err != nil {
+		return err
+	}
+	if _, err := enc.Write(pcm.Data); err != nil {
+		enc.Close()
+		return fmt.Errorf("写入编码器失败: %w", err)
+	}
+	return enc.Close()
+}
+
+// DiscordPCM 将音频转换为 Discord 语音所需的 48kHz 双声道 16 位 PCM
+// 末尾以静音补齐为完整的 20ms 帧，每帧 DiscordFrameSamples*DiscordChannels 个采样，可逐帧交给 Opus 编码器
+func DiscordPCM(w *WAV) (*WAV, error) {
+	out, err := Convert(w, Format{SampleRate: DiscordSampleRate, Channels: DiscordChannels, BitDepth: 16}, ResampleSinc)
+	if err != nil {
+		return nil, err
+	}
+
+	frameBytes := DiscordFrameSamples * DiscordChannels * 2
+	if rem := len(out.Data) % frameBytes; rem != 0 {
+		data := make([]byte, len(out.Data)+frameBytes-rem)
+		copy(data, out.Data)
+		out = &WAV{SampleRate: out.SampleRate, Channels: out.Channels, BitDepth: out.BitDepth, Data: data}
+	}
+	return out, nil
+}
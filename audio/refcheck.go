@@ -0,0 +1,248 @@
+package audio
+
+// 提供参考音频的本地检查，在用作 ref_audio_path 前给出可操作的修改建议
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+)
+
+// RefSeverity 代表参考音频问题的严重程度
+type RefSeverity int
+
+const (
+	RefWarning RefSeverity = iota // 可以使用，但可能影响克隆效果
+	RefError                      // 服务端会拒绝或克隆效果必然很差
+)
+
+// String 返回严重程度名称
+func (s RefSeverity) String() string {
+	if s == RefError {
+		return "error"
+	}
+	return "warning"
+}
+
+// 参考音频问题代码
+const (
+	RefTooShort        = "too_short"        // 时长过短
+	RefTooLong         = "too_long"         // 时长过长
+	RefLowSampleRate   = "low_sample_rate"  // 采样率过低
+	RefNotMono         = "not_mono"         // 非单声道
+	RefClipping        = "clipping"         // 存在削波失真
+	RefSilent          = "silent"           // 全部为静音
+	RefTooQuiet        = "too_quiet"        // 音量过低
+	RefTooMuchSilence  = "too_much_silence" // 静音占比过高
+	RefLeadingSilence  = "leading_silence"  // 开头静音过长
+	RefTrailingSilence = "trailing_silence" // 结尾静音过长
+)
+
+// RefIssue 代表参考音频检查发现的一个问题
+type RefIssue struct {
+	Code     string      // 问题代码，如 RefTooShort
+	Severity RefSeverity // 严重程度
+	Message  string      // 问题说明与修改建议
+}
+
+// RefCheckOptions 代表参考音频检查选项，零值字段使用默认值
+type RefCheckOptions struct {
+	MinDuration     time.Duration // 最短时长，默认 3 秒（GPT-SoVITS 拒绝更短的参考音频）
+	MaxDuration     time.Duration // 最长时长，默认 10 秒
+	MinSampleRate   int           // 最低采样率，默认 16000
+	ClipRatio       float64       // 削波采样占比上限，默认 0.001
+	SilenceDB       float64       // 静音门限（dBFS），默认 -45
+	MinRMSDB        float64       // 最低均方根电平（dBFS），默认 -35
+	MaxSilenceRatio float64       // 静音占比上限，默认 0.4
+	MaxEdgeSilence  time.Duration // 首尾静音的最长时长，默认 1 秒
+}
+
+// withDefaults 返回填充默认值后的选项
+func (o *RefCheckOptions) withDefaults() RefCheckOptions {
+	var out RefCheckOptions
+	if o != nil {
+		out = *o
+	}
+	if out.MinDuration <= 0 {
+		out.MinDuration = 3 * time.Second
+	}
+	if out.MaxDuration <= 0 {
+		out.MaxDuration = 10 * time.Second
+	}
+	if out.MinSampleRate <= 0 {
+		out.MinSampleRate = 16000
+	}
+	if out.ClipRatio <= 0 {
+		out.ClipRatio = 0.001
+	}
+	if out.SilenceDB == 0 {
+		out.SilenceDB = -45
+	}
+	if out.MinRMSDB == 0 {
+		out.MinRMSDB = -35
+	}
+	if out.MaxSilenceRatio <= 0 {
+		out.MaxSilenceRatio = 0.4
+	}
+	if out.MaxEdgeSilence <= 0 {
+		out.MaxEdgeSilence = time.Second
+	}
+	return out
+}
+
+// RefReport 代表参考音频的检查结果
+type RefReport struct {
+	Duration        time.Duration // 时长
+	SampleRate      int           // 采样率
+	Channels        int           // 声道数
+	PeakDB          float64       // 采样峰值（dBFS）
+	RMSDB           float64       // 均方根电平（dBFS）
+	ClipRatio       float64       // 削波采样占比
+	SilenceRatio    float64       // 静音占比
+	LeadingSilence  time.Duration // 开头静音时长
+	TrailingSilence time.Duration // 结尾静音时长
+	Issues          []RefIssue    // 发现的问题
+}
+
+// OK 判断参考音频是否没有错误级别的问题
+func (r *RefReport) OK() bool {
+	for _, is := range r.Issues {
+		if is.Severity == RefError {
+			return false
+		}
+	}
+	return true
+}
+
+// Err 在存在错误级别的问题时返回汇总这些问题的错误，否则返回 nil
+func (r *RefReport) Err() error {
+	var msgs []string
+	for _, is := range r.Issues {
+		if is.Severity == RefError {
+			msgs = append(msgs, is.Message)
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.New("参考音频不可用: " + strings.Join(msgs, "；"))
+}
+
+// add 追加一个问题
+func (r *RefReport) add(code string, severity RefSeverity, format string, args ...any) {
+	r.Issues = append(r.Issues, RefIssue{Code: code, Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+// refWindow 为静音检测的分析窗口时长
+const refWindow = 20 * time.Millisecond
+
+// CheckReference 检查音频是否适合作为参考音频，opts 为 nil 时使用默认选项
+func CheckReference(w *WAV, opts *RefCheckOptions) (*RefReport, error) {
+	o := opts.withDefaults()
+	s, err := w.samples()
+	if err != nil {
+		return nil, err
+	}
+
+	ch := max(w.Channels, 1)
+	r := &RefReport{Duration: w.Duration(), SampleRate: w.SampleRate, Channels: w.Channels, PeakDB: math.Inf(-1), RMSDB: math.Inf(-1)}
+
+	// 格式与时长
+	switch {
+	case r.Duration < o.MinDuration:
+		r.add(RefTooShort, RefError, "时长 %.1f 秒，短于 %.0f 秒，请录制更长的完整语句", r.Duration.Seconds(), o.MinDuration.Seconds())
+	case r.Duration > o.MaxDuration:
+		r.add(RefTooLong, RefError, "时长 %.1f 秒，超过 %.0f 秒，请截取其中一句完整的话", r.Duration.Seconds(), o.MaxDuration.Seconds())
+	}
+	if w.SampleRate < o.MinSampleRate {
+		r.add(RefLowSampleRate, RefWarning, "采样率 %d Hz 低于 %d Hz，音质会明显下降，请使用更高采样率的原始录音", w.SampleRate, o.MinSampleRate)
+	}
+	if w.Channels > 1 {
+		r.add(RefNotMono, RefWarning, "音频为 %d 声道，建议转换为单声道，避免声道间的相位差影响音色", w.Channels)
+	}
+	if len(s) == 0 {
+		r.add(RefSilent, RefError, "音频为空")
+		return r, nil
+	}
+
+	// 峰值、电平与削波
+	peak, sum, clipped := 0.0, 0.0, 0
+	for _, v := range s {
+		a := math.Abs(v)
+		peak = math.Max(peak, a)
+		sum += v * v
+		if a >= 0.999 {
+			clipped++
+		}
+	}
+	r.PeakDB = ampToDB(peak)
+	r.RMSDB = ampToDB(math.Sqrt(sum / float64(len(s))))
+	r.ClipRatio = float64(clipped) / float64(len(s))
+	if r.ClipRatio > o.ClipRatio {
+		r.add(RefClipping, RefWarning, "%.2f%% 的采样发生削波，请降低录音增益后重新录制", r.ClipRatio*100)
+	}
+
+	// 按窗口检测静音
+	frames := len(s) / ch
+	win := max(int(refWindow*time.Duration(w.SampleRate)/time.Second), 1)
+	threshold := dbToAmp(o.SilenceDB)
+	var silent []bool
+	for start := 0; start < frames; start += win {
+		end := min(start+win, frames)
+		e := 0.0
+		for _, v := range s[start*ch : end*ch] {
+			e += v * v
+		}
+		silent = append(silent, math.Sqrt(e/float64((end-start)*ch)) < threshold)
+	}
+
+	quiet, lead, trail := 0, 0, 0
+	for _, q := range silent {
+		if q {
+			quiet++
+		}
+	}
+	for lead < len(silent) && silent[lead] {
+		lead++
+	}
+	for trail < len(silent)-lead && silent[len(silent)-1-trail] {
+		trail++
+	}
+	if quiet == len(silent) {
+		r.add(RefSilent, RefError, "音频全部为静音，请检查录音设备")
+		return r, nil
+	}
+
+	r.SilenceRatio = float64(quiet) / float64(len(silent))
+	r.LeadingSilence = time.Duration(lead) * refWindow
+	r.TrailingSilence = time.Duration(trail) * refWindow
+	if r.RMSDB < o.MinRMSDB {
+		r.add(RefTooQuiet, RefWarning, "平均电平 %.1f dBFS 过低，请靠近麦克风录音或先进行响度归一化", r.RMSDB)
+	}
+	if r.SilenceRatio > o.MaxSilenceRatio {
+		r.add(RefTooMuchSilence, RefWarning, "静音占比 %.0f%%，请去除句间的长停顿", r.SilenceRatio*100)
+	}
+	if r.LeadingSilence > o.MaxEdgeSilence {
+		r.add(RefLeadingSilence, RefWarning, "开头有 %.1f 秒静音，请使用 Trim 裁剪", r.LeadingSilence.Seconds())
+	}
+	if r.TrailingSilence > o.MaxEdgeSilence {
+		r.add(RefTrailingSilence, RefWarning, "结尾有 %.1f 秒静音，请使用 Trim 裁剪", r.TrailingSilence.Seconds())
+	}
+	return r, nil
+}
+
+// CheckReferenceFile 读取 WAV 文件并检查是否适合作为参考音频
+func CheckReferenceFile(path string, opts *RefCheckOptions) (*RefReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取参考音频失败: %w", err)
+	}
+	w, err := ParseWAV(data)
+	if err != nil {
+		return nil, err
+	}
+	return CheckReference(w, opts)
+}
@@ -0,0 +1,124 @@
+package audio
+
+// 提供参考音频预处理，将任意 WAV 转换为 GPT-SoVITS 适用的参考音频格式
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"time"
+)
+
+// RefPrepareOptions 代表参考音频预处理选项，零值字段使用默认值
+type RefPrepareOptions struct {
+	SampleRate  int            // 输出采样率，默认 32000，44100 亦可
+	MaxDuration time.Duration  // 输出的最长时长，默认 9.5 秒，超出时在靠近上限的停顿处截断
+	TrimDB      float64        // 首尾静音裁剪门限（dBFS），默认 -45
+	PeakDB      float64        // 峰值归一化目标（dBFS），默认 -1
+	Resample    ResampleMethod // 重采样算法
+}
+
+// withDefaults 返回填充默认值后的选项
+func (o *RefPrepareOptions) withDefaults() RefPrepareOptions {
+	var out RefPrepareOptions
+	if o != nil {
+		out = *o
+	}
+	if out.SampleRate <= 0 {
+		out.SampleRate = 32000
+	}
+	if out.MaxDuration <= 0 {
+		out.MaxDuration = 9500 * time.Millisecond
+	}
+	if out.TrimDB == 0 {
+		out.TrimDB = -45
+	}
+	if out.PeakDB == 0 {
+		out.PeakDB = -1
+	}
+	return out
+}
+
+// refCutFade 为硬截断时末尾淡出的时长
+const refCutFade = 30 * time.Millisecond
+
+// PrepareReference 将音频转换为单声道 16 位、给定采样率，裁剪首尾静音，限制时长并进行峰值归一化
+// opts 为 nil 时使用默认选项；音频全部为静音时返回 ErrSilent
+func PrepareReference(w *WAV, opts *RefPrepareOptions) (*WAV, error) {
+	o := opts.withDefaults()
+
+	out, err := Convert(w, Format{SampleRate: o.SampleRate, Channels: 1, BitDepth: 16}, o.Resample)
+	if err != nil {
+		return nil, err
+	}
+	if out, err = Trim(out, TrimOptions{ThresholdDB: o.TrimDB, Padding: defaultTrimPadding}); err != nil {
+		return nil, err
+	}
+	if len(out.Data) == 0 {
+		return nil, ErrSilent
+	}
+
+	if out.Duration() > o.MaxDuration {
+		if out, err = cutAtPause(out, o.MaxDuration, o.TrimDB); err != nil {
+			return nil, err
+		}
+	}
+
+	if out, err = NormalizePeak(out, o.PeakDB); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// cutAtPause 将单声道音频截断到 limit 以内，优先在后半段最靠后的停顿处截断，找不到停顿时硬截断并淡出
+func cutAtPause(w *WAV, limit time.Duration, silenceDB float64) (*WAV, error) {
+	s, err := w.samples()
+	if err != nil {
+		return nil, err
+	}
+
+	win := max(int(refWindow*time.Duration(w.SampleRate)/time.Second), 1)
+	end := min(int(limit*time.Duration(w.SampleRate)/time.Second), len(s))
+	threshold := dbToAmp(silenceDB)
+
+	// 从上限处向前查找静音窗口，且不早于上限的一半
+	cut := -1
+	for start := end - win; start >= end/2; start -= win {
+		e := 0.0
+		for _, v := range s[start : start+win] {
+			e += v * v
+		}
+		if math.Sqrt(e/float64(win)) < threshold {
+			cut = start + win/2
+			break
+		}
+	}
+
+	if cut < 0 {
+		cut = end
+		n := min(int(refCutFade*time.Duration(w.SampleRate)/time.Second), cut)
+		fade(s[cut-n:cut], n, 1, false)
+	}
+	return w.withSamples(s[:cut]), nil
+}
+
+// PrepareReferenceFile 读取 WAV 文件进行预处理并写入 dst
+func PrepareReferenceFile(src, dst string, opts *RefPrepareOptions) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("读取参考音频失败: %w", err)
+	}
+	w, err := ParseWAV(data)
+	if err != nil {
+		return err
+	}
+
+	out, err := PrepareReference(w, opts)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(dst, out.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("写入参考音频失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package audio
+
+// 提供与 speed_factor 无关的后处理变速、变调与重采样
+
+import (
+	"fmt"
+	"math"
+)
+
+// ResampleMethod 代表重采样算法
+type ResampleMethod int
+
+const (
+	ResampleLinear ResampleMethod = iota // 线性插值，速度快，高频有混叠
+	ResampleSinc                         // 加窗 sinc 插值，质量高，速度较慢
+)
+
+// StretchMethod 代表时间伸缩算法
+type StretchMethod int
+
+const (
+	StretchWSOLA StretchMethod = iota // 波形相似叠加，语音效果较好
+	StretchOLA                        // 简单叠加，速度快，可能有相位失真
+)
+
+// sincHalfWidth 为 sinc 插值每侧使用的过零点数
+const sincHalfWidth = 16
+
+// Resample 将音频转换为给定采样率，时长与音高不变
+func Resample(w *WAV, sampleRate int, method ResampleMethod) (*WAV, error) {
+	if sampleRate <= 0 {
+		return nil, fmt.Errorf("采样率无效: %d", sampleRate)
+	}
+	if sampleRate == w.SampleRate {
+		return w, nil
+	}
+
+	s, err := w.samples()
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := float64(sampleRate) / float64(w.SampleRate)
+	out := w.withSamples(resample(s, w.Channels, ratio, method))
+	out.SampleRate = sampleRate
+	return out, nil
+}
+
+// ChangeSpeed 以变速变调的方式调整播放速度，factor 大于 1 时加快且音高升高
+func ChangeSpeed(w *WAV, factor float64, method ResampleMethod) (*WAV, error) {
+	if factor <= 0 {
+		return nil, fmt.Errorf("速度倍数无效: %v", factor)
+	}
+
+	s, err := w.samples()
+	if err != nil {
+		return nil, err
+	}
+	return w.withSamples(resample(s, w.Channels, 1/factor, method)), nil
+}
+
+// TimeStretch 调整速度而保持音高不变，factor 大于 1 时加快
+func TimeStretch(w *WAV, factor float64, method StretchMethod) (*WAV, error) {
+	if factor <= 0 {
+		return nil, fmt.Errorf("速度倍数无效: %v", factor)
+	}
+
+	s, err := w.samples()
+	if err != nil {
+		return nil, err
+	}
+	return w.withSamples(stretch(s, w.Channels, w.SampleRate, factor, method)), nil
+}
+
+// PitchShift 调整音高而保持时长不变，semitones 为升高的半音数，可为负数
+func PitchShift(w *WAV, semitones float64, method StretchMethod) (*WAV, error) {
+	s, err := w.samples()
+	if err != nil {
+		return nil, err
+	}
+
+	// 先按音高比例拉长时长，再重采样回原时长
+	ratio := math.Pow(2, semitones/12)
+	stretched := stretch(s, w.Channels, w.SampleRate, 1/ratio, method)
+	return w.withSamples(resample(stretched, w.Channels, 1/ratio, ResampleSinc)), nil
+}
+
+// resample 按比例（输出长度/输入长度）对交错存储的多声道采样重采样
+func resample(s []float64, ch int, ratio float64, method ResampleMethod) []float64 {
+	if ch <= 0 {
+		ch = 1
+	}
+	inFrames := len(s) / ch
+	outFrames := int(math.Round(float64(inFrames) * ratio))
+	out := make([]float64, outFrames*ch)
+
+	// 降采样时降低截止频率以避免混叠
+	cutoff := math.Min(1, ratio)
+
+	at := func(frame, c int) float64 {
+		if frame < 0 || frame >= inFrames {
+			return 0
+		}
+		return s[frame*ch+c]
+	}
+
+	for i := 0; i < outFrames; i++ {
+		pos := float64(i) / ratio
+		base := int(math.Floor(pos))
+		frac := pos - float64(base)
+
+		for c := 0; c < ch; c++ {
+			switch method {
+			case ResampleSinc:
+				// 加 Hann 窗的 sinc 插值
+				sum, norm := 0.0, 0.0
+				for k := -sincHalfWidth + 1; k <= sincHalfWidth; k++ {
+					x := (float64(k) - frac) * cutoff
+					win := 0.5 + 0.5*math.Cos(math.Pi*(float64(k)-frac)/sincHalfWidth)
+					g := sinc(x) * win
+					sum += at(base+k, c) * g
+					norm += g
+				}
+				if norm != 0 {
+					out[i*ch+c] = sum / norm
+				}
+			default:
+				out[i*ch+c] = at(base, c)*(1-frac) + at(base+1, c)*frac
+			}
+		}
+	}
+	return out
+}
+
+// sinc 返回归一化 sinc 函数值
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+// stretch 以叠加方式对交错存储的多声道采样进行时间伸缩，factor 大于 1 时输出变短
+func stretch(s []float64, ch, sampleRate int, factor float64, method StretchMethod) []float64 {
+	if ch <= 0 {
+		ch = 1
+	}
+	inFrames := len(s) / ch
+
+	// 20ms 窗，50% 重叠
+	n := sampleRate / 50
+	if n < 2 || inFrames < n {
+		return append([]float64(nil), s...)
+	}
+	hs := n / 2
+	ha := float64(hs) * factor
+
+	// WSOLA 在 ±10ms 范围内寻找与上一帧自然延续最相似的位置
+	tolerance := 0
+	if method == StretchWSOLA {
+		tolerance = n / 2
+	}
+
+	// 单声道混音用于相似度计算
+	mono := make([]float64, inFrames)
+	for i := range mono {
+		for c := 0; c < ch; c++ {
+			mono[i] += s[i*ch+c]
+		}
+	}
+
+	outFrames := int(float64(inFrames)/factor) + n
+	out := make([]float64, outFrames*ch)
+	weight := make([]float64, outFrames)
+	window := make([]float64, n)
+	for i := range window {
+		window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(n))
+	}
+
+	prev := 0
+	for k := 0; ; k++ {
+		outPos := k * hs
+		target := int(float64(k) * ha)
+		if target+n > inFrames || outPos+n > outFrames {
+			break
+		}
+
+		// 寻找最佳偏移
+		pos := target
+		if k > 0 && tolerance > 0 {
+			natural := prev + hs
+			best := math.Inf(-1)
+			for d := -tolerance; d <= tolerance; d++ {
+				cand := target + d
+				if cand < 0 || cand+n > inFrames || natural+n > inFrames {
+					continue
+				}
+				corr := 0.0
+				for i := 0; i < n; i++ {
+					corr += mono[cand+i] * mono[natural+i]
+				}
+				if corr > best {
+					best, pos = corr, cand
+				}
+			}
+		}
+
+		// 加窗叠加
+		for i := 0; i < n; i++ {
+			for c := 0; c < ch; c++ {
+				out[(outPos+i)*ch+c] += s[(pos+i)*ch+c] * window[i]
+			}
+			weight[outPos+i] += window[i]
+		}
+		prev = pos
+	}
+
+	// 按窗函数权重归一化并截去末尾空白
+	last := 0
+	for i, wt := range weight {
+		if wt > 1e-6 {
+			for c := 0; c < ch; c++ {
+				out[i*ch+c] /= wt
+			}
+			last = i + 1
+		}
+	}
+	return out[:last*ch]
+}
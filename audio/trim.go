@@ -0,0 +1,88 @@
+package audio
+
+// 提供首尾静音裁剪
+
+import (
+	"math"
+	"time"
+)
+
+// defaultTrimPadding 为裁剪后保留的默认静音时长
+const defaultTrimPadding = 50 * time.Millisecond
+
+// TrimOptions 代表静音裁剪选项
+type TrimOptions struct {
+	ThresholdDB float64       // 静音门限（dBFS，如 -40），幅度低于门限的采样视为静音
+	Padding     time.Duration // 裁剪后在首尾保留的静音时长，避免截断字音的起止
+	KeepLeading bool          // 保留开头的静音，仅裁剪结尾
+}
+
+// TrimSilence 裁剪 WAV 数据首尾的静音，保留 50ms 余量，返回带文件头的 WAV 数据
+func TrimSilence(wav []byte, thresholdDB float64) ([]byte, error) {
+	w, err := ParseWAV(wav)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := Trim(w, TrimOptions{ThresholdDB: thresholdDB, Padding: defaultTrimPadding})
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// Trim 按选项裁剪音频首尾的静音，全部为静音时返回空音频
+func Trim(w *WAV, opts TrimOptions) (*WAV, error) {
+	s, err := w.samples()
+	if err != nil {
+		return nil, err
+	}
+
+	ch := w.Channels
+	if ch <= 0 {
+		ch = 1
+	}
+	frames := len(s) / ch
+	threshold := dbToAmp(opts.ThresholdDB)
+
+	// loud 判断某一帧是否有任一声道超过门限
+	loud := func(frame int) bool {
+		for c := 0; c < ch; c++ {
+			if math.Abs(s[frame*ch+c]) > threshold {
+				return true
+			}
+		}
+		return false
+	}
+
+	// 查找首尾第一个非静音帧
+	first, last := -1, -1
+	for i := 0; i < frames; i++ {
+		if loud(i) {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return &WAV{SampleRate: w.SampleRate, Channels: w.Channels, BitDepth: w.BitDepth}, nil
+	}
+	for i := frames - 1; i >= first; i-- {
+		if loud(i) {
+			last = i
+			break
+		}
+	}
+
+	// 保留余量
+	pad := int(opts.Padding * time.Duration(w.SampleRate) / time.Second)
+	start := max(first-pad, 0)
+	if opts.KeepLeading {
+		start = 0
+	}
+	end := min(last+1+pad, frames)
+
+	frameSize := w.frameSize()
+	out := &WAV{SampleRate: w.SampleRate, Channels: w.Channels, BitDepth: w.BitDepth}
+	out.Data = append([]byte(nil), w.Data[start*frameSize:end*frameSize]...)
+	return out, nil
+}
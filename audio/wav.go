@@ -0,0 +1,133 @@
+// Package audio 提供对 GPT-SoVITS 输出音频的处理工具
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// wavHeaderSize 为标准 PCM WAV 文件头的长度
+const wavHeaderSize = 44
+
+// WrapPCMAsWAV 为无文件头的 PCM 数据（如 media_type=raw 的输出）添加 WAV 文件头
+// bitDepth 为每个采样的位数，GPT-SoVITS 的 raw 输出为 16 位
+func WrapPCMAsWAV(pcm []byte, sampleRate, channels, bitDepth int) []byte {
+	blockAlign := channels * bitDepth / 8
+	byteRate := sampleRate * blockAlign
+
+	buf := make([]byte, wavHeaderSize+len(pcm))
+
+	// RIFF 头
+	copy(buf[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(buf[4:8], uint32(36+len(pcm)))
+	copy(buf[8:12], "WAVE")
+
+	// fmt 块
+	copy(buf[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(buf[16:20], 16)
+	binary.LittleEndian.PutUint16(buf[20:22], 1) // PCM 格式
+	binary.LittleEndian.PutUint16(buf[22:24], uint16(channels))
+	binary.LittleEndian.PutUint32(buf[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(buf[28:32], uint32(byteRate))
+	binary.LittleEndian.PutUint16(buf[32:34], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(buf[34:36], uint16(bitDepth))
+
+	// data 块
+	copy(buf[36:40], "data")
+	binary.LittleEndian.PutUint32(buf[40:44], uint32(len(pcm)))
+	copy(buf[wavHeaderSize:], pcm)
+
+	return buf
+}
+
+// WAV 代表解析后的 PCM WAV 音频
+type WAV struct {
+	SampleRate int    // 采样率
+	Channels   int    // 声道数
+	BitDepth   int    // 每个采样的位数
+	Data       []byte // PCM 数据（交错存储的小端序采样）
+}
+
+// ParseWAV 解析 PCM WAV 数据，兼容流式输出中 data 块长度未知（为0或超出实际长度）的情况
+func ParseWAV(data []byte) (*WAV, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("不是有效的 WAV 数据")
+	}
+
+	w := &WAV{}
+	gotFmt := false
+	for off := 12; off+8 <= len(data); {
+		id := string(data[off : off+4])
+		size := int(binary.LittleEndian.Uint32(data[off+4 : off+8]))
+		body := off + 8
+
+		switch id {
+		case "fmt ":
+			if body+16 > len(data) {
+				return nil, fmt.Errorf("WAV fmt 块不完整")
+			}
+			if format := binary.LittleEndian.Uint16(data[body : body+2]); format != 1 && format != 0xFFFE {
+				return nil, fmt.Errorf("不支持的 WAV 编码格式 %d，仅支持 PCM", format)
+			}
+			w.Channels = int(binary.LittleEndian.Uint16(data[body+2 : body+4]))
+			w.SampleRate = int(binary.LittleEndian.Uint32(data[body+4 : body+8]))
+			w.BitDepth = int(binary.LittleEndian.Uint16(data[body+14 : body+16]))
+			gotFmt = true
+		case "data":
+			if !gotFmt {
+				return nil, fmt.Errorf("WAV data 块位于 fmt 块之前")
+			}
+			end := body + size
+			if size == 0 || end > len(data) {
+				end = len(data)
+			}
+			w.Data = data[body:end]
+			return w, nil
+		}
+
+		off = body + size + size%2
+	}
+
+	return nil, fmt.Errorf("WAV 数据中缺少 data 块")
+}
+
+// Bytes 将音频编码为带文件头的 WAV 数据
+func (w *WAV) Bytes() []byte {
+	return WrapPCMAsWAV(w.Data, w.SampleRate, w.Channels, w.BitDepth)
+}
+
+// frameSize 返回一个采样帧（所有声道）的字节数
+func (w *WAV) frameSize() int {
+	return w.Channels * w.BitDepth / 8
+}
+
+// Duration 返回音频时长
+func (w *WAV) Duration() time.Duration {
+	frame := w.frameSize()
+	if frame == 0 || w.SampleRate == 0 {
+		return 0
+	}
+	frames := len(w.Data) / frame
+	return time.Duration(frames) * time.Second / time.Duration(w.SampleRate)
+}
+
+// bytesFor 返回给定时长对应的 PCM 字节数，按采样帧对齐，负时长或无效格式返回 0
+func (w *WAV) bytesFor(d time.Duration) int {
+	frames := int(d * time.Duration(w.SampleRate) / time.Second)
+	return max(frames*w.frameSize(), 0)
+}
+
+// sameFormat 判断两段音频格式是否一致
+func (w *WAV) sameFormat(o *WAV) bool {
+	return w.SampleRate == o.SampleRate && w.Channels == o.Channels && w.BitDepth == o.BitDepth
+}
+
+// Duration 返回 WAV 数据的时长，解析失败时返回错误
+func Duration(data []byte) (time.Duration, error) {
+	w, err := ParseWAV(data)
+	if err != nil {
+		return 0, err
+	}
+	return w.Duration(), nil
+}
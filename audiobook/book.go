@@ -0,0 +1,205 @@
+package audiobook
+
+// 提供按章节合成、写出音频文件与清单的流水线
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	gsv "github.com/ssdomei232/gpt_sovits_go_sdk"
+)
+
+// ManifestFile 为输出目录中清单文件的名称
+const ManifestFile = "manifest.json"
+
+// Options 代表有声书合成选项
+type Options struct {
+	Client    *gsv.Client       // 合成所用的客户端
+	Voice     *gsv.Voice        // 朗读音色，为空时使用客户端默认参数
+	Request   gsv.TTSRequest    // 每块请求的基础参数
+	OutputDir string            // 输出目录，章节音频与清单写入该目录
+	Splitter  *gsv.TextSplitter // 章节内的文本切分器，为空时使用默认切分器
+	Gap       time.Duration     // 章节内相邻块之间的静音时长
+	Crossfade time.Duration     // 章节内相邻块的交叉淡化时长
+
+	// OnChapter 在每章完成（含因已完成而跳过）后调用，可用于显示进度
+	OnChapter func(entry ChapterEntry, skipped bool)
+}
+
+// ChapterEntry 代表清单中的一章
+type ChapterEntry struct {
+	Index      int           `json:"index"`       // 章节序号，从 0 开始
+	Title      string        `json:"title"`       // 章节标题
+	File       string        `json:"file"`        // 音频文件名，相对于输出目录
+	Duration   time.Duration `json:"duration"`    // 音频时长
+	SHA256     string        `json:"sha256"`      // 音频文件的 SHA-256
+	TextSHA256 string        `json:"text_sha256"` // 章节正文的 SHA-256，用于判断是否需要重新合成
+}
+
+// Manifest 代表有声书清单
+type Manifest struct {
+	Chapters []ChapterEntry `json:"chapters"` // 各章信息
+	Total    time.Duration  `json:"total"`    // 总时长
+}
+
+// Synthesize 逐章合成并写出 WAV 文件与清单，每完成一章即保存清单
+// 重新运行时，正文未变化且音频文件校验一致的章节将被跳过，实现断点续做
+func Synthesize(ctx context.Context, chapters []Chapter, opts Options) (*Manifest, error) {
+	if opts.Client == nil {
+		return nil, fmt.Errorf("未设置客户端")
+	}
+	if opts.OutputDir == "" {
+		return nil, fmt.Errorf("未设置输出目录")
+	}
+	if err := os.MkdirAll(opts.OutputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	// 读取上次的清单
+	previous, err := LoadManifest(opts.OutputDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	done := make(map[int]ChapterEntry)
+	if previous != nil {
+		for _, e := range previous.Chapters {
+			done[e.Index] = e
+		}
+	}
+
+	manifest := &Manifest{}
+	for i, ch := range chapters {
+		textHash := sha256Hex([]byte(ch.Text))
+
+		// 已完成且未变化的章节直接复用
+		if e, ok := done[i]; ok && e.TextSHA256 == textHash && fileMatches(filepath.Join(opts.OutputDir, e.File), e.SHA256) {
+			manifest.add(e)
+			if opts.OnChapter != nil {
+				opts.OnChapter(e, true)
+			}
+			continue
+		}
+
+		entry, err := synthesizeChapter(ctx, i, ch, textHash, opts)
+		if err != nil {
+			return manifest, fmt.Errorf("第 %d 章 %s: %w", i+1, ch.Title, err)
+		}
+		manifest.add(entry)
+		if err := manifest.save(opts.OutputDir); err != nil {
+			return manifest, err
+		}
+		if opts.OnChapter != nil {
+			opts.OnChapter(entry, false)
+		}
+	}
+
+	return manifest, manifest.save(opts.OutputDir)
+}
+
+// synthesizeChapter 合成一章并写出音频文件
+func synthesizeChapter(ctx context.Context, index int, ch Chapter, textHash string, opts Options) (ChapterEntry, error) {
+	req := opts.Request
+	req.Text = ch.Text
+	if opts.Voice != nil {
+		// 音色专用的发音词典
+		text, err := opts.Voice.ProcessText(req.Text, req.TextLang)
+		if err != nil {
+			return ChapterEntry{}, err
+		}
+		req.Text = text
+	}
+
+	result, err := opts.Client.TTSLong(ctx, req, &gsv.LongTextOptions{
+		Splitter:  opts.Splitter,
+		Gap:       opts.Gap,
+		Crossfade: opts.Crossfade,
+		Voice:     opts.Voice,
+	})
+	if err != nil {
+		return ChapterEntry{}, err
+	}
+
+	entry := ChapterEntry{
+		Index:      index,
+		Title:      ch.Title,
+		File:       fmt.Sprintf("%03d.wav", index+1),
+		SHA256:     sha256Hex(result.Audio),
+		TextSHA256: textHash,
+	}
+	if n := len(result.Chunks); n > 0 {
+		entry.Duration = result.Chunks[n-1].End
+	}
+
+	if err := writeFileAtomic(filepath.Join(opts.OutputDir, entry.File), result.Audio); err != nil {
+		return ChapterEntry{}, err
+	}
+	return entry, nil
+}
+
+// LoadManifest 读取输出目录中的清单
+func LoadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("解析清单失败: %w", err)
+	}
+	return &m, nil
+}
+
+// add 追加一章并累计总时长
+func (m *Manifest) add(e ChapterEntry) {
+	m.Chapters = append(m.Chapters, e)
+	m.Total += e.Duration
+}
+
+// save 将清单写入输出目录
+func (m *Manifest) save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("清单序列化失败: %w", err)
+	}
+	return writeFileAtomic(filepath.Join(dir, ManifestFile), data)
+}
+
+// fileMatches 判断文件存在且摘要一致
+func fileMatches(path, sum string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && sha256Hex(data) == sum
+}
+
+// sha256Hex 返回数据的 SHA-256 十六进制摘要
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// writeFileAtomic 先写入临时文件再重命名，避免中断时留下不完整的文件
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("创建临时文件失败: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("写入临时文件失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("关闭临时文件失败: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("重命名文件失败: %w", err)
+	}
+	return nil
+}
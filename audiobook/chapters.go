@@ -0,0 +1,114 @@
+// Package audiobook 提供有声书合成流水线：解析 Markdown、纯文本或 EPUB，按章节合成音频并生成清单，支持断点续做
+package audiobook
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/textproc"
+)
+
+// Chapter 代表一个章节
+type Chapter struct {
+	Title string // 章节标题
+	Text  string // 去除格式后的正文
+}
+
+// 章节识别使用的正则表达式
+var (
+	mdHeadingRe    = regexp.MustCompile(`^\s{0,3}(#{1,2})\s+(.+?)\s*#*\s*$`)
+	plainChapterRe = regexp.MustCompile(`^\s*(第[0-9零一二三四五六七八九十百千]+[章节回卷部].*|(?i:chapter)\s+[0-9IVXLCDM]+\b.*)$`)
+)
+
+// Load 按扩展名解析文件：.md/.markdown 为 Markdown，.epub 为 EPUB，其余按纯文本处理
+func Load(path string) ([]Chapter, error) {
+	if strings.EqualFold(filepath.Ext(path), ".epub") {
+		return LoadEPUB(path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return ParseMarkdown(string(data))
+	default:
+		return ParsePlainText(string(data))
+	}
+}
+
+// ParseMarkdown 按一级与二级标题切分章节并移除 Markdown 标记，首个标题之前的内容作为无标题章节
+func ParseMarkdown(text string) ([]Chapter, error) {
+	return splitChapters(text, func(line string) (string, bool) {
+		m := mdHeadingRe.FindStringSubmatch(line)
+		if m == nil {
+			return "", false
+		}
+		return m[2], true
+	})
+}
+
+// ParsePlainText 按“第X章”或“Chapter N”形式的标题行切分章节，没有标题行时整个文本作为一章
+func ParsePlainText(text string) ([]Chapter, error) {
+	return splitChapters(text, func(line string) (string, bool) {
+		if plainChapterRe.MatchString(line) {
+			return strings.TrimSpace(line), true
+		}
+		return "", false
+	})
+}
+
+// splitChapters 按标题行切分文本并清理各章正文，丢弃没有正文的章节
+func splitChapters(text string, heading func(line string) (string, bool)) ([]Chapter, error) {
+	sanitizer := textproc.NewSanitizer()
+
+	var (
+		chapters []Chapter
+		title    string
+		body     strings.Builder
+	)
+	flush := func() error {
+		clean, err := sanitizer.Process(body.String(), "")
+		if err != nil {
+			return err
+		}
+		if clean != "" {
+			t, _ := sanitizer.Process(title, "")
+			chapters = append(chapters, Chapter{Title: t, Text: clean})
+		}
+		body.Reset()
+		return nil
+	}
+
+	inFence := false
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		// 代码块中的 # 不是标题
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		if !inFence {
+			if t, ok := heading(line); ok {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+				title = t
+				continue
+			}
+		}
+		body.WriteString(line)
+		body.WriteByte('\n')
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if len(chapters) == 0 {
+		return nil, fmt.Errorf("文本中没有可合成的内容")
+	}
+	return chapters, nil
+}
@@ -0,0 +1,148 @@
+package audiobook
+
+// 提供 EPUB 电子书的章节解析，按书脊（spine）顺序读取各 XHTML 文档
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/textproc"
+)
+
+// EPUB 解析使用的正则表达式
+var (
+	htmlHeadingRe = regexp.MustCompile(`(?is)<h[12][^>]*>(.*?)</h[12]>`)
+	htmlTitleRe   = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlSkipRe    = regexp.MustCompile(`(?is)<(head|script|style)[^>]*>.*?</(head|script|style)>`)
+	htmlBlockRe   = regexp.MustCompile(`(?i)</?(p|div|br|h[1-6]|li|tr|blockquote|section)[^>]*>`)
+	htmlAnyTagRe  = regexp.MustCompile(`<[^>]+>`)
+	htmlEntityMap = strings.NewReplacer("&nbsp;", " ", "&lt;", "<", "&gt;", ">", "&quot;", `"`, "&#39;", "'", "&apos;", "'", "&amp;", "&")
+)
+
+// epubContainer 代表 META-INF/container.xml
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// epubPackage 代表 OPF 包文件
+type epubPackage struct {
+	Manifest []struct {
+		ID        string `xml:"id,attr"`
+		Href      string `xml:"href,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"manifest>item"`
+	Spine []struct {
+		IDRef string `xml:"idref,attr"`
+	} `xml:"spine>itemref"`
+}
+
+// LoadEPUB 解析 EPUB 文件，每个书脊文档作为一章，标题取文档中的首个 h1/h2 或 title
+func LoadEPUB(file string) ([]Chapter, error) {
+	zr, err := zip.OpenReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("打开 EPUB 失败: %w", err)
+	}
+	defer zr.Close()
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	// 定位 OPF 包文件
+	var container epubContainer
+	if err := readXML(files, "META-INF/container.xml", &container); err != nil {
+		return nil, err
+	}
+	if len(container.Rootfiles) == 0 {
+		return nil, fmt.Errorf("EPUB 中缺少 rootfile")
+	}
+	opfPath := container.Rootfiles[0].FullPath
+
+	var pkg epubPackage
+	if err := readXML(files, opfPath, &pkg); err != nil {
+		return nil, err
+	}
+	hrefs := make(map[string]string, len(pkg.Manifest))
+	for _, item := range pkg.Manifest {
+		hrefs[item.ID] = path.Join(path.Dir(opfPath), item.Href)
+	}
+
+	// 按书脊顺序读取文档
+	sanitizer := textproc.NewSanitizer()
+	var chapters []Chapter
+	for _, ref := range pkg.Spine {
+		name, ok := hrefs[ref.IDRef]
+		if !ok {
+			continue
+		}
+		data, err := readZipFile(files, name)
+		if err != nil {
+			return nil, err
+		}
+
+		title, text := htmlToText(string(data))
+		clean, err := sanitizer.Process(text, "")
+		if err != nil {
+			return nil, err
+		}
+		if clean != "" {
+			chapters = append(chapters, Chapter{Title: title, Text: clean})
+		}
+	}
+
+	if len(chapters) == 0 {
+		return nil, fmt.Errorf("EPUB 中没有可合成的内容")
+	}
+	return chapters, nil
+}
+
+// htmlToText 提取 XHTML 文档的标题与纯文本
+func htmlToText(doc string) (title, text string) {
+	// 优先使用正文的首个标题
+	m := htmlHeadingRe.FindStringSubmatch(doc)
+	if m == nil {
+		m = htmlTitleRe.FindStringSubmatch(doc)
+	}
+	if m != nil {
+		title = strings.TrimSpace(htmlEntityMap.Replace(htmlAnyTagRe.ReplaceAllString(m[1], "")))
+	}
+
+	doc = htmlSkipRe.ReplaceAllString(doc, "")
+	doc = htmlBlockRe.ReplaceAllString(doc, "\n")
+	doc = htmlAnyTagRe.ReplaceAllString(doc, "")
+	return title, htmlEntityMap.Replace(doc)
+}
+
+// readXML 读取并解析压缩包中的 XML 文件
+func readXML(files map[string]*zip.File, name string, v any) error {
+	data, err := readZipFile(files, name)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("解析 %s 失败: %w", name, err)
+	}
+	return nil
+}
+
+// readZipFile 读取压缩包中的文件
+func readZipFile(files map[string]*zip.File, name string) ([]byte, error) {
+	f, ok := files[name]
+	if !ok {
+		return nil, fmt.Errorf("EPUB 中缺少 %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("读取 %s 失败: %w", name, err)
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
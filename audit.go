@@ -0,0 +1,269 @@
+package gpt_sovits_go_sdk
+
+// 提供合成请求的审计日志，记录谁在何时以哪个音色合成了什么文本及输出的摘要，供企业部署的合规审查使用
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// defaultAuditTimeout 为远程审计接收端的默认超时
+const defaultAuditTimeout = 10 * time.Second
+
+// 审计记录的结果
+const (
+	AuditOK      = "ok"      // 合成成功
+	AuditFailed  = "failed"  // 合成失败
+	AuditBlocked = "blocked" // 被内容过滤器拒绝
+	AuditDenied  = "denied"  // 音色未获授权
+)
+
+// AuditRecord 代表一次合成请求的审计记录
+type AuditRecord struct {
+	Time         time.Time `json:"time"`                     // 请求完成时间
+	RequestID    string    `json:"request_id,omitempty"`     // 请求 ID
+	Tenant       string    `json:"tenant,omitempty"`         // 租户名称
+	Key          string    `json:"key,omitempty"`            // 调用方标识，见 WithUsageKey
+	Usage        string    `json:"usage,omitempty"`          // 声明的用途，见 WithUsageTag
+	Voice        string    `json:"voice,omitempty"`          // 音色名称，参考音频未关联已登记的音色时为空
+	RefAudioPath string    `json:"ref_audio_path,omitempty"` // 参考音频路径
+	Text         string    `json:"text,omitempty"`           // 请求的文本，按 AuditOptions 脱敏
+	TextSHA256   string    `json:"text_sha256"`              // 原始文本的 SHA-256，脱敏后仍可比对
+	Characters   int       `json:"characters"`               // 原始文本的字符数
+	Outcome      string    `json:"outcome"`                  // 结果，为 AuditOK 等常量之一
+	Error        string    `json:"error,omitempty"`          // 失败或拒绝的原因
+	AudioSHA256  string    `json:"audio_sha256,omitempty"`   // 输出音频的 SHA-256，流式调用为空
+	AudioSeconds float64   `json:"audio_seconds,omitempty"`  // 输出音频的秒数
+	FromCache    bool      `json:"from_cache,omitempty"`     // 结果是否来自缓存
+	Streamed     bool      `json:"streamed,omitempty"`       // 是否为流式调用，记录于响应开始时
+}
+
+// AuditSink 代表审计记录的接收端，实现必须是并发安全的
+type AuditSink interface {
+	WriteAudit(ctx context.Context, rec AuditRecord) error
+}
+
+// AuditSinkFunc 允许将普通函数用作 AuditSink
+type AuditSinkFunc func(ctx context.Context, rec AuditRecord) error
+
+// WriteAudit 实现 AuditSink 接口
+func (f AuditSinkFunc) WriteAudit(ctx context.Context, rec AuditRecord) error {
+	return f(ctx, rec)
+}
+
+// AuditTextMode 代表审计记录中文本的保存方式
+type AuditTextMode string
+
+// 审计记录中文本的保存方式
+const (
+	AuditTextFull AuditTextMode = "full" // 保存完整文本
+	AuditTextHash AuditTextMode = "hash" // 只保存摘要与字符数
+)
+
+// AuditOptions 代表审计日志的脱敏与错误处理选项
+type AuditOptions struct {
+	Text     AuditTextMode // 文本的保存方式，为空时为 AuditTextFull
+	MaxChars int           // 保存的最大字符数，超出部分以 "…" 代替，<=0 时不限制
+	MaskPII  bool          // 以 "***" 遮盖文本中的手机号、身份证号、电子邮箱等个人信息
+
+	// Redact 在写入前修改记录，用于自定义脱敏
+	Redact func(rec *AuditRecord)
+	// OnError 接收写入失败的错误，为空时忽略；审计失败不影响合成结果
+	OnError func(err error)
+}
+
+// WithAudit 为客户端设置审计日志，TTS、TTSGet 与流式接口的每次请求（含被拒绝与命中缓存的请求）各记录一条
+// 自动分块时每块记录一条；预热调用不记录
+func WithAudit(sink AuditSink, opts *AuditOptions) ClientOption {
+	return func(c *Client) {
+		if opts == nil {
+			opts = &AuditOptions{}
+		}
+		c.auditSink, c.auditOptions = sink, opts
+	}
+}
+
+// audit 在设置了审计日志时记录一次请求
+// ttsResp 为 nil 时以 callErr 作为结果；流式调用不记录音频摘要与时长
+func (c *Client) audit(ctx context.Context, req TTSRequest, o *callOptions, ttsResp *TTSResponse, callErr error, streamed bool) {
+	if c.auditSink == nil || o.noUsage {
+		return
+	}
+	req = c.applyDefaults(req)
+
+	sum := sha256.Sum256([]byte(req.Text))
+	rec := AuditRecord{
+		Time:         time.Now(),
+		Tenant:       c.tenant,
+		Key:          o.usageKey,
+		Usage:        o.usageTag,
+		RefAudioPath: req.RefAudioPath,
+		Text:         req.Text,
+		TextSHA256:   hex.EncodeToString(sum[:]),
+		Characters:   utf8.RuneCountInString(req.Text),
+		Streamed:     streamed,
+	}
+	if o.voice != nil {
+		rec.Voice = o.voice.Name
+	} else if c.voices != nil {
+		if v, ok := c.voices.ByRefAudio(req.RefAudioPath); ok {
+			rec.Voice = v.Name
+		}
+	}
+
+	err := callErr
+	if ttsResp != nil && err == nil {
+		err = ttsResp.Err()
+		rec.RequestID = ttsResp.RequestID
+		rec.FromCache = ttsResp.FromCache
+		if err == nil && !streamed {
+			rec.AudioSHA256 = ttsResp.SHA256
+			if rec.AudioSHA256 == "" {
+				audioSum := sha256.Sum256(ttsResp.AudioData)
+				rec.AudioSHA256 = hex.EncodeToString(audioSum[:])
+			}
+			rec.AudioSeconds = ttsResp.Duration.Seconds()
+		}
+	}
+	switch {
+	case err == nil:
+		rec.Outcome = AuditOK
+	case errors.Is(err, ErrContentBlocked):
+		rec.Outcome = AuditBlocked
+	case errors.Is(err, ErrVoiceNotAuthorized):
+		rec.Outcome = AuditDenied
+	default:
+		rec.Outcome = AuditFailed
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+
+	c.auditOptions.redact(&rec)
+
+	// 请求已取消时仍需记录
+	if err := c.auditSink.WriteAudit(context.WithoutCancel(ctx), rec); err != nil && c.auditOptions.OnError != nil {
+		c.auditOptions.OnError(fmt.Errorf("写入审计记录失败: %w", err))
+	}
+}
+
+// redact 按选项对记录脱敏
+func (o *AuditOptions) redact(rec *AuditRecord) {
+	switch {
+	case o.Text == AuditTextHash:
+		rec.Text = ""
+	default:
+		if o.MaskPII {
+			rec.Text = maskPII(rec.Text, "***")
+		}
+		if o.MaxChars > 0 && utf8.RuneCountInString(rec.Text) > o.MaxChars {
+			rec.Text = string([]rune(rec.Text)[:o.MaxChars]) + "…"
+		}
+	}
+	if o.Redact != nil {
+		o.Redact(rec)
+	}
+}
+
+// JSONLAuditSink 代表以 JSON Lines 格式写入 io.Writer 的审计接收端
+type JSONLAuditSink struct {
+	mu sync.Mutex
+	w  io.Writer
+	f  *os.File // 由 NewFileAuditSink 打开的文件
+}
+
+// NewJSONLAuditSink 创建一个写入 w 的审计接收端，每条记录占一行
+func NewJSONLAuditSink(w io.Writer) *JSONLAuditSink {
+	return &JSONLAuditSink{w: w}
+}
+
+// NewFileAuditSink 创建一个追加写入 path 的审计接收端，文件不存在时自动创建
+func NewFileAuditSink(path string) (*JSONLAuditSink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("打开审计文件失败: %w", err)
+	}
+	return &JSONLAuditSink{w: f, f: f}, nil
+}
+
+// WriteAudit 实现 AuditSink 接口
+func (s *JSONLAuditSink) WriteAudit(_ context.Context, rec AuditRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("审计记录序列化失败: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 每条记录一次写入，避免与其他进程的追加交错
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+// Close 关闭由 NewFileAuditSink 打开的文件，写入 io.Writer 时不做任何操作
+func (s *JSONLAuditSink) Close() error {
+	if s.f == nil {
+		return nil
+	}
+	return s.f.Close()
+}
+
+// HTTPAuditSink 代表以 POST 请求逐条发送审计记录的远程接收端
+type HTTPAuditSink struct {
+	URL        string            // 接收端地址，请求体为 JSON 格式的 AuditRecord
+	Headers    map[string]string // 附加的请求头，如认证令牌
+	Timeout    time.Duration     // 单条记录的发送超时，<=0 时为 10 秒
+	HTTPClient *http.Client      // 发送请求的 HTTP 客户端，为空时使用 http.DefaultClient
+}
+
+// WriteAudit 实现 AuditSink 接口，接收端返回非 2xx 状态码时返回错误
+func (s *HTTPAuditSink) WriteAudit(ctx context.Context, rec AuditRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("审计记录序列化失败: %w", err)
+	}
+
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = defaultAuditTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("创建审计请求失败: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range s.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	hc := s.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("发送审计记录失败: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("审计接收端返回状态码 %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package gpt_sovits_go_sdk
+
+// 提供模型未加载时自动设置权重并重试，使服务器冷启动对调用方透明
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AutoWeights 代表模型未加载时自动设置的权重
+type AutoWeights struct {
+	GPT    string // GPT 权重路径，为空时不设置
+	SoVITS string // SoVITS 权重路径，为空时不设置
+
+	// Match 判断错误响应是否表示模型未加载，为空时按错误信息中的常见关键字判断
+	Match func(err *APIError) bool
+
+	mu  sync.Mutex
+	gen uint64 // 已设置权重的次数，用于合并并发请求触发的设置
+}
+
+// modelNotLoadedHints 为模型未加载时错误信息中的常见关键字
+var modelNotLoadedHints = []string{
+	"not loaded",
+	"no model",
+	"model is none",
+	"'nonetype' object",
+	"未加载",
+	"请先加载",
+}
+
+// matches 判断错误响应是否表示模型未加载
+func (a *AutoWeights) matches(err *APIError) bool {
+	if a.Match != nil {
+		return a.Match(err)
+	}
+	msg := strings.ToLower(err.Message + " " + err.Exception)
+	for _, hint := range modelNotLoadedHints {
+		if strings.Contains(msg, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithAutoWeights 设置模型未加载时自动设置的 GPT/SoVITS 权重
+// TTS 与 TTSGet 的错误响应表示模型未加载（如服务器重启后）时，客户端设置这组权重并重试一次；流式接口不重试
+func WithAutoWeights(gpt, sovits string) ClientOption {
+	return func(c *Client) {
+		c.autoWeights = &AutoWeights{GPT: gpt, SoVITS: sovits}
+	}
+}
+
+// WithAutoWeightsConfig 以自定义的判断条件设置模型未加载时自动设置的权重
+func WithAutoWeightsConfig(a *AutoWeights) ClientOption {
+	return func(c *Client) {
+		c.autoWeights = a
+	}
+}
+
+// sendTTSWithAutoWeights 发送请求，模型未加载时设置权重后重试一次
+func (c *Client) sendTTSWithAutoWeights(httpReq *http.Request, o *callOptions, start time.Time) *TTSResponse {
+	a := c.autoWeights
+	if a == nil {
+		return c.sendTTS(httpReq, o, start)
+	}
+
+	a.mu.Lock()
+	gen := a.gen
+	a.mu.Unlock()
+
+	// 重试需要重新读取请求体，发送前先保留一份副本
+	retry := httpReq.Clone(httpReq.Context())
+	ttsResp := c.sendTTS(httpReq, o, start)
+
+	apiErr, ok := ttsResp.Error.(*APIError)
+	if !ok || !a.matches(apiErr) {
+		return ttsResp
+	}
+	if httpReq.GetBody != nil {
+		body, err := httpReq.GetBody()
+		if err != nil {
+			return ttsResp
+		}
+		retry.Body = body
+	}
+
+	if err := c.applyAutoWeights(retry, gen); err != nil {
+		ttsResp.Error = err
+		return ttsResp
+	}
+	return c.sendTTS(retry, o, start)
+}
+
+// applyAutoWeights 设置自动权重，其他请求已在 gen 之后完成设置时跳过
+func (c *Client) applyAutoWeights(httpReq *http.Request, gen uint64) error {
+	a := c.autoWeights
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.gen != gen {
+		return nil
+	}
+
+	// 经模型管理器排空进行中的请求后设置，使 Current 与缓存键保持一致；
+	// 不获取会话锁，触发重试的请求可能正处于持有读锁的会话合成中，且服务器已丢失原有权重
+	if err := c.Models().setWeights(httpReq.Context(), a.GPT, a.SoVITS); err != nil {
+		return err
+	}
+	a.gen++
+	return nil
+}
@@ -0,0 +1,122 @@
+// Package bench 提供 GPT-SoVITS 服务器吞吐量压测工具，统计延迟分位数、实时率与错误率
+package bench
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gsv "github.com/ssdomei232/gpt_sovits_go_sdk"
+)
+
+// Config 代表压测配置
+type Config struct {
+	Client      gsv.Synthesizer // 被测客户端，可为 *gsv.Client 或 *gsv.PoolClient
+	Request     gsv.TTSRequest  // 请求模板
+	Texts       []string        // 轮流使用的合成文本，为空时使用 Request.Text
+	Concurrency int             // 并发数，<=0 时为 1
+	Duration    time.Duration   // 压测持续时间，为0时只受 Requests 限制
+	Requests    int             // 最大请求数，为0时只受 Duration 限制
+}
+
+// Sample 代表单个请求的测量结果
+type Sample struct {
+	Index      int           `json:"index"`                 // 请求序号
+	Start      time.Duration `json:"start"`                 // 相对压测开始的发起时间
+	Latency    time.Duration `json:"latency"`               // 请求耗时
+	AudioLen   time.Duration `json:"audio_len"`             // 合成音频时长
+	RTF        float64       `json:"rtf"`                   // 实时率，请求耗时 / 音频时长，越小越快
+	StatusCode int           `json:"status_code"`           // HTTP 状态码
+	Error      string        `json:"error,omitempty"`       // 失败原因
+	TextLength int           `json:"text_length,omitempty"` // 文本字符数
+}
+
+// Run 按配置执行压测并返回报告，ctx 取消时提前结束
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if cfg.Client == nil {
+		return nil, fmt.Errorf("未设置被测客户端")
+	}
+	if cfg.Duration <= 0 && cfg.Requests <= 0 {
+		return nil, fmt.Errorf("至少需要设置 Duration 或 Requests 之一")
+	}
+
+	texts := cfg.Texts
+	if len(texts) == 0 {
+		texts = []string{cfg.Request.Text}
+	}
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	if cfg.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Duration)
+		defer cancel()
+	}
+
+	var (
+		next    atomic.Int64
+		mu      sync.Mutex
+		samples []Sample
+		wg      sync.WaitGroup
+	)
+	start := time.Now()
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				// 领取请求序号，达到请求数上限时退出
+				i := int(next.Add(1)) - 1
+				if cfg.Requests > 0 && i >= cfg.Requests {
+					return
+				}
+
+				req := cfg.Request
+				req.Text = texts[i%len(texts)]
+				s := measure(ctx, cfg.Client, req, i, start)
+
+				// 压测结束导致的取消不计入结果
+				if ctx.Err() != nil && s.Error != "" {
+					return
+				}
+
+				mu.Lock()
+				samples = append(samples, s)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return newReport(samples, time.Since(start), concurrency), nil
+}
+
+// measure 发送一个请求并记录测量结果
+func measure(ctx context.Context, client gsv.Synthesizer, req gsv.TTSRequest, index int, start time.Time) Sample {
+	s := Sample{Index: index, Start: time.Since(start), TextLength: len([]rune(req.Text))}
+
+	t := time.Now()
+	resp, err := client.TTS(ctx, req)
+	s.Latency = time.Since(t)
+
+	if err == nil {
+		err = resp.Err()
+	}
+	if resp != nil {
+		s.StatusCode = resp.StatusCode
+		s.AudioLen = resp.Duration
+	}
+	if err != nil {
+		s.Error = err.Error()
+		return s
+	}
+	if s.AudioLen > 0 {
+		s.RTF = s.Latency.Seconds() / s.AudioLen.Seconds()
+	}
+	return s
+}
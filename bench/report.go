@@ -0,0 +1,133 @@
+package bench
+
+// 提供压测报告的统计与 JSON/CSV 输出
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Percentiles 代表一组测量值的分布
+type Percentiles struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// Report 代表压测报告
+type Report struct {
+	Concurrency  int           `json:"concurrency"`   // 并发数
+	Elapsed      time.Duration `json:"elapsed"`       // 压测总耗时
+	Requests     int           `json:"requests"`      // 完成的请求数
+	Errors       int           `json:"errors"`        // 失败的请求数
+	ErrorRate    float64       `json:"error_rate"`    // 错误率
+	Throughput   float64       `json:"throughput"`    // 每秒完成的请求数
+	AudioSeconds float64       `json:"audio_seconds"` // 合成音频总时长（秒）
+	LatencyMs    Percentiles   `json:"latency_ms"`    // 成功请求的延迟分布（毫秒）
+	RTF          Percentiles   `json:"rtf"`           // 成功请求的实时率分布
+	Samples      []Sample      `json:"samples"`       // 单个请求的测量结果，按序号排列
+}
+
+// newReport 汇总测量结果
+func newReport(samples []Sample, elapsed time.Duration, concurrency int) *Report {
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Index < samples[j].Index })
+
+	r := &Report{
+		Concurrency: concurrency,
+		Elapsed:     elapsed,
+		Requests:    len(samples),
+		Samples:     samples,
+	}
+
+	var latencies, rtfs []float64
+	for _, s := range samples {
+		if s.Error != "" {
+			r.Errors++
+			continue
+		}
+		latencies = append(latencies, float64(s.Latency)/float64(time.Millisecond))
+		if s.RTF > 0 {
+			rtfs = append(rtfs, s.RTF)
+		}
+		r.AudioSeconds += s.AudioLen.Seconds()
+	}
+
+	if r.Requests > 0 {
+		r.ErrorRate = float64(r.Errors) / float64(r.Requests)
+	}
+	if elapsed > 0 {
+		r.Throughput = float64(r.Requests) / elapsed.Seconds()
+	}
+	r.LatencyMs = percentiles(latencies)
+	r.RTF = percentiles(rtfs)
+	return r
+}
+
+// percentiles 计算测量值的分布，无数据时返回零值
+func percentiles(v []float64) Percentiles {
+	if len(v) == 0 {
+		return Percentiles{}
+	}
+	sort.Float64s(v)
+
+	sum := 0.0
+	for _, x := range v {
+		sum += x
+	}
+
+	// 最近秩法
+	at := func(p float64) float64 {
+		i := int(math.Ceil(p*float64(len(v)))) - 1
+		return v[max(i, 0)]
+	}
+	return Percentiles{
+		Min:  v[0],
+		Mean: sum / float64(len(v)),
+		P50:  at(0.50),
+		P90:  at(0.90),
+		P95:  at(0.95),
+		P99:  at(0.99),
+		Max:  v[len(v)-1],
+	}
+}
+
+// WriteJSON 以 JSON 格式输出报告
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV 以 CSV 格式输出每个请求的测量结果，时间单位为毫秒
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"index", "start_ms", "latency_ms", "audio_ms", "rtf", "status_code", "text_length", "error"})
+
+	ms := func(d time.Duration) string {
+		return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 1, 64)
+	}
+	for _, s := range r.Samples {
+		_ = cw.Write([]string{
+			strconv.Itoa(s.Index),
+			ms(s.Start),
+			ms(s.Latency),
+			ms(s.AudioLen),
+			strconv.FormatFloat(s.RTF, 'f', 4, 64),
+			strconv.Itoa(s.StatusCode),
+			strconv.Itoa(s.TextLength),
+			s.Error,
+		})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
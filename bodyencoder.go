@@ -0,0 +1,130 @@
+package gpt_sovits_go_sdk
+
+// 提供可替换的请求体编码，便于对接只接受表单或 multipart 的网关
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"mime/multipart"
+	"net/url"
+	"slices"
+)
+
+// BodyEncoder 代表 POST /tts 请求体的编码方式，实现必须是并发安全的
+type BodyEncoder interface {
+	// Encode 编码请求，返回请求体与对应的 Content-Type
+	Encode(req TTSRequest) (body []byte, contentType string, err error)
+}
+
+// BodyEncoderFunc 允许将普通函数用作 BodyEncoder
+type BodyEncoderFunc func(req TTSRequest) ([]byte, string, error)
+
+// Encode 实现 BodyEncoder 接口
+func (f BodyEncoderFunc) Encode(req TTSRequest) ([]byte, string, error) {
+	return f(req)
+}
+
+// WithBodyEncoder 设置 POST /tts 请求体的编码方式，默认使用 JSONEncoder
+// 仅作用于 api_v2 的合成请求，权重切换、控制命令与 APIV1 的请求仍使用 JSON
+func WithBodyEncoder(e BodyEncoder) ClientOption {
+	return func(c *Client) {
+		c.bodyEncoder = e
+	}
+}
+
+// JSONEncoder 代表 JSON 请求体编码，为默认编码方式
+type JSONEncoder struct{}
+
+// Encode 实现 BodyEncoder 接口
+func (JSONEncoder) Encode(req TTSRequest) ([]byte, string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, "application/json", nil
+}
+
+// FormEncoder 代表 application/x-www-form-urlencoded 请求体编码
+// 字段名与 JSON 编码相同，取值规则与 GET 查询参数一致，内嵌参考音频以 base64 写入 ref_audio_base64
+type FormEncoder struct{}
+
+// Encode 实现 BodyEncoder 接口
+func (FormEncoder) Encode(req TTSRequest) ([]byte, string, error) {
+	return []byte(formValues(req).Encode()), "application/x-www-form-urlencoded", nil
+}
+
+// MultipartEncoder 代表 multipart/form-data 请求体编码，字段取值与 FormEncoder 相同
+type MultipartEncoder struct {
+	// RefAudioField 为内嵌参考音频的文件字段名，设置时 RefAudioData 以文件形式上传，为空时以 base64 写入 ref_audio_base64
+	RefAudioField string
+	// RefAudioName 为上传参考音频的文件名，为空时使用 "ref.wav"
+	RefAudioName string
+}
+
+// Encode 实现 BodyEncoder 接口
+func (e MultipartEncoder) Encode(req TTSRequest) ([]byte, string, error) {
+	fileData := req.RefAudioData
+	if e.RefAudioField == "" {
+		fileData = nil
+	} else {
+		req.RefAudioData = nil
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	// 写入普通字段，同名多值字段按顺序写入
+	values := formValues(req)
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		for _, v := range values[key] {
+			if err := mw.WriteField(key, v); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	// 以文件形式上传参考音频
+	if len(fileData) > 0 {
+		name := e.RefAudioName
+		if name == "" {
+			name = "ref.wav"
+		}
+		part, err := mw.CreateFormFile(e.RefAudioField, name)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(fileData); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), mw.FormDataContentType(), nil
+}
+
+// formValues 将请求编码为表单字段，在查询参数的基础上包含内嵌参考音频
+func formValues(req TTSRequest) url.Values {
+	v := req.QueryValues()
+	if len(req.RefAudioData) > 0 {
+		v.Set("ref_audio_base64", base64.StdEncoding.EncodeToString(req.RefAudioData))
+	}
+	return v
+}
+
+// encodeBody 使用客户端配置的编码方式编码请求体
+func (c *Client) encodeBody(req TTSRequest) ([]byte, string, error) {
+	enc := c.bodyEncoder
+	if enc == nil {
+		enc = JSONEncoder{}
+	}
+	body, contentType, err := enc.Encode(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("请求序列化失败: %w", err)
+	}
+	return body, contentType, nil
+}
@@ -0,0 +1,158 @@
+package gpt_sovits_go_sdk
+
+// 提供熔断器，在后端持续失败时本地快速失败
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen 表示熔断器处于打开状态，请求被本地拒绝
+var ErrCircuitOpen = errors.New("熔断器已打开，请求被拒绝")
+
+// BreakerState 代表熔断器状态
+type BreakerState int
+
+const (
+	// BreakerClosed 正常放行请求
+	BreakerClosed BreakerState = iota
+	// BreakerOpen 拒绝所有请求直至冷却结束
+	BreakerOpen
+	// BreakerHalfOpen 冷却结束后放行单个探测请求
+	BreakerHalfOpen
+)
+
+// String 返回熔断器状态名称
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker 代表熔断器，连续失败达到阈值后打开，冷却后进入半开状态
+type CircuitBreaker struct {
+	FailureThreshold int           // 触发熔断的连续失败次数
+	Cooldown         time.Duration // 打开状态持续时间
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool // 半开状态下是否已有探测请求在进行
+}
+
+// NewCircuitBreaker 创建一个新的熔断器
+func NewCircuitBreaker(failureThreshold int, cooldown time.Duration) *CircuitBreaker {
+	if failureThreshold <= 0 {
+		failureThreshold = 5
+	}
+	return &CircuitBreaker{
+		FailureThreshold: failureThreshold,
+		Cooldown:         cooldown,
+	}
+}
+
+// State 返回熔断器当前状态
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.Cooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Middleware 返回应用熔断逻辑的中间件，可通过 Client.Use 注册
+func (b *CircuitBreaker) Middleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if !b.allow() {
+				return nil, ErrCircuitOpen
+			}
+
+			resp, err := next(req)
+			// 调用方主动取消不计入失败
+			if err != nil && req.Context().Err() != nil {
+				b.releaseProbe()
+				return resp, err
+			}
+
+			// 传输错误与5xx响应计为失败
+			b.record(err == nil && resp.StatusCode < http.StatusInternalServerError)
+			return resp, err
+		}
+	}
+}
+
+// allow 判断是否放行当前请求
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.Cooldown {
+			return false
+		}
+		// 冷却结束，进入半开状态并放行一个探测请求
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record 记录请求结果并更新状态
+func (b *CircuitBreaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.state = BreakerClosed
+		b.failures = 0
+		b.probing = false
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.FailureThreshold {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+		b.probing = false
+	}
+}
+
+// releaseProbe 释放半开状态下的探测名额
+func (b *CircuitBreaker) releaseProbe() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// Reset 将熔断器重置为关闭状态
+func (b *CircuitBreaker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = BreakerClosed
+	b.failures = 0
+	b.probing = false
+}
@@ -0,0 +1,135 @@
+package gpt_sovits_go_sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// breakerStep 代表经过熔断器的一次请求
+type breakerStep struct {
+	status  int           // 后端返回的状态码，0 表示传输错误
+	wait    time.Duration // 发送前等待的时间
+	allowed bool          // 请求是否应被放行
+	state   BreakerState  // 请求完成后的熔断器状态
+}
+
+func TestCircuitBreakerStateMachine(t *testing.T) {
+	const cooldown = 20 * time.Millisecond
+
+	tests := []struct {
+		name  string
+		steps []breakerStep
+	}{
+		{
+			name: "连续失败达到阈值后打开",
+			steps: []breakerStep{
+				{status: 500, allowed: true, state: BreakerClosed},
+				{status: 0, allowed: true, state: BreakerOpen},
+				{status: 200, allowed: false, state: BreakerOpen},
+			},
+		},
+		{
+			name: "成功重置失败计数",
+			steps: []breakerStep{
+				{status: 500, allowed: true, state: BreakerClosed},
+				{status: 200, allowed: true, state: BreakerClosed},
+				{status: 503, allowed: true, state: BreakerClosed},
+				{status: 502, allowed: true, state: BreakerOpen},
+			},
+		},
+		{
+			name: "4xx 不计为失败",
+			steps: []breakerStep{
+				{status: 400, allowed: true, state: BreakerClosed},
+				{status: 404, allowed: true, state: BreakerClosed},
+				{status: 429, allowed: true, state: BreakerClosed},
+			},
+		},
+		{
+			name: "冷却后探测成功则关闭",
+			steps: []breakerStep{
+				{status: 500, allowed: true, state: BreakerClosed},
+				{status: 500, allowed: true, state: BreakerOpen},
+				{status: 200, wait: cooldown, allowed: true, state: BreakerClosed},
+				{status: 500, allowed: true, state: BreakerClosed},
+			},
+		},
+		{
+			name: "冷却后探测失败则重新打开",
+			steps: []breakerStep{
+				{status: 500, allowed: true, state: BreakerClosed},
+				{status: 500, allowed: true, state: BreakerOpen},
+				{status: 500, wait: cooldown, allowed: true, state: BreakerOpen},
+				{status: 200, allowed: false, state: BreakerOpen},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewCircuitBreaker(2, cooldown)
+			for i, step := range tt.steps {
+				time.Sleep(step.wait)
+
+				called := false
+				rt := b.Middleware()(func(req *http.Request) (*http.Response, error) {
+					called = true
+					if step.status == 0 {
+						return nil, errors.New("connection refused")
+					}
+					return &http.Response{StatusCode: step.status, Body: http.NoBody}, nil
+				})
+
+				req, _ := http.NewRequest(http.MethodGet, "http://backend/", nil)
+				_, err := rt(req)
+				if called != step.allowed {
+					t.Fatalf("第 %d 步: 放行 = %v，期望 %v", i+1, called, step.allowed)
+				}
+				if !step.allowed && !errors.Is(err, ErrCircuitOpen) {
+					t.Fatalf("第 %d 步: 错误 = %v，期望 ErrCircuitOpen", i+1, err)
+				}
+				if got := b.State(); got != step.state {
+					t.Fatalf("第 %d 步: 状态 = %v，期望 %v", i+1, got, step.state)
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerHalfOpenSingleProbe(t *testing.T) {
+	b := NewCircuitBreaker(1, 0)
+	b.record(false)
+
+	// 半开状态下只放行一个探测请求
+	if !b.allow() {
+		t.Fatal("第一个探测请求应被放行")
+	}
+	if b.allow() {
+		t.Fatal("探测进行中时其他请求应被拒绝")
+	}
+
+	// 探测请求被调用方取消时释放名额
+	b.releaseProbe()
+	if !b.allow() {
+		t.Fatal("释放名额后应放行新的探测请求")
+	}
+}
+
+func TestCircuitBreakerIgnoresCallerCancel(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Minute)
+	rt := b.Middleware()(func(req *http.Request) (*http.Response, error) {
+		return nil, req.Context().Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://backend/", nil)
+	_, _ = rt(req)
+
+	if got := b.State(); got != BreakerClosed {
+		t.Fatalf("调用方取消后状态 = %v，期望 closed", got)
+	}
+}
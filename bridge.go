@@ -0,0 +1,240 @@
+package gpt_sovits_go_sdk
+
+// 提供将流式大模型输出的文本片段逐句转为语音的桥接，适用于语音助手
+
+import (
+	"context"
+	"iter"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 桥接的默认配置
+const (
+	defaultBridgeMaxChars    = 100 // 默认的最大缓冲字符数
+	defaultBridgeParallelism = 2   // 默认同时合成的句子数
+)
+
+// SpeechChunk 代表桥接输出的一句语音
+type SpeechChunk struct {
+	Index    int          // 句子序号，从0开始
+	Text     string       // 句子文本
+	Response *TTSResponse // 合成响应，Err 不为 nil 时可能为 nil
+	Err      error        // 合成失败的原因，出现错误后桥接停止
+}
+
+// TokenBridge 代表将文本片段流缓冲为句子并逐句合成的桥接，按句子顺序输出音频
+type TokenBridge struct {
+	Session *Session // 用于合成的会话
+	Style   string   // 音色风格，为空时仅应用音色
+
+	MinChars    int // 句子的最小字符数，过短的句子与下一句合并后再合成，<=0 时不合并
+	MaxChars    int // 未遇到句末标点时的最大缓冲字符数，超过时在句中标点处断开，<=0 时为 100
+	Parallelism int // 同时合成的句子数，<=0 时为 2，输出顺序始终与文本一致
+}
+
+// NewTokenBridge 创建一个使用会话合成的桥接
+func NewTokenBridge(s *Session) *TokenBridge {
+	return &TokenBridge{Session: s}
+}
+
+// Run 从 tokens 读取文本片段直至通道关闭，在句子边界触发合成，并按顺序将每句的语音发送到返回的通道
+// 全部句子输出完毕、出现错误、ctx 取消或会话被 Interrupt 打断后返回的通道关闭；调用方应持续读取返回的通道直至关闭
+func (b *TokenBridge) Run(ctx context.Context, tokens <-chan string) <-chan SpeechChunk {
+	return b.RunSeq(ctx, func(yield func(string) bool) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case token, ok := <-tokens:
+				if !ok || !yield(token) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// RunSeq 与 Run 相同，但从迭代器读取文本片段
+func (b *TokenBridge) RunSeq(ctx context.Context, tokens iter.Seq[string]) <-chan SpeechChunk {
+	out := make(chan SpeechChunk)
+	ctx, cancel := b.Session.bind(ctx)
+
+	parallelism := b.Parallelism
+	if parallelism <= 0 {
+		parallelism = defaultBridgeParallelism
+	}
+
+	// 按句子顺序提交合成，最多同时进行 Parallelism 句
+	type pending struct {
+		chunk SpeechChunk
+		done  chan struct{}
+	}
+	queue := make(chan *pending, parallelism)
+	sem := make(chan struct{}, parallelism)
+	go func() {
+		defer close(queue)
+
+		index := 0
+		submit := func(text string) bool {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return false
+			}
+
+			p := &pending{chunk: SpeechChunk{Index: index, Text: text}, done: make(chan struct{})}
+			index++
+			go func() {
+				defer func() { <-sem }()
+				defer close(p.done)
+
+				resp, err := b.Session.SpeakStyle(ctx, b.Style, text)
+				if err == nil {
+					err = resp.Err()
+				}
+				p.chunk.Response, p.chunk.Err = resp, err
+			}()
+
+			select {
+			case queue <- p:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		sb := &sentenceBuffer{minChars: b.MinChars, maxChars: b.MaxChars}
+		for token := range tokens {
+			for _, sentence := range sb.push(token) {
+				if !submit(sentence) {
+					return
+				}
+			}
+		}
+		for _, sentence := range sb.flush() {
+			if !submit(sentence) {
+				return
+			}
+		}
+	}()
+
+	// 按顺序输出，出现错误后停止
+	go func() {
+		defer close(out)
+		defer cancel()
+
+		for p := range queue {
+			<-p.done
+
+			// 会话被打断后丢弃尚未交付的音频
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case out <- p.chunk:
+			case <-ctx.Done():
+				return
+			}
+			if p.chunk.Err != nil {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// closingMarks 为可跟随在句末标点之后的右引号与右括号
+const closingMarks = "”’」』）)\"'"
+
+// sentenceBuffer 代表将文本片段缓冲为完整句子的缓冲区
+type sentenceBuffer struct {
+	minChars int
+	maxChars int
+	buf      string
+	pending  string // 因过短而等待与下一句合并的句子
+}
+
+// push 追加文本片段，返回已完整的句子
+func (s *sentenceBuffer) push(token string) []string {
+	s.buf += token
+	maxChars := s.maxChars
+	if maxChars <= 0 {
+		maxChars = defaultBridgeMaxChars
+	}
+
+	var out []string
+	for {
+		end := sentenceBoundary(s.buf)
+		if end < 0 && utf8.RuneCountInString(s.buf) > maxChars {
+			// 长时间没有句末标点时在句中标点处断开
+			end = len(limitLength(s.buf, maxChars)[0])
+		}
+		if end < 0 {
+			return out
+		}
+
+		sentence := s.buf[:end]
+		s.buf = s.buf[end:]
+		if text, ok := s.merge(sentence); ok {
+			out = append(out, text)
+		}
+	}
+}
+
+// flush 返回缓冲区中剩余的全部文本
+func (s *sentenceBuffer) flush() []string {
+	text := strings.TrimSpace(s.pending + s.buf)
+	s.pending, s.buf = "", ""
+	if text == "" {
+		return nil
+	}
+	return []string{text}
+}
+
+// merge 合并过短的句子，句子达到最小长度时返回合并后的文本
+func (s *sentenceBuffer) merge(sentence string) (string, bool) {
+	text := s.pending + sentence
+	if strings.TrimSpace(text) == "" {
+		s.pending = ""
+		return "", false
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(text)) < s.minChars {
+		s.pending = text
+		return "", false
+	}
+	s.pending = ""
+	return strings.TrimSpace(text), true
+}
+
+// sentenceBoundary 返回文本中第一个句子边界之后的字节偏移，不存在时返回 -1
+// 英文句末标点之后必须跟随空白才视为边界，避免在小数点或缩写处断开；
+// 句末标点位于文本末尾时尚无法确定之后是否还有右引号等，继续等待下一个片段
+func sentenceBoundary(text string) int {
+	for i, r := range text {
+		if !strings.ContainsRune(sentenceEnds, r) {
+			continue
+		}
+		end := i + utf8.RuneLen(r)
+		if r >= utf8.RuneSelf || r == '\n' {
+			// 连续的句末标点与右引号、右括号归入同一句
+			for end < len(text) {
+				next, size := utf8.DecodeRuneInString(text[end:])
+				if !strings.ContainsRune(sentenceEnds, next) && !strings.ContainsRune(closingMarks, next) {
+					break
+				}
+				end += size
+			}
+			if end == len(text) {
+				return -1
+			}
+			return end
+		}
+		next, size := utf8.DecodeRuneInString(text[end:])
+		if size > 0 && unicode.IsSpace(next) {
+			return end
+		}
+	}
+	return -1
+}
@@ -0,0 +1,192 @@
+package gpt_sovits_go_sdk
+
+// 提供合成结果的缓存层，相同的请求直接返回已合成的音频
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCacheMiss 表示缓存中不存在该键
+var ErrCacheMiss = errors.New("缓存未命中")
+
+// defaultMemoryCacheSize 为内存缓存的默认容量
+const defaultMemoryCacheSize = 64 << 20
+
+// CacheEntry 代表一条缓存的合成结果
+type CacheEntry struct {
+	Audio       []byte      `json:"-"`                 // 音频数据
+	ContentType string      `json:"content_type"`      // 响应的 Content-Type
+	Seed        int         `json:"seed"`              // 合成时实际使用的随机种子
+	Timing      *Timing     `json:"timing,omitempty"`  // 服务器返回的时间对齐信息
+	Request     *TTSRequest `json:"request,omitempty"` // 合并默认参数后的合成参数，便于检查缓存内容
+	CreatedAt   time.Time   `json:"created_at"`        // 写入时间
+}
+
+// Cache 代表合成结果的缓存，实现必须是并发安全的
+type Cache interface {
+	Get(ctx context.Context, key string) (*CacheEntry, error) // 读取缓存，不存在时返回 ErrCacheMiss
+	Set(ctx context.Context, key string, entry *CacheEntry) error
+}
+
+// WithCache 为 TTS 设置结果缓存，同时启用相同请求的合并（见 WithSingleflight）
+// 缓存键由基础URL、API 版本、模型管理器记录的当前权重、租户、调用方标识、自定义请求头与合并默认参数后的请求计算；
+// 未指定随机种子的请求视为接受任意种子，命中时返回首次合成的结果
+// 缓存读写失败时按未命中处理，不影响合成
+func WithCache(cache Cache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithoutCache 使本次调用跳过缓存读写与相同请求的合并，总是发送到服务器
+func WithoutCache() CallOption {
+	return func(o *callOptions) {
+		o.noCache = true
+	}
+}
+
+// cacheKey 计算请求的缓存键，不同租户与调用方的请求互不共享缓存与合并结果
+func (c *Client) cacheKey(req TTSRequest, o *callOptions) (string, error) {
+	gpt, sovits := c.Models().Current()
+	data, err := json.Marshal(struct {
+		BaseURL    string            `json:"base_url"`
+		APIVersion string            `json:"api_version"`
+		GPT        string            `json:"gpt,omitempty"`
+		SoVITS     string            `json:"sovits,omitempty"`
+		Tenant     string            `json:"tenant,omitempty"`
+		UsageKey   string            `json:"usage_key,omitempty"`
+		Headers    map[string]string `json:"headers,omitempty"`
+		Request    TTSRequest        `json:"request"`
+	}{c.BaseURL, c.apiVersion.String(), gpt, sovits, c.tenant, o.usageKey, o.headers, c.applyDefaults(req)})
+	if err != nil {
+		return "", fmt.Errorf("请求序列化失败: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// cachedResponse 从缓存构建响应，未命中时返回 nil
+func (c *Client) cachedResponse(ctx context.Context, key string, o *callOptions, start time.Time) *TTSResponse {
+	entry, err := c.cache.Get(ctx, key)
+	if err != nil || entry == nil || len(entry.Audio) == 0 {
+		return nil
+	}
+
+	ttsResp := &TTSResponse{
+		StatusCode:  http.StatusOK,
+		AudioData:   entry.Audio,
+		ContentType: entry.ContentType,
+		Seed:        entry.Seed,
+		Timing:      entry.Timing,
+		Elapsed:     time.Since(start),
+		FromCache:   true,
+	}
+	c.fillAudioInfo(ttsResp)
+	ttsResp.Samples, ttsResp.Duration, _ = audioLength(ttsResp)
+	if o.checksum {
+		fillChecksum(ttsResp)
+	}
+	return ttsResp
+}
+
+// storeResponse 将成功的响应写入缓存
+func (c *Client) storeResponse(ctx context.Context, key string, req TTSRequest, ttsResp *TTSResponse) {
+	if ttsResp.Err() != nil || len(ttsResp.AudioData) == 0 {
+		return
+	}
+	req = c.applyDefaults(req)
+	_ = c.cache.Set(ctx, key, &CacheEntry{
+		Audio:       ttsResp.AudioData,
+		ContentType: ttsResp.ContentType,
+		Seed:        ttsResp.Seed,
+		Timing:      ttsResp.Timing,
+		Request:     &req,
+		CreatedAt:   time.Now(),
+	})
+}
+
+// MemoryCache 代表按最近最少使用淘汰的内存缓存，并发安全
+type MemoryCache struct {
+	maxBytes int64
+
+	mu      sync.Mutex
+	size    int64
+	order   *list.List // 按使用时间排列，队首为最近使用
+	entries map[string]*list.Element
+}
+
+// memoryItem 代表内存缓存中的一项
+type memoryItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache 创建一个新的内存缓存，maxBytes 为音频数据的总容量，<=0 时为 64 MiB
+func NewMemoryCache(maxBytes int64) *MemoryCache {
+	if maxBytes <= 0 {
+		maxBytes = defaultMemoryCacheSize
+	}
+	return &MemoryCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get 实现 Cache 接口
+func (m *MemoryCache) Get(_ context.Context, key string) (*CacheEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+	m.order.MoveToFront(el)
+	return el.Value.(*memoryItem).entry, nil
+}
+
+// Set 实现 Cache 接口，超过容量的单项不写入
+func (m *MemoryCache) Set(_ context.Context, key string, entry *CacheEntry) error {
+	size := int64(len(entry.Audio))
+	if size > m.maxBytes {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		m.size -= int64(len(el.Value.(*memoryItem).entry.Audio))
+		m.order.Remove(el)
+	}
+	m.entries[key] = m.order.PushFront(&memoryItem{key: key, entry: entry})
+	m.size += size
+
+	// 淘汰最久未使用的项直至不超过容量
+	for m.size > m.maxBytes {
+		el := m.order.Back()
+		item := el.Value.(*memoryItem)
+		m.order.Remove(el)
+		delete(m.entries, item.key)
+		m.size -= int64(len(item.entry.Audio))
+	}
+	return nil
+}
+
+// Len 返回缓存的项数
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.entries)
+}
@@ -0,0 +1,159 @@
+package gpt_sovits_go_sdk
+
+// 提供服务器能力探测，构建请求时去除服务器不支持的可选字段，避免不同分支的服务器返回 400
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+)
+
+// Capabilities 代表服务器支持的 /tts 请求字段
+type Capabilities struct {
+	Fields map[string]bool `json:"fields"` // 支持的字段，键为 JSON 字段名，如 "sample_steps"；为 nil 时视为全部支持
+}
+
+// NewCapabilities 创建支持给定字段的能力集
+func NewCapabilities(fields ...string) *Capabilities {
+	caps := &Capabilities{Fields: make(map[string]bool, len(fields))}
+	for _, f := range fields {
+		caps.Fields[f] = true
+	}
+	return caps
+}
+
+// Supports 判断服务器是否支持给定的请求字段
+func (caps *Capabilities) Supports(field string) bool {
+	return caps == nil || caps.Fields == nil || caps.Fields[field]
+}
+
+// List 返回按名称排序的支持字段
+func (caps *Capabilities) List() []string {
+	var out []string
+	for f, ok := range caps.Fields {
+		if ok {
+			out = append(out, f)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// optionalFields 为可以去除的可选字段及其清零方法，必填字段与内嵌参考音频始终发送
+var optionalFields = []struct {
+	name  string
+	clear func(r *TTSRequest)
+}{
+	{"aux_ref_audio_paths", func(r *TTSRequest) { r.AuxRefAudioPaths = nil }},
+	{"top_k", func(r *TTSRequest) { r.TopK = 0 }},
+	{"top_p", func(r *TTSRequest) { r.TopP = 0 }},
+	{"temperature", func(r *TTSRequest) { r.Temperature = 0 }},
+	{"text_split_method", func(r *TTSRequest) { r.TextSplitMethod = "" }},
+	{"batch_size", func(r *TTSRequest) { r.BatchSize = 0 }},
+	{"batch_threshold", func(r *TTSRequest) { r.BatchThreshold = 0 }},
+	{"split_bucket", func(r *TTSRequest) { r.SplitBucket = nil }},
+	{"speed_factor", func(r *TTSRequest) { r.SpeedFactor = 0 }},
+	{"fragment_interval", func(r *TTSRequest) { r.FragmentInterval = 0 }},
+	{"seed", func(r *TTSRequest) { r.Seed = 0 }},
+	{"parallel_infer", func(r *TTSRequest) { r.ParallelInfer = nil }},
+	{"repetition_penalty", func(r *TTSRequest) { r.RepetitionPenalty = 0 }},
+	{"sample_steps", func(r *TTSRequest) { r.SampleSteps = 0 }},
+	{"super_sampling", func(r *TTSRequest) { r.SuperSampling = false }},
+}
+
+// Strip 去除请求中服务器不支持的可选字段
+func (caps *Capabilities) Strip(req *TTSRequest) {
+	for _, f := range optionalFields {
+		if !caps.Supports(f.name) {
+			f.clear(req)
+		}
+	}
+}
+
+// WithCapabilities 设置服务器支持的请求字段，构建请求时去除不支持的可选字段
+func WithCapabilities(caps *Capabilities) ClientOption {
+	return func(c *Client) {
+		c.caps = caps
+	}
+}
+
+// Capabilities 返回服务器支持的请求字段
+// 未通过 WithCapabilities 设置时读取服务器的 /openapi.json（FastAPI 自动生成）探测 /tts 的请求模型，探测结果会被缓存并用于之后的请求
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	c.capsMu.Lock()
+	defer c.capsMu.Unlock()
+
+	if c.caps != nil {
+		return c.caps, nil
+	}
+
+	caps, err := c.probeCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.caps = caps
+	return caps, nil
+}
+
+// knownCapabilities 返回已设置或已探测的能力集，未知时返回 nil
+func (c *Client) knownCapabilities() *Capabilities {
+	c.capsMu.Lock()
+	defer c.capsMu.Unlock()
+
+	return c.caps
+}
+
+// probeCapabilities 从 OpenAPI 文档中查找 /tts 的请求模型并读取其字段
+func (c *Client) probeCapabilities(ctx context.Context) (*Capabilities, error) {
+	// 创建GET请求
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/openapi.json", c.BaseURL), nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建探测请求失败: %w", err)
+	}
+
+	// 发送请求
+	resp, err := c.do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("探测服务器能力失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 读取响应体
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取 OpenAPI 文档失败: %w", err)
+	}
+
+	// 检查响应状态
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("探测服务器能力失败，状态码 %d，请使用 WithCapabilities 手动设置", resp.StatusCode)
+	}
+
+	var doc struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("解析 OpenAPI 文档失败: %w", err)
+	}
+
+	// 包含 text、text_lang 与 ref_audio_path 的模型即为 /tts 的请求模型
+	for _, schema := range doc.Components.Schemas {
+		props := schema.Properties
+		if props["text"] == nil || props["text_lang"] == nil || props["ref_audio_path"] == nil {
+			continue
+		}
+		caps := &Capabilities{Fields: make(map[string]bool, len(props))}
+		for name := range props {
+			caps.Fields[name] = true
+		}
+		return caps, nil
+	}
+	return nil, fmt.Errorf("OpenAPI 文档中未找到 /tts 的请求模型，请使用 WithCapabilities 手动设置")
+}
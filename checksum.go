@@ -0,0 +1,46 @@
+package gpt_sovits_go_sdk
+
+// 提供音频校验和与时长的计算，用于去重、缓存校验与截断检测
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/audio"
+)
+
+// WithChecksum 在响应中计算音频的 SHA-256
+func WithChecksum() CallOption {
+	return func(o *callOptions) {
+		o.checksum = true
+	}
+}
+
+// fillChecksum 计算音频数据的校验和
+func fillChecksum(ttsResp *TTSResponse) {
+	sum := sha256.Sum256(ttsResp.AudioData)
+	ttsResp.SHA256 = hex.EncodeToString(sum[:])
+}
+
+// audioLength 按检测到的格式计算每声道采样帧数与时长，无法计算时 ok 为 false
+func audioLength(ttsResp *TTSResponse) (samples int, d time.Duration, ok bool) {
+	switch ttsResp.Format {
+	case MediaTypeWAV:
+		w, err := audio.ParseWAV(ttsResp.AudioData)
+		if err != nil {
+			return 0, 0, false
+		}
+		if frame := w.Channels * w.BitDepth / 8; frame > 0 {
+			samples = len(w.Data) / frame
+		}
+		return samples, w.Duration(), true
+	case MediaTypeRAW:
+		// raw 输出为 16 位 PCM
+		if ttsResp.Channels > 0 && ttsResp.SampleRate > 0 {
+			samples = len(ttsResp.AudioData) / (2 * ttsResp.Channels)
+			return samples, time.Duration(samples) * time.Second / time.Duration(ttsResp.SampleRate), true
+		}
+	}
+	return 0, 0, false
+}
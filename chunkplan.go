@@ -0,0 +1,261 @@
+package gpt_sovits_go_sdk
+
+// 提供可断点续传的分块合成清单，编辑源文本后重新合成时只合成内容变化的块
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/audio"
+)
+
+// ChunkManifestFile 为分块清单的文件名
+const ChunkManifestFile = "chunks.json"
+
+// ChunkRecord 代表清单中的一块
+type ChunkRecord struct {
+	Index        int           `json:"index"`                    // 块序号
+	Text         string        `json:"text"`                     // 块文本
+	Key          string        `json:"key"`                      // 文本与合成参数的摘要，决定是否需要重新合成
+	File         string        `json:"file"`                     // 音频文件名（相对于清单目录）
+	SHA256       string        `json:"sha256"`                   // 音频文件的 SHA-256，用于校验文件完整
+	Duration     time.Duration `json:"duration"`                 // 音频时长
+	Start        time.Duration `json:"start"`                    // 在拼接音频中的起始时间
+	End          time.Duration `json:"end"`                      // 在拼接音频中的结束时间
+	RefAudioPath string        `json:"ref_audio_path,omitempty"` // 该块使用的参考音频
+}
+
+// ChunkManifest 代表分块合成的清单
+type ChunkManifest struct {
+	Chunks []ChunkRecord `json:"chunks"` // 按顺序排列的块
+}
+
+// PlannedChunk 代表计划中的一块
+type PlannedChunk struct {
+	Index   int        // 块序号
+	Text    string     // 块文本
+	Key     string     // 文本与合成参数的摘要
+	File    string     // 音频文件名
+	Request TTSRequest // 该块的合成请求
+	Cached  bool       // 清单中已有内容一致的音频，无需重新合成
+}
+
+// ChunkPlan 代表一次分块合成的计划，由 PlanChunks 生成、ApplyChunks 执行
+type ChunkPlan struct {
+	Dir     string          // 清单与音频文件所在目录
+	Chunks  []PlannedChunk  // 按顺序排列的块
+	Options LongTextOptions // 切分与拼接选项
+
+	cached map[string]ChunkRecord // 可复用的已有块，按 Key 索引
+	stale  []string               // 不再被引用的音频文件
+}
+
+// Pending 返回需要合成的块
+func (p *ChunkPlan) Pending() []PlannedChunk {
+	var out []PlannedChunk
+	for _, ch := range p.Chunks {
+		if !ch.Cached {
+			out = append(out, ch)
+		}
+	}
+	return out
+}
+
+// Stale 返回旧清单中不再被引用的音频文件，ApplyChunks 完成后将其删除
+func (p *ChunkPlan) Stale() []string {
+	return p.stale
+}
+
+// PlanChunks 切分文本并与 dir 中的清单比较，得出需要重新合成的块而不发送请求
+// 块按文本与合并默认参数后的合成参数计算摘要，插入或删除句子不会使其余块失效
+func (c *Client) PlanChunks(dir string, req TTSRequest, opts *LongTextOptions) (*ChunkPlan, error) {
+	if opts == nil {
+		opts = &LongTextOptions{}
+	}
+
+	texts := opts.Splitter.Split(req.Text)
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("待合成文本为空")
+	}
+
+	// 读取已有清单，清单不存在时全部重新合成
+	prev, err := LoadChunkManifest(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if prev == nil {
+		prev = &ChunkManifest{}
+	}
+
+	plan := &ChunkPlan{Dir: dir, Options: *opts, cached: make(map[string]ChunkRecord)}
+	used := make(map[string]bool)
+	for i, text := range texts {
+		chunkReq := req
+		chunkReq.Text = text
+		if opts.Voice != nil {
+			opts.Voice.ApplyRef(&chunkReq, i)
+		}
+		chunkReq.MediaType = MediaTypeWAV
+		chunkReq.StreamingMode = false
+
+		key, err := chunkKey(c.applyDefaults(chunkReq), opts.Trim)
+		if err != nil {
+			return nil, err
+		}
+		plan.Chunks = append(plan.Chunks, PlannedChunk{
+			Index:   i,
+			Text:    text,
+			Key:     key,
+			File:    key[:16] + ".wav",
+			Request: chunkReq,
+		})
+		used[key[:16]+".wav"] = true
+	}
+
+	// 文件存在且摘要一致的块可以复用
+	for _, rec := range prev.Chunks {
+		if used[rec.File] && fileSHA256(filepath.Join(dir, rec.File)) == rec.SHA256 {
+			plan.cached[rec.Key] = rec
+		}
+		if !used[rec.File] {
+			plan.stale = append(plan.stale, rec.File)
+		}
+	}
+	for i := range plan.Chunks {
+		_, plan.Chunks[i].Cached = plan.cached[plan.Chunks[i].Key]
+	}
+	return plan, nil
+}
+
+// ApplyChunks 合成计划中需要重新合成的块并拼接全部块，返回拼接结果
+// 每合成一块即更新清单，中断后重新 PlanChunks 与 ApplyChunks 可从中断处继续
+func (c *Client) ApplyChunks(ctx context.Context, plan *ChunkPlan) (*LongTextResult, error) {
+	if err := os.MkdirAll(plan.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	// 进行中的清单保留旧记录，使中断后已合成的块仍可复用
+	progress := &ChunkManifest{}
+	done := make(map[string]ChunkRecord, len(plan.cached))
+	for key, rec := range plan.cached {
+		progress.Chunks = append(progress.Chunks, rec)
+		done[key] = rec
+	}
+
+	segments := make([]*audio.WAV, len(plan.Chunks))
+	records := make([]ChunkRecord, len(plan.Chunks))
+	for i, ch := range plan.Chunks {
+		var seg *audio.WAV
+		rec, ok := done[ch.Key]
+		if ok {
+			// 读取已有的块音频
+			data, err := os.ReadFile(filepath.Join(plan.Dir, rec.File))
+			if err != nil {
+				return nil, fmt.Errorf("第 %d 块: 读取音频失败: %w", i+1, err)
+			}
+			if seg, err = audio.ParseWAV(data); err != nil {
+				return nil, fmt.Errorf("第 %d 块: %w", i+1, err)
+			}
+		} else {
+			var err error
+			if seg, err = c.synthesizeChunk(ctx, ch.Request, ch.Text); err != nil {
+				return nil, fmt.Errorf("第 %d 块: %w", i+1, err)
+			}
+			if plan.Options.Trim != nil {
+				if seg, err = audio.Trim(seg, *plan.Options.Trim); err != nil {
+					return nil, fmt.Errorf("第 %d 块: %w", i+1, err)
+				}
+			}
+
+			data := seg.Bytes()
+			if err := writeFileAtomic(filepath.Join(plan.Dir, ch.File), data); err != nil {
+				return nil, fmt.Errorf("第 %d 块: %w", i+1, err)
+			}
+			sum := sha256.Sum256(data)
+			rec = ChunkRecord{Key: ch.Key, File: ch.File, SHA256: hex.EncodeToString(sum[:])}
+			done[ch.Key] = rec
+
+			progress.Chunks = append(progress.Chunks, rec)
+			if err := progress.save(plan.Dir); err != nil {
+				return nil, err
+			}
+		}
+
+		rec.Index, rec.Text, rec.RefAudioPath = i, ch.Text, ch.Request.RefAudioPath
+		rec.Duration = seg.Duration()
+		records[i] = rec
+		segments[i] = seg
+	}
+
+	stitched, spans, err := audio.ConcatWithSpans(segments, audio.ConcatOptions{Gap: plan.Options.Gap, Crossfade: plan.Options.Crossfade})
+	if err != nil {
+		return nil, err
+	}
+
+	// 写出最终清单并删除不再引用的文件
+	chunks := make([]Chunk, len(records))
+	for i, span := range spans {
+		records[i].Start, records[i].End = span.Start, span.End
+		chunks[i] = Chunk{Index: i, Text: records[i].Text, Start: span.Start, End: span.End, RefAudioPath: records[i].RefAudioPath}
+	}
+	if err := (&ChunkManifest{Chunks: records}).save(plan.Dir); err != nil {
+		return nil, err
+	}
+	for _, name := range plan.stale {
+		_ = os.Remove(filepath.Join(plan.Dir, name))
+	}
+
+	return &LongTextResult{Audio: stitched.Bytes(), Chunks: chunks}, nil
+}
+
+// LoadChunkManifest 读取目录中的分块清单，清单不存在时返回的错误满足 errors.Is(err, os.ErrNotExist)
+func LoadChunkManifest(dir string) (*ChunkManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ChunkManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var m ChunkManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("解析分块清单失败: %w", err)
+	}
+	return &m, nil
+}
+
+// save 将清单写入目录
+func (m *ChunkManifest) save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("分块清单序列化失败: %w", err)
+	}
+	return writeFileAtomic(filepath.Join(dir, ChunkManifestFile), data)
+}
+
+// chunkKey 计算块文本与合成参数的摘要，裁剪选项影响输出文件，一并计入
+func chunkKey(req TTSRequest, trim *audio.TrimOptions) (string, error) {
+	data, err := json.Marshal(struct {
+		Request TTSRequest         `json:"request"`
+		Trim    *audio.TrimOptions `json:"trim,omitempty"`
+	}{req, trim})
+	if err != nil {
+		return "", fmt.Errorf("请求序列化失败: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// fileSHA256 返回文件的 SHA-256，读取失败时返回空字符串
+func fileSHA256(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
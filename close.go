@@ -0,0 +1,72 @@
+package gpt_sovits_go_sdk
+
+// 提供客户端的关闭，释放连接池与后台协程
+
+import (
+	"errors"
+)
+
+// ErrClientClosed 表示客户端已关闭
+var ErrClientClosed = errors.New("客户端已关闭")
+
+// Close 关闭客户端并释放资源，可重复调用，并发安全
+//
+// 关闭时依次：
+//   - 阻止新的合成请求，之后的调用返回 ErrClientClosed
+//   - 排队中的异步任务不再发送，其 Future 以 ErrClientClosed 完成
+//   - 等待异步调度器执行中的任务完成并停止工作协程
+//   - 关闭连接池中的空闲连接
+//
+// 已发出的同步请求不受影响，Close 不等待其完成
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		c.gate.close()
+
+		// 占用初始化，使关闭后不再启动调度器
+		c.dispatcherOnce.Do(func() {})
+		if c.dispatcher != nil {
+			c.dispatcher.close()
+		}
+
+		c.closeIdleConnections()
+	})
+	return nil
+}
+
+// closeIdleConnections 关闭默认传输层与自定义传输层中的空闲连接
+func (c *Client) closeIdleConnections() {
+	if c.HTTPClient != nil {
+		c.HTTPClient.CloseIdleConnections()
+	}
+	if t, ok := c.Transport.(interface{ CloseIdleConnections() }); ok {
+		t.CloseIdleConnections()
+	}
+}
+
+// Close 停止后台健康检查并等待其退出，然后关闭所有后端的客户端
+func (p *PoolClient) Close() error {
+	p.Stop()
+	p.checks.Wait()
+
+	var errs []error
+	for _, b := range p.backends {
+		errs = append(errs, b.client.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Close 关闭所有已创建的租户客户端及共享连接池中的空闲连接
+// 关闭后 Get 仍可为租户创建新的客户端
+func (m *ClientManager) Close() error {
+	m.mu.Lock()
+	clients := m.clients
+	m.clients = make(map[string]*Client)
+	m.mu.Unlock()
+
+	var errs []error
+	for _, c := range clients {
+		errs = append(errs, c.Close())
+	}
+	m.transport.CloseIdleConnections()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,322 @@
+package main
+
+// 提供各子命令的实现
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	gsv "github.com/ssdomei232/gpt_sovits_go_sdk"
+	"github.com/ssdomei232/gpt_sovits_go_sdk/bench"
+)
+
+// runTTS 执行 tts 命令
+func runTTS(args []string) error {
+	fs := flag.NewFlagSet("tts", flag.ExitOnError)
+	var common commonFlags
+	common.register(fs)
+
+	text := fs.String("text", "", "需要合成的文本，为 \"-\" 时从标准输入读取")
+	textLang := fs.String("text-lang", "", "合成文本的语言")
+	ref := fs.String("ref", "", "参考音频路径（服务器可见的路径）")
+	promptText := fs.String("prompt-text", "", "参考音频的提示文本")
+	promptLang := fs.String("prompt-lang", "", "参考音频提示文本的语言")
+	out := fs.String("out", "output.wav", "输出文件路径，为 \"-\" 时写入标准输出")
+	mediaType := fs.String("media-type", "", "输出音频类型: wav、ogg、aac、raw，默认 wav")
+	stream := fs.Bool("stream", false, "使用流式模式边合成边写出")
+	speed := fs.Float64("speed", 0, "语速系数")
+	seed := fs.Int("seed", 0, "随机种子")
+	_ = fs.Parse(args)
+
+	cfg, client, err := common.newClient()
+	if err != nil {
+		return err
+	}
+
+	// 读取待合成文本
+	if *text == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("读取标准输入失败: %w", err)
+		}
+		*text = string(data)
+	}
+	if *text == "" {
+		return fmt.Errorf("缺少 --text 参数")
+	}
+
+	// 以配置中的默认参数与默认音色为基础，命令行参数优先
+	req := cfg.Request(*text)
+	req.TextLang = firstNonEmpty(*textLang, req.TextLang)
+	req.RefAudioPath = firstNonEmpty(*ref, req.RefAudioPath)
+	req.PromptText = firstNonEmpty(*promptText, req.PromptText)
+	req.PromptLang = firstNonEmpty(*promptLang, req.PromptLang)
+	req.MediaType = gsv.MediaType(firstNonEmpty(*mediaType, string(req.MediaType), string(gsv.MediaTypeWAV)))
+	if *speed > 0 {
+		req.SpeedFactor = *speed
+	}
+	if *seed != 0 {
+		req.Seed = *seed
+	}
+	if req.RefAudioPath == "" {
+		return fmt.Errorf("缺少 --ref 参数")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// 流式模式边接收边写出
+	if *stream {
+		var w io.Writer = os.Stdout
+		if *out != "-" {
+			f, err := os.Create(*out)
+			if err != nil {
+				return fmt.Errorf("创建输出文件失败: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		n, err := client.TTSStreamTo(ctx, req, w)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "已写入 %d 字节\n", n)
+		return nil
+	}
+
+	resp, err := client.TTS(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := resp.Err(); err != nil {
+		return err
+	}
+
+	// 写出音频
+	if *out == "-" {
+		_, err = os.Stdout.Write(resp.AudioData)
+	} else {
+		err = os.WriteFile(*out, resp.AudioData, 0o644)
+	}
+	if err != nil {
+		return fmt.Errorf("写入音频失败: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "已写入 %d 字节\n", len(resp.AudioData))
+	return nil
+}
+
+// runSwitchModel 执行 switch-model 命令
+func runSwitchModel(args []string) error {
+	fs := flag.NewFlagSet("switch-model", flag.ExitOnError)
+	var common commonFlags
+	common.register(fs)
+
+	gpt := fs.String("gpt", "", "GPT 模型权重路径")
+	sovits := fs.String("sovits", "", "SoVITS 模型权重路径")
+	_ = fs.Parse(args)
+
+	if *gpt == "" && *sovits == "" {
+		return fmt.Errorf("至少需要指定 --gpt 或 --sovits 之一")
+	}
+
+	_, client, err := common.newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if *gpt != "" {
+		if err := client.SetGPTWeights(ctx, *gpt); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "GPT权重更新成功")
+	}
+	if *sovits != "" {
+		if err := client.SetSoVITSWeights(ctx, *sovits); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "SoVITS权重更新成功")
+	}
+	return nil
+}
+
+// runListModels 执行 list-models 命令
+func runListModels(args []string) error {
+	fs := flag.NewFlagSet("list-models", flag.ExitOnError)
+	var common commonFlags
+	common.register(fs)
+
+	kind := fs.String("kind", "all", "列出的权重类型: gpt、sovits 或 all")
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	_ = fs.Parse(args)
+
+	_, client, err := common.newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	list := &gsv.WeightsList{}
+	switch *kind {
+	case "gpt":
+		list.GPT, err = client.ListGPTWeights(ctx)
+	case "sovits":
+		list.SoVITS, err = client.ListSoVITSWeights(ctx)
+	case "all":
+		list, err = client.ListWeights(ctx)
+	default:
+		return fmt.Errorf("不支持的权重类型 %q", *kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+
+	// 按类型逐行输出名称与路径
+	for _, group := range []struct {
+		name  string
+		files []gsv.WeightFile
+	}{{"gpt", list.GPT}, {"sovits", list.SoVITS}} {
+		for _, f := range group.files {
+			fmt.Printf("%s\t%s\t%s\n", group.name, f.Name, f.Path)
+		}
+	}
+	return nil
+}
+
+// runControl 执行 control 命令
+func runControl(args []string) error {
+	fs := flag.NewFlagSet("control", flag.ExitOnError)
+	var common commonFlags
+	common.register(fs)
+	wait := fs.Duration("wait", 0, "执行 restart 后等待服务器重新上线的最长时间，为0时不等待")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "用法: gptsovits control [参数] <restart|exit>")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("缺少控制命令")
+	}
+	command := gsv.ControlCommand(fs.Arg(0))
+	if err := command.Validate(); err != nil {
+		return err
+	}
+
+	_, client, err := common.newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if command == gsv.CommandRestart && *wait > 0 {
+		if err := client.RestartAndWait(ctx, *wait); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "服务器已重启")
+		return nil
+	}
+
+	if err := client.Control(ctx, command); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "控制命令执行成功")
+	return nil
+}
+
+// runBench 执行 bench 命令
+func runBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	var common commonFlags
+	common.register(fs)
+
+	text := fs.String("text", "今天天气很好，我们一起去公园散步吧。", "合成文本")
+	ref := fs.String("ref", "", "参考音频路径（服务器可见的路径）")
+	promptText := fs.String("prompt-text", "", "参考音频的提示文本")
+	concurrency := fs.Int("concurrency", 1, "并发数")
+	duration := fs.Duration("duration", 0, "压测持续时间，如 30s、5m")
+	requests := fs.Int("requests", 0, "最大请求数")
+	format := fs.String("format", "json", "报告格式: json 或 csv")
+	out := fs.String("out", "-", "报告输出路径，为 \"-\" 时写入标准输出")
+	_ = fs.Parse(args)
+
+	if *duration <= 0 && *requests <= 0 {
+		*requests = 10
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("不支持的报告格式 %q", *format)
+	}
+
+	cfg, client, err := common.newClient()
+	if err != nil {
+		return err
+	}
+
+	req := cfg.Request(*text)
+	req.RefAudioPath = firstNonEmpty(*ref, req.RefAudioPath)
+	req.PromptText = firstNonEmpty(*promptText, req.PromptText)
+	req.MediaType = gsv.MediaTypeWAV
+	if req.RefAudioPath == "" {
+		return fmt.Errorf("缺少 --ref 参数")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := bench.Run(ctx, bench.Config{
+		Client:      client,
+		Request:     req,
+		Concurrency: *concurrency,
+		Duration:    *duration,
+		Requests:    *requests,
+	})
+	if err != nil {
+		return err
+	}
+
+	// 写出报告
+	var w io.Writer = os.Stdout
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("创建报告文件失败: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if *format == "csv" {
+		err = report.WriteCSV(w)
+	} else {
+		err = report.WriteJSON(w)
+	}
+	if err != nil {
+		return fmt.Errorf("写出报告失败: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "请求 %d，失败 %d，吞吐 %.2f 次/秒，P50 %.0fms，P95 %.0fms，平均实时率 %.3f\n",
+		report.Requests, report.Errors, report.Throughput, report.LatencyMs.P50, report.LatencyMs.P95, report.RTF.Mean)
+	return nil
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
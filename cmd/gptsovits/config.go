@@ -0,0 +1,90 @@
+package main
+
+// 提供命令行工具的配置加载，优先级为命令行参数 > 环境变量 > 配置文件 > 默认值
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	gsv "github.com/ssdomei232/gpt_sovits_go_sdk"
+)
+
+// commonFlags 代表所有命令共享的参数
+type commonFlags struct {
+	configPath string
+	baseURL    string
+	timeout    time.Duration
+}
+
+// register 在参数集上注册通用参数
+func (f *commonFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.configPath, "config", "", "配置文件路径（JSON），默认读取 $GPTSOVITS_CONFIG 或 ~/.config/gptsovits/config.json")
+	fs.StringVar(&f.baseURL, "url", "", "服务器地址，默认 http://127.0.0.1:9880")
+	fs.DurationVar(&f.timeout, "timeout", 0, "请求超时时间，如 60s、5m")
+}
+
+// load 按优先级合并配置文件、环境变量与命令行参数
+func (f *commonFlags) load() (*gsv.ClientConfig, error) {
+	cfg := &gsv.ClientConfig{}
+
+	// 配置文件
+	path := f.configPath
+	explicit := path != ""
+	if path == "" {
+		path = os.Getenv("GPTSOVITS_CONFIG")
+		explicit = path != ""
+	}
+	if path == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "gptsovits", "config.json")
+		}
+	}
+	if path != "" {
+		loaded, err := gsv.LoadConfig(path)
+		switch {
+		case err == nil:
+			cfg = loaded
+		case !errors.Is(err, os.ErrNotExist) || explicit:
+			return nil, err
+		}
+	}
+
+	// 环境变量
+	if err := cfg.ApplyEnv(); err != nil {
+		return nil, err
+	}
+
+	// 命令行参数
+	if f.baseURL != "" {
+		cfg.BaseURL = f.baseURL
+	}
+	if f.timeout > 0 {
+		cfg.Timeout = gsv.Duration(f.timeout)
+	}
+
+	// 默认值
+	if cfg.Voice == nil {
+		cfg.Voice = &gsv.Voice{}
+	}
+	if cfg.Voice.PromptLang == "" {
+		cfg.Voice.PromptLang = "zh"
+	}
+	if cfg.Defaults.TextLang == "" {
+		cfg.Defaults.TextLang = "zh"
+	}
+
+	return cfg, nil
+}
+
+// newClient 加载配置并创建 SDK 客户端
+func (f *commonFlags) newClient() (*gsv.ClientConfig, *gsv.Client, error) {
+	cfg, err := f.load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("加载配置失败: %w", err)
+	}
+	return cfg, cfg.NewClient(), nil
+}
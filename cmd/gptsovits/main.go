@@ -0,0 +1,76 @@
+// gptsovits 是 GPT-SoVITS API 的命令行工具
+//
+// 用法:
+//
+//	gptsovits tts --text "你好" --ref ref.wav --prompt-text "参考文本" --out out.wav
+//	gptsovits switch-model --gpt model.ckpt --sovits model.pth
+//	gptsovits list-models --kind gpt
+//	gptsovits control restart
+//	gptsovits bench --concurrency 4 --duration 1m --ref ref.wav --format csv
+//
+// 服务器地址等参数可通过命令行参数、环境变量或配置文件提供，优先级依次降低
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// usage 为顶层帮助信息
+const usage = `gptsovits - GPT-SoVITS API 命令行工具
+
+用法:
+  gptsovits <命令> [参数]
+
+命令:
+  tts           合成语音
+  switch-model  切换 GPT/SoVITS 模型权重
+  list-models   列出服务器上可用的 GPT/SoVITS 模型权重
+  control       发送控制命令 (restart 或 exit)
+  bench         压测服务器吞吐量，输出延迟分位数、实时率与错误率
+
+使用 "gptsovits <命令> -h" 查看命令参数
+
+环境变量:
+  GPTSOVITS_URL          服务器地址
+  GPTSOVITS_CONFIG       配置文件路径
+  GPTSOVITS_TIMEOUT      请求超时时间，如 "5m"
+  GPTSOVITS_API_KEY      以 Bearer 方式发送的认证令牌
+  GPTSOVITS_REF_AUDIO    默认参考音频路径
+  GPTSOVITS_PROMPT_TEXT  默认参考音频提示文本
+  GPTSOVITS_PROMPT_LANG  默认参考音频提示文本语言
+  GPTSOVITS_TEXT_LANG    默认合成文本语言
+  GPTSOVITS_MEDIA_TYPE   默认输出音频类型
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "tts":
+		err = runTTS(os.Args[2:])
+	case "switch-model":
+		err = runSwitchModel(os.Args[2:])
+	case "list-models":
+		err = runListModels(os.Args[2:])
+	case "control":
+		err = runControl(os.Args[2:])
+	case "bench":
+		err = runBench(os.Args[2:])
+	case "-h", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "未知命令 %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "错误:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,131 @@
+package gpt_sovits_go_sdk
+
+// 提供 gzip 请求体压缩与响应体的透明解压，适用于经广域网传输长文本与大音频的场景
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// defaultGzipMinSize 为压缩请求体的默认最小字节数
+const defaultGzipMinSize = 1 << 10
+
+// WithGzipRequests 以 gzip 压缩不小于 minSize 字节的请求体并设置 Content-Encoding，<=0 时为 1 KiB
+// 服务器或网关需支持解压请求体；压缩在中间件链之后进行，请求签名覆盖的是压缩前的请求体
+func WithGzipRequests(minSize int) ClientOption {
+	return func(c *Client) {
+		if minSize <= 0 {
+			minSize = defaultGzipMinSize
+		}
+		c.gzipMinSize = minSize
+	}
+}
+
+// WithoutCompression 禁用请求体压缩与响应体的 gzip 协商，用于无法正确处理压缩的服务器或网关
+func WithoutCompression() ClientOption {
+	return func(c *Client) {
+		c.noCompression = true
+		c.httpTransport().DisableCompression = true
+	}
+}
+
+// compress 为传输调用添加请求体压缩与响应体解压
+// 默认传输层会自行协商并解压响应，自定义传输层时由客户端发送 Accept-Encoding 并解压
+func (c *Client) compress(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if c.noCompression {
+			return next(req)
+		}
+
+		if c.gzipMinSize > 0 {
+			gzipped, err := gzipRequest(req, c.gzipMinSize)
+			if err != nil {
+				return nil, err
+			}
+			req = gzipped
+		}
+
+		manual := c.Transport != nil && req.Header.Get("Accept-Encoding") == ""
+		if manual {
+			req = req.Clone(req.Context())
+			req.Header.Set("Accept-Encoding", "gzip")
+		}
+
+		resp, err := next(req)
+		if err != nil || !manual {
+			return resp, err
+		}
+		if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+			resp.Body = &gzipBody{body: resp.Body}
+			resp.Header.Del("Content-Encoding")
+			resp.Header.Del("Content-Length")
+			resp.ContentLength = -1
+			resp.Uncompressed = true
+		}
+		return resp, nil
+	}
+}
+
+// gzipRequest 返回请求体经 gzip 压缩的请求副本，请求体过小、无法重复读取或压缩无收益时返回原请求
+func gzipRequest(req *http.Request, minSize int) (*http.Request, error) {
+	if req.GetBody == nil || req.ContentLength < int64(minSize) || req.Header.Get("Content-Encoding") != "" {
+		return req, nil
+	}
+
+	// 通过副本读取，不消耗原请求体
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err = io.Copy(zw, body)
+	body.Close()
+	if err == nil {
+		err = zw.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if int64(buf.Len()) >= req.ContentLength {
+		return req, nil
+	}
+
+	data := buf.Bytes()
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(data))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	out.ContentLength = int64(len(data))
+	out.Header.Set("Content-Encoding", "gzip")
+	out.Header.Set("Content-Length", strconv.Itoa(len(data)))
+	return out, nil
+}
+
+// gzipBody 代表首次读取时才开始解压的响应体，避免流式响应在收到首个数据前阻塞
+type gzipBody struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+	err  error
+}
+
+// Read 实现 io.Reader
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.zr == nil && b.err == nil {
+		b.zr, b.err = gzip.NewReader(b.body)
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.zr.Read(p)
+}
+
+// Close 实现 io.Closer
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
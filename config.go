@@ -0,0 +1,180 @@
+package gpt_sovits_go_sdk
+
+// 提供从配置文件与环境变量加载客户端配置
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Duration 代表可从JSON解析的时间间隔，支持 "60s" 形式的字符串或以秒为单位的数字
+type Duration time.Duration
+
+// UnmarshalJSON 实现 json.Unmarshaler
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		v, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("无效的时间间隔 %q: %w", s, err)
+		}
+		*d = Duration(v)
+		return nil
+	}
+
+	var secs float64
+	if err := json.Unmarshal(data, &secs); err != nil {
+		return fmt.Errorf("无效的时间间隔 %s", string(data))
+	}
+	*d = Duration(secs * float64(time.Second))
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// ClientConfig 代表客户端配置
+type ClientConfig struct {
+	BaseURL               string     `json:"base_url"`                // API基础URL
+	Timeout               Duration   `json:"timeout"`                 // 总超时时间
+	ConnectTimeout        Duration   `json:"connect_timeout"`         // 连接超时时间
+	ResponseHeaderTimeout Duration   `json:"response_header_timeout"` // 等待响应头的超时时间
+	APIKey                string     `json:"api_key"`                 // 以 Bearer 方式发送的认证令牌
+	Voice                 *Voice     `json:"voice,omitempty"`         // 默认音色
+	Defaults              TTSRequest `json:"defaults"`                // 默认 TTS 参数
+}
+
+// LoadConfig 从 JSON 配置文件加载客户端配置
+func LoadConfig(path string) (*ClientConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+	}
+
+	cfg := &ClientConfig{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// ConfigFromEnv 从环境变量加载客户端配置
+func ConfigFromEnv() (*ClientConfig, error) {
+	cfg := &ClientConfig{}
+	if err := cfg.ApplyEnv(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// ApplyEnv 使用非空的环境变量覆盖配置，支持的环境变量:
+//
+//	GPTSOVITS_URL、GPTSOVITS_TIMEOUT、GPTSOVITS_CONNECT_TIMEOUT、GPTSOVITS_RESPONSE_HEADER_TIMEOUT、
+//	GPTSOVITS_API_KEY、GPTSOVITS_REF_AUDIO、GPTSOVITS_PROMPT_TEXT、GPTSOVITS_PROMPT_LANG、
+//	GPTSOVITS_TEXT_LANG、GPTSOVITS_MEDIA_TYPE
+func (cfg *ClientConfig) ApplyEnv() error {
+	setStringFromEnv(&cfg.BaseURL, "GPTSOVITS_URL")
+	setStringFromEnv(&cfg.APIKey, "GPTSOVITS_API_KEY")
+
+	for key, dst := range map[string]*Duration{
+		"GPTSOVITS_TIMEOUT":                 &cfg.Timeout,
+		"GPTSOVITS_CONNECT_TIMEOUT":         &cfg.ConnectTimeout,
+		"GPTSOVITS_RESPONSE_HEADER_TIMEOUT": &cfg.ResponseHeaderTimeout,
+	} {
+		v := os.Getenv(key)
+		if v == "" {
+			continue
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			// 兼容以秒为单位的数字
+			secs, numErr := strconv.ParseFloat(v, 64)
+			if numErr != nil {
+				return fmt.Errorf("环境变量 %s 不是有效的时间间隔: %w", key, err)
+			}
+			d = time.Duration(secs * float64(time.Second))
+		}
+		*dst = Duration(d)
+	}
+
+	// 默认音色
+	voice := Voice{}
+	if cfg.Voice != nil {
+		voice = *cfg.Voice
+	}
+	setStringFromEnv(&voice.RefAudioPath, "GPTSOVITS_REF_AUDIO")
+	setStringFromEnv(&voice.PromptText, "GPTSOVITS_PROMPT_TEXT")
+	setStringFromEnv(&voice.PromptLang, "GPTSOVITS_PROMPT_LANG")
+	if voice.RefAudioPath != "" || voice.PromptText != "" || voice.PromptLang != "" {
+		cfg.Voice = &voice
+	}
+
+	// 默认 TTS 参数
+	setStringFromEnv(&cfg.Defaults.TextLang, "GPTSOVITS_TEXT_LANG")
+	if v := os.Getenv("GPTSOVITS_MEDIA_TYPE"); v != "" {
+		cfg.Defaults.MediaType = MediaType(v)
+	}
+
+	return nil
+}
+
+// NewClient 根据配置创建客户端，opts 在配置之后应用
+func (cfg *ClientConfig) NewClient(opts ...ClientOption) *Client {
+	var all []ClientOption
+	if cfg.Timeout > 0 {
+		all = append(all, WithTimeout(time.Duration(cfg.Timeout)))
+	}
+	if cfg.ConnectTimeout > 0 {
+		all = append(all, WithConnectTimeout(time.Duration(cfg.ConnectTimeout)))
+	}
+	if cfg.ResponseHeaderTimeout > 0 {
+		all = append(all, WithResponseHeaderTimeout(time.Duration(cfg.ResponseHeaderTimeout)))
+	}
+	if cfg.APIKey != "" {
+		all = append(all, WithBearerToken(cfg.APIKey))
+	}
+	// 默认参数与默认音色合并到每个请求中
+	all = append(all, WithDefaultParams(cfg.Request("")))
+	all = append(all, opts...)
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = "http://127.0.0.1:9880"
+	}
+	return NewClient(baseURL, all...)
+}
+
+// Request 以默认参数与默认音色为基础构建合成给定文本的请求
+func (cfg *ClientConfig) Request(text string) TTSRequest {
+	req := cfg.Defaults
+	if cfg.Voice != nil {
+		cfg.Voice.Apply(&req)
+	}
+	req.Text = text
+	return req
+}
+
+// WithBearerToken 为每个请求添加 "Authorization: Bearer <token>" 请求头
+func WithBearerToken(token string) ClientOption {
+	return func(c *Client) {
+		c.Use(func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				req.Header.Set("Authorization", "Bearer "+token)
+				return next(req)
+			}
+		})
+	}
+}
+
+// setStringFromEnv 在环境变量非空时覆盖目标值
+func setStringFromEnv(dst *string, key string) {
+	if v := os.Getenv(key); v != "" {
+		*dst = v
+	}
+}
@@ -0,0 +1,209 @@
+package gpt_sovits_go_sdk
+
+// 提供音色的授权与同意信息，以及合成前的授权检查，未获授权的音色或不允许的用途在客户端被拒绝
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrVoiceNotAuthorized 表示音色未获授权用于本次合成
+var ErrVoiceNotAuthorized = errors.New("音色未获授权")
+
+// ConsentStatus 代表音色所有者的同意状态
+type ConsentStatus string
+
+// 音色所有者的同意状态
+const (
+	ConsentPending  ConsentStatus = "pending"  // 等待所有者确认
+	ConsentApproved ConsentStatus = "approved" // 所有者已同意
+	ConsentRevoked  ConsentStatus = "revoked"  // 所有者已撤回同意
+)
+
+// VoiceLicense 代表音色的授权信息
+type VoiceLicense struct {
+	Owner         string        `json:"owner"`                    // 声音所有者
+	Consent       ConsentStatus `json:"consent"`                  // 同意状态
+	ConsentRef    string        `json:"consent_ref,omitempty"`    // 同意记录的编号，如授权书或工单编号
+	AllowedUsages []string      `json:"allowed_usages,omitempty"` // 允许的用途标签，如 "audiobook"、"ads"，为空时不限用途
+	ExpiresAt     *time.Time    `json:"expires_at,omitempty"`     // 授权到期时间，为空时不过期
+}
+
+// VoiceAuthError 代表被拒绝的合成，记录检查时的音色、用途与原因，可供审计
+// 可用 errors.Is(err, ErrVoiceNotAuthorized) 判断
+type VoiceAuthError struct {
+	Voice        string        // 音色名称，参考音频未关联已登记的音色时为空
+	RefAudioPath string        // 请求的参考音频路径
+	Owner        string        // 声音所有者
+	Consent      ConsentStatus // 检查时的同意状态
+	ConsentRef   string        // 同意记录的编号
+	Usage        string        // 本次合成声明的用途
+	Reason       string        // 拒绝的原因
+	Time         time.Time     // 检查时间
+}
+
+// Error 实现 error 接口
+func (e *VoiceAuthError) Error() string {
+	var b strings.Builder
+	b.WriteString("音色未获授权")
+	if e.Voice != "" {
+		fmt.Fprintf(&b, "（音色 %s", e.Voice)
+	} else {
+		fmt.Fprintf(&b, "（参考音频 %s", e.RefAudioPath)
+	}
+	if e.Usage != "" {
+		fmt.Fprintf(&b, "，用途 %s", e.Usage)
+	}
+	b.WriteString("）")
+	if e.Reason != "" {
+		b.WriteString(": " + e.Reason)
+	}
+	return b.String()
+}
+
+// Is 使 errors.Is(err, ErrVoiceNotAuthorized) 成立
+func (e *VoiceAuthError) Is(target error) bool {
+	return target == ErrVoiceNotAuthorized
+}
+
+// VoicePolicy 代表合成前的音色授权检查，实现必须是并发安全的
+type VoicePolicy interface {
+	// Authorize 检查音色能否用于 usage 用途的合成，拒绝时返回 *VoiceAuthError
+	// voice 为 nil 表示请求的参考音频未关联已登记的音色，req 为合并默认参数后的请求
+	Authorize(ctx context.Context, voice *Voice, usage string, req *TTSRequest) error
+}
+
+// VoicePolicyFunc 允许将普通函数用作 VoicePolicy
+type VoicePolicyFunc func(ctx context.Context, voice *Voice, usage string, req *TTSRequest) error
+
+// Authorize 实现 VoicePolicy 接口
+func (f VoicePolicyFunc) Authorize(ctx context.Context, voice *Voice, usage string, req *TTSRequest) error {
+	return f(ctx, voice, usage, req)
+}
+
+// WithVoicePolicy 设置合成前的音色授权检查，TTS、TTSGet 与流式接口在读取缓存或发送请求前执行
+// 音色取自 Session 或 Speak 使用的音色，直接调用 TTS 时按参考音频路径在 WithVoices 的注册表中查找；
+// 预热调用不受检查
+func WithVoicePolicy(p VoicePolicy) ClientOption {
+	return func(c *Client) {
+		c.voicePolicy = p
+	}
+}
+
+// WithUsageTag 声明本次合成的用途，供音色授权检查比对 VoiceLicense.AllowedUsages
+func WithUsageTag(tag string) CallOption {
+	return func(o *callOptions) {
+		o.usageTag = tag
+	}
+}
+
+// withoutAuthorization 使本次调用跳过音色授权检查，仅供预热调用使用
+func withoutAuthorization() CallOption {
+	return func(o *callOptions) {
+		o.skipAuthorization = true
+	}
+}
+
+// ConsentPolicy 代表按 VoiceLicense 执行的授权检查：所有者须已同意、授权未过期且用途在允许范围内
+type ConsentPolicy struct {
+	AllowUnregistered bool // 允许使用未关联已登记音色的参考音频
+	AllowUnlicensed   bool // 允许使用未设置授权信息的音色
+	RequireUsageTag   bool // 要求每次合成都通过 WithUsageTag 声明用途
+
+	// Now 返回当前时间，为空时使用 time.Now
+	Now func() time.Time
+}
+
+// Authorize 实现 VoicePolicy 接口
+func (p *ConsentPolicy) Authorize(_ context.Context, voice *Voice, usage string, req *TTSRequest) error {
+	now := time.Now()
+	if p.Now != nil {
+		now = p.Now()
+	}
+	e := &VoiceAuthError{RefAudioPath: req.RefAudioPath, Usage: usage, Time: now}
+
+	if voice == nil {
+		if p.AllowUnregistered {
+			return nil
+		}
+		e.Reason = "参考音频未关联已登记的音色"
+		return e
+	}
+	e.Voice = voice.Name
+
+	l := voice.License
+	if l == nil {
+		if p.AllowUnlicensed {
+			return nil
+		}
+		e.Reason = "音色缺少授权信息"
+		return e
+	}
+	e.Owner, e.Consent, e.ConsentRef = l.Owner, l.Consent, l.ConsentRef
+
+	switch {
+	case l.Consent != ConsentApproved:
+		e.Reason = fmt.Sprintf("所有者的同意状态为 %q", consentLabel(l.Consent))
+	case l.ExpiresAt != nil && !now.Before(*l.ExpiresAt):
+		e.Reason = fmt.Sprintf("授权已于 %s 到期", l.ExpiresAt.Format(time.RFC3339))
+	case usage == "" && p.RequireUsageTag:
+		e.Reason = "未声明用途"
+	case usage != "" && len(l.AllowedUsages) > 0 && !slices.Contains(l.AllowedUsages, usage):
+		e.Reason = fmt.Sprintf("用途不在允许范围内（允许 %s）", strings.Join(l.AllowedUsages, "、"))
+	case usage == "" && len(l.AllowedUsages) > 0:
+		e.Reason = "音色限定了用途，须通过 WithUsageTag 声明"
+	default:
+		return nil
+	}
+	return e
+}
+
+// consentLabel 返回同意状态的显示文本，未设置时为 unknown
+func consentLabel(s ConsentStatus) ConsentStatus {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// authorizeVoice 在设置了音色授权检查时检查本次合成
+func (c *Client) authorizeVoice(ctx context.Context, req TTSRequest, o *callOptions) error {
+	if c.voicePolicy == nil || o.skipAuthorization {
+		return nil
+	}
+
+	voice := o.voice
+	if voice == nil && c.voices != nil {
+		voice, _ = c.voices.ByRefAudio(req.RefAudioPath)
+	}
+
+	err := c.voicePolicy.Authorize(ctx, voice, o.usageTag, &req)
+	if err == nil || errors.Is(err, ErrVoiceNotAuthorized) {
+		return err
+	}
+	return fmt.Errorf("音色授权检查失败: %w", err)
+}
+
+// ByRefAudio 按参考音频路径查找音色，包括参与轮换的额外参考音频
+func (r *VoiceRegistry) ByRefAudio(path string) (*Voice, bool) {
+	if path == "" {
+		return nil, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, v := range r.voices {
+		for _, ref := range v.refAudios() {
+			if ref.Path == path {
+				copied := *v
+				return &copied, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,170 @@
+package gpt_sovits_go_sdk
+
+// 提供合成前的内容过滤，按部署策略拦截脏话、个人信息或其他不允许合成的文本
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrContentBlocked 表示文本被内容过滤器拒绝
+var ErrContentBlocked = errors.New("内容被拒绝")
+
+// ContentBlockedError 代表被内容过滤器拒绝的文本，可用 errors.Is(err, ErrContentBlocked) 判断
+type ContentBlockedError struct {
+	Category string // 拒绝的类别，如 "profanity" 或 "pii"
+	Reason   string // 拒绝的原因，可展示给终端用户
+}
+
+// Error 实现 error 接口
+func (e *ContentBlockedError) Error() string {
+	msg := "内容被拒绝"
+	if e.Category != "" {
+		msg += "（" + e.Category + "）"
+	}
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+// Is 使 errors.Is(err, ErrContentBlocked) 成立
+func (e *ContentBlockedError) Is(target error) bool {
+	return target == ErrContentBlocked
+}
+
+// ContentFilter 代表合成前的内容过滤器，实现必须是并发安全的
+type ContentFilter interface {
+	// Check 检查调用方传入的文本，拒绝时返回 *ContentBlockedError；
+	// 检查本身失败（如审核服务不可用）时返回其他错误，请求同样不会发送
+	Check(ctx context.Context, text, lang string) error
+}
+
+// ContentFilterFunc 允许将普通函数用作 ContentFilter
+type ContentFilterFunc func(ctx context.Context, text, lang string) error
+
+// Check 实现 ContentFilter 接口
+func (f ContentFilterFunc) Check(ctx context.Context, text, lang string) error {
+	return f(ctx, text, lang)
+}
+
+// WithContentFilter 注册合成前的内容过滤器，多个过滤器按注册顺序执行，任一拒绝即不发送请求
+// 过滤在文本长度限制之后、文本预处理之前进行，检查的是将被合成的原始文本；自动分块时逐块检查
+func WithContentFilter(filters ...ContentFilter) ClientOption {
+	return func(c *Client) {
+		c.contentFilters = append(c.contentFilters, filters...)
+	}
+}
+
+// filterContent 依次执行内容过滤器
+func (c *Client) filterContent(ctx context.Context, text, lang string) error {
+	if contentChecked(ctx) {
+		return nil
+	}
+	for _, f := range c.contentFilters {
+		err := f.Check(ctx, text, lang)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, ErrContentBlocked) {
+			return err
+		}
+		return fmt.Errorf("内容过滤失败: %w", err)
+	}
+	return nil
+}
+
+// contentCheckedKey 为标记文本已通过内容过滤的上下文键
+type contentCheckedKey struct{}
+
+// withContentChecked 标记上下文中的请求已通过内容过滤
+func withContentChecked(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contentCheckedKey{}, true)
+}
+
+// contentChecked 判断上下文中的请求是否已通过内容过滤
+func contentChecked(ctx context.Context) bool {
+	v, _ := ctx.Value(contentCheckedKey{}).(bool)
+	return v
+}
+
+// KeywordFilter 代表按关键词拒绝文本的过滤器，不区分大小写
+type KeywordFilter struct {
+	Words    []string // 关键词
+	Category string   // 拒绝的类别，为空时为 "keyword"
+	Reason   string   // 拒绝的原因，为空时为 "包含不允许的词语"
+}
+
+// Check 实现 ContentFilter 接口
+func (f *KeywordFilter) Check(_ context.Context, text, _ string) error {
+	lower := strings.ToLower(text)
+	for _, w := range f.Words {
+		if w != "" && strings.Contains(lower, strings.ToLower(w)) {
+			return &ContentBlockedError{
+				Category: cmp.Or(f.Category, "keyword"),
+				Reason:   cmp.Or(f.Reason, "包含不允许的词语"),
+			}
+		}
+	}
+	return nil
+}
+
+// PatternFilter 代表按正则表达式拒绝文本的过滤器
+type PatternFilter struct {
+	Patterns []*regexp.Regexp // 任一匹配即拒绝
+	Category string           // 拒绝的类别，为空时为 "pattern"
+	Reason   string           // 拒绝的原因，为空时为 "包含不允许的内容"
+}
+
+// Check 实现 ContentFilter 接口
+func (f *PatternFilter) Check(_ context.Context, text, _ string) error {
+	for _, p := range f.Patterns {
+		if p.MatchString(text) {
+			return &ContentBlockedError{
+				Category: cmp.Or(f.Category, "pattern"),
+				Reason:   cmp.Or(f.Reason, "包含不允许的内容"),
+			}
+		}
+	}
+	return nil
+}
+
+// piiPatterns 为常见个人信息的正则表达式，第一个分组为个人信息本身
+var piiPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?:^|\D)(1[3-9]\d{9})(?:\D|$)`),                                    // 中国大陆手机号
+	regexp.MustCompile(`(?:^|\D)(\d{17}[\dXx])(?:\D|$)`),                                   // 居民身份证号
+	regexp.MustCompile(`([A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,})`),                 // 电子邮箱
+	regexp.MustCompile(`(?:^|\D)((?:\d{4}[ -]?){3}\d{4})(?:\D|$)`),                         // 银行卡号
+	regexp.MustCompile(`(?:^|\D)(\+?\d{1,3}[ -]?\(?\d{3}\)?[ -]?\d{3}[ -]?\d{4})(?:\D|$)`), // 国际电话号码
+}
+
+// maskPII 以 mask 替换文本中的个人信息
+// 正则中的边界字符会被匹配消耗，每次从个人信息之后继续查找，使以分隔符相连的多项（如 "13800138000,13900139000"）都被替换
+func maskPII(text, mask string) string {
+	for _, p := range piiPatterns {
+		var b strings.Builder
+		last := 0
+		for last < len(text) {
+			m := p.FindStringSubmatchIndex(text[last:])
+			if m == nil {
+				break
+			}
+			b.WriteString(text[last : last+m[2]])
+			b.WriteString(mask)
+			last += m[3]
+		}
+		b.WriteString(text[last:])
+		text = b.String()
+	}
+	return text
+}
+
+// NewPIIFilter 创建拒绝常见个人信息（手机号、身份证号、电子邮箱、银行卡号、电话号码）的过滤器
+// 规则基于格式匹配，可能存在误判与漏判，需要更高准确率时应接入专门的审核服务
+func NewPIIFilter() *PatternFilter {
+	return &PatternFilter{Patterns: piiPatterns, Category: "pii", Reason: "包含个人信息"}
+}
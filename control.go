@@ -0,0 +1,88 @@
+package gpt_sovits_go_sdk
+
+// 提供控制命令常量与重启等待辅助函数
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ControlCommand 代表服务器控制命令
+type ControlCommand string
+
+const (
+	// CommandRestart 重启服务器
+	CommandRestart ControlCommand = "restart"
+	// CommandExit 关闭服务器
+	CommandExit ControlCommand = "exit"
+)
+
+// Validate 校验控制命令是否受服务器支持
+func (cmd ControlCommand) Validate() error {
+	switch cmd {
+	case CommandRestart, CommandExit:
+		return nil
+	default:
+		return fmt.Errorf("未知的控制命令 %q，支持 restart 或 exit", string(cmd))
+	}
+}
+
+// restartPollInterval 为等待重启时的探测间隔
+const restartPollInterval = 500 * time.Millisecond
+
+// restartDownGrace 为等待服务器下线的最长时间，超过后直接开始等待上线
+const restartDownGrace = 3 * time.Second
+
+// RestartAndWait 发送重启命令，并等待服务器重新可以应答，timeout 为等待的总时长
+func (c *Client) RestartAndWait(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// 服务器重启时可能来不及返回响应，连接层面的错误视为重启已开始
+	if err := c.Control(ctx, CommandRestart); err != nil {
+		var urlErr *url.Error
+		if !errors.As(err, &urlErr) || ctx.Err() != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(restartPollInterval)
+	defer ticker.Stop()
+
+	// 先等待服务器下线，避免在重启前误判为已就绪
+	downDeadline := time.Now().Add(restartDownGrace)
+	down := false
+	for !down && time.Now().Before(downDeadline) {
+		if c.ping(ctx, restartPollInterval) != nil {
+			down = true
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("等待服务器重启超时: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+
+	// 再等待服务器重新上线
+	for {
+		if c.ping(ctx, restartPollInterval*2) == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("等待服务器重启超时: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// ping 以较短的超时探测服务器
+func (c *Client) ping(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return c.Ping(ctx)
+}
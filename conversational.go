@@ -0,0 +1,178 @@
+package gpt_sovits_go_sdk
+
+// 提供面向对话机器人的低延迟合成：首句立即以流式合成，其余部分在后台合成
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// defaultFirstSentenceChars 为首句的默认最大字符数
+const defaultFirstSentenceChars = 40
+
+// ConversationalOptions 代表对话式合成的选项
+type ConversationalOptions struct {
+	Style    string        // 音色风格，为空时仅应用音色
+	Splitter *TextSplitter // 首句之后文本的切分器，为空时使用默认切分器
+
+	// FirstSentenceChars 为首句的最大字符数，超过时在句中标点处截断，<=0 时为 40
+	FirstSentenceChars int
+
+	// LowLatency 调整首句请求的参数，为空时使用 BatchSize 1、不再切分并启用流式响应
+	LowLatency func(req *TTSRequest)
+}
+
+// ConversationalStats 代表对话式合成的统计信息
+type ConversationalStats struct {
+	FirstAudio time.Duration // 从调用开始到收到首个音频片段的耗时
+	Bytes      int64         // 交给回调的总字节数
+	Chunks     int           // 交给回调的片段数
+	Sentences  int           // 合成的块数，包括首句
+}
+
+// SpeakConversational 以对话机器人的低延迟模式合成回复，按顺序将 16 位 PCM 音频片段交给 fn
+//
+// 首句以低延迟参数流式合成并边收边交给 fn，同时在后台依次合成其余部分，首句播放期间即可完成后续合成；
+// 所有片段均为 raw 格式，依次拼接即为完整的 PCM 音频，但单个片段不保证按采样帧对齐，
+// 采样率与客户端的 RawSampleRate 一致；fn 返回错误时取消后台合成并返回该错误，
+// 会话被 Interrupt 打断时停止交付并返回 ErrInterrupted
+func (s *Session) SpeakConversational(ctx context.Context, text string, fn func(chunk []byte, seq int) error, opts *ConversationalOptions, callOpts ...CallOption) (*ConversationalStats, error) {
+	if opts == nil {
+		opts = &ConversationalOptions{}
+	}
+	maxFirst := opts.FirstSentenceChars
+	if maxFirst <= 0 {
+		maxFirst = defaultFirstSentenceChars
+	}
+
+	first, rest := firstSentence(text, maxFirst)
+	if first == "" {
+		return nil, fmt.Errorf("待合成文本为空")
+	}
+
+	ctx, done := s.bind(ctx)
+	defer done()
+	start := time.Now()
+	stats := &ConversationalStats{}
+
+	// 在后台依次合成其余部分，结果按顺序交付
+	type result struct {
+		audio []byte
+		err   error
+	}
+	pieces := opts.Splitter.Split(rest)
+	results := make(chan result, len(pieces))
+	go func() {
+		defer close(results)
+		for _, piece := range pieces {
+			resp, err := s.speakRaw(ctx, opts.Style, piece, callOpts)
+			if err == nil {
+				err = resp.Err()
+			}
+			if err != nil {
+				results <- result{err: err}
+				return
+			}
+			results <- result{audio: resp.AudioData}
+		}
+	}()
+
+	emit := func(chunk []byte) error {
+		// 被打断后不再交付音频
+		if err := ctx.Err(); err != nil {
+			return s.interrupted(ctx, err)
+		}
+		if stats.Chunks == 0 {
+			stats.FirstAudio = time.Since(start)
+		}
+		if err := fn(chunk, stats.Chunks); err != nil {
+			return err
+		}
+		stats.Bytes += int64(len(chunk))
+		stats.Chunks++
+		return nil
+	}
+
+	// 首句以低延迟参数流式合成
+	if err := s.streamFirst(ctx, opts, first, emit, callOpts); err != nil {
+		return stats, s.interrupted(ctx, err)
+	}
+	stats.Sentences++
+
+	for res := range results {
+		if res.err != nil {
+			if err := s.interrupted(ctx, res.err); err == ErrInterrupted {
+				return stats, err
+			}
+			return stats, fmt.Errorf("第 %d 块: %w", stats.Sentences+1, res.err)
+		}
+		if len(res.audio) > 0 {
+			if err := emit(res.audio); err != nil {
+				return stats, err
+			}
+		}
+		stats.Sentences++
+	}
+	return stats, nil
+}
+
+// streamFirst 以低延迟参数流式合成首句
+func (s *Session) streamFirst(ctx context.Context, opts *ConversationalOptions, text string, emit func([]byte) error, callOpts []CallOption) error {
+	req, callOpts, err := s.request(opts.Style, text, callOpts)
+	if err != nil {
+		return err
+	}
+	req.MediaType = MediaTypeRAW
+	req.BatchSize = 1
+	req.TextSplitMethod = Cut0
+	if opts.LowLatency != nil {
+		opts.LowLatency(&req)
+	}
+
+	release, err := s.client.Models().acquire(ctx, s.Model.GPT, s.Model.SoVITS)
+	if err != nil {
+		return fmt.Errorf("切换会话模型失败: %w", err)
+	}
+	defer release()
+
+	_, err = s.client.TTSStreamChunks(ctx, req, func(chunk []byte, _ int) error {
+		return emit(chunk)
+	}, callOpts...)
+	return err
+}
+
+// speakRaw 以 raw 格式合成一块文本
+func (s *Session) speakRaw(ctx context.Context, style, text string, callOpts []CallOption) (*TTSResponse, error) {
+	req, callOpts, err := s.request(style, text, callOpts)
+	if err != nil {
+		return nil, err
+	}
+	req.MediaType = MediaTypeRAW
+	req.StreamingMode = false
+
+	release, err := s.client.Models().acquire(ctx, s.Model.GPT, s.Model.SoVITS)
+	if err != nil {
+		return nil, fmt.Errorf("切换会话模型失败: %w", err)
+	}
+	defer release()
+
+	return s.client.TTS(ctx, req, callOpts...)
+}
+
+// firstSentence 取出文本的首句，首句超过 maxChars 时在句中标点处截断，仍然过长时按字符数截断
+func firstSentence(text string, maxChars int) (first, rest string) {
+	text = strings.TrimSpace(text)
+	sentences := splitAfterAny(text, sentenceEnds)
+	if len(sentences) == 0 {
+		return "", ""
+	}
+
+	first = sentences[0]
+	if utf8.RuneCountInString(first) > maxChars {
+		first = limitLength(first, maxChars)[0]
+	}
+	return strings.TrimSpace(first), strings.TrimSpace(text[len(first):])
+}
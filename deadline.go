@@ -0,0 +1,98 @@
+package gpt_sovits_go_sdk
+
+// 提供基于近期实时率的合成耗时估计，使长文本合成在上下文截止前交付已完成的部分
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrDeadline 表示剩余时间不足以完成合成
+var ErrDeadline = errors.New("剩余时间不足以完成合成")
+
+// 截止时间自适应的默认配置
+const (
+	defaultMinChunkChars = 10  // 默认的最小块字符数
+	rtfStatsWeight       = 0.3 // 近期统计的指数加权系数，越大越偏向最近的请求
+)
+
+// DeadlineError 代表长文本合成因截止时间而提前结束，可用 errors.As 取得
+type DeadlineError struct {
+	Done      int           // 已完成的块数
+	Remaining int           // 未合成的字符数
+	Left      time.Duration // 提前结束时距截止时间的剩余时间
+	Estimated time.Duration // 下一块按最小字符数估计的合成耗时，无法估计时为0
+}
+
+// Error 实现 error 接口
+func (e *DeadlineError) Error() string {
+	msg := fmt.Sprintf("剩余时间不足以完成合成，已完成 %d 块，剩余 %d 字，距截止时间 %s", e.Done, e.Remaining, e.Left.Round(time.Millisecond))
+	if e.Estimated > 0 {
+		msg += fmt.Sprintf("，下一块预计需要 %s", e.Estimated.Round(time.Millisecond))
+	}
+	return msg
+}
+
+// Is 使 errors.Is(err, ErrDeadline) 成立
+func (e *DeadlineError) Is(target error) bool {
+	return target == ErrDeadline
+}
+
+// rtfStats 代表近期成功合成的实时率与每字音频时长的指数加权平均，零值可直接使用
+type rtfStats struct {
+	mu         sync.Mutex
+	rtf        float64 // 实时率
+	secPerChar float64 // 每字符的音频秒数
+	n          int     // 样本数
+}
+
+// observe 记录一次成功合成
+func (s *rtfStats) observe(text string, ttsResp *TTSResponse) {
+	chars := utf8.RuneCountInString(text)
+	if chars == 0 || ttsResp.RTF <= 0 || ttsResp.Duration <= 0 || ttsResp.FromCache {
+		return
+	}
+	perChar := ttsResp.Duration.Seconds() / float64(chars)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.n == 0 {
+		s.rtf, s.secPerChar = ttsResp.RTF, perChar
+	} else {
+		s.rtf += rtfStatsWeight * (ttsResp.RTF - s.rtf)
+		s.secPerChar += rtfStatsWeight * (perChar - s.secPerChar)
+	}
+	s.n++
+}
+
+// perChar 返回每字符的预计合成耗时，没有统计时 ok 为 false
+func (s *rtfStats) perChar() (d time.Duration, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.n == 0 {
+		return 0, false
+	}
+	return time.Duration(s.rtf * s.secPerChar * float64(time.Second)), true
+}
+
+// RecentRTF 返回客户端近期成功合成的平均实时率，尚无统计时 ok 为 false
+func (c *Client) RecentRTF() (rtf float64, ok bool) {
+	c.rtf.mu.Lock()
+	defer c.rtf.mu.Unlock()
+
+	return c.rtf.rtf, c.rtf.n > 0
+}
+
+// EstimateSynthesis 按近期统计估计合成 text 的耗时，尚无统计时 ok 为 false
+func (c *Client) EstimateSynthesis(text string) (d time.Duration, ok bool) {
+	perChar, ok := c.rtf.perChar()
+	if !ok {
+		return 0, false
+	}
+	return perChar * time.Duration(utf8.RuneCountInString(text)), true
+}
@@ -0,0 +1,107 @@
+package gpt_sovits_go_sdk
+
+// 提供客户端级别的默认 TTS 参数
+
+// WithDefaultParams 设置合并到每个请求中的默认 TTS 参数
+func WithDefaultParams(defaults TTSRequest) ClientOption {
+	return func(c *Client) {
+		c.SetDefaults(defaults)
+	}
+}
+
+// SetDefaults 设置合并到每个请求中的默认 TTS 参数，请求中已设置的字段优先
+// 普通布尔字段（StreamingMode、SuperSampling）无法区分未设置与 false，默认值只能将其开启
+func (c *Client) SetDefaults(defaults TTSRequest) {
+	c.defaultsMu.Lock()
+	defer c.defaultsMu.Unlock()
+
+	c.defaults = &defaults
+}
+
+// Defaults 返回客户端当前的默认 TTS 参数
+func (c *Client) Defaults() TTSRequest {
+	c.defaultsMu.RLock()
+	defer c.defaultsMu.RUnlock()
+
+	if c.defaults == nil {
+		return TTSRequest{}
+	}
+	return *c.defaults
+}
+
+// applyDefaults 将客户端默认参数合并到请求中
+func (c *Client) applyDefaults(req TTSRequest) TTSRequest {
+	c.defaultsMu.RLock()
+	defer c.defaultsMu.RUnlock()
+
+	if c.defaults == nil {
+		return req
+	}
+	return mergeRequest(req, *c.defaults)
+}
+
+// mergeRequest 用 defaults 填充 req 中的零值字段，Text 不参与合并
+func mergeRequest(req, defaults TTSRequest) TTSRequest {
+	if req.TextLang == "" {
+		req.TextLang = defaults.TextLang
+	}
+	// 请求未指定任何参考音频时才使用默认参考音频
+	if req.RefAudioPath == "" && len(req.RefAudioData) == 0 {
+		req.RefAudioPath = defaults.RefAudioPath
+		req.RefAudioData = defaults.RefAudioData
+	}
+	if len(req.AuxRefAudioPaths) == 0 {
+		req.AuxRefAudioPaths = defaults.AuxRefAudioPaths
+	}
+	if req.PromptText == "" {
+		req.PromptText = defaults.PromptText
+	}
+	if req.PromptLang == "" {
+		req.PromptLang = defaults.PromptLang
+	}
+	if req.TopK == 0 {
+		req.TopK = defaults.TopK
+	}
+	if req.TopP == 0 {
+		req.TopP = defaults.TopP
+	}
+	if req.Temperature == 0 {
+		req.Temperature = defaults.Temperature
+	}
+	if req.TextSplitMethod == "" {
+		req.TextSplitMethod = defaults.TextSplitMethod
+	}
+	if req.BatchSize == 0 {
+		req.BatchSize = defaults.BatchSize
+	}
+	if req.BatchThreshold == 0 {
+		req.BatchThreshold = defaults.BatchThreshold
+	}
+	if req.SplitBucket == nil {
+		req.SplitBucket = defaults.SplitBucket
+	}
+	if req.SpeedFactor == 0 {
+		req.SpeedFactor = defaults.SpeedFactor
+	}
+	if req.FragmentInterval == 0 {
+		req.FragmentInterval = defaults.FragmentInterval
+	}
+	if req.Seed == 0 {
+		req.Seed = defaults.Seed
+	}
+	if req.ParallelInfer == nil {
+		req.ParallelInfer = defaults.ParallelInfer
+	}
+	if req.RepetitionPenalty == 0 {
+		req.RepetitionPenalty = defaults.RepetitionPenalty
+	}
+	if req.SampleSteps == 0 {
+		req.SampleSteps = defaults.SampleSteps
+	}
+	if req.MediaType == "" {
+		req.MediaType = defaults.MediaType
+	}
+	req.SuperSampling = req.SuperSampling || defaults.SuperSampling
+	req.StreamingMode = req.StreamingMode || defaults.StreamingMode
+	return req
+}
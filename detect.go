@@ -0,0 +1,30 @@
+package gpt_sovits_go_sdk
+
+// 提供合成前的文本语言自动检测辅助函数
+
+import (
+	"github.com/ssdomei232/gpt_sovits_go_sdk/lang"
+)
+
+// DetectTextLang 根据文本内容自动设置 TextLang，cantonese 为 true 时中文按粤语处理
+func (r *TTSRequest) DetectTextLang(cantonese bool) {
+	if cantonese {
+		r.TextLang = lang.DetectCantonese(r.Text)
+		return
+	}
+	r.TextLang = lang.Detect(r.Text)
+}
+
+// SplitByLang 将混合语言文本拆分为多个请求，每个请求使用对应片段的语言
+func SplitByLang(req TTSRequest) []TTSRequest {
+	segments := lang.Split(req.Text)
+
+	reqs := make([]TTSRequest, 0, len(segments))
+	for _, seg := range segments {
+		r := req
+		r.Text = seg.Text
+		r.TextLang = seg.Lang
+		reqs = append(reqs, r)
+	}
+	return reqs
+}
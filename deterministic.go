@@ -0,0 +1,50 @@
+package gpt_sovits_go_sdk
+
+// 提供可复现合成的参数设置，便于回归测试跨次比较音频摘要
+
+// MakeDeterministic 设置随机种子、关闭并行推理，并将未设置的采样与切分参数固定为 api_v2 的默认值
+// 相同的模型权重、参考音频与文本在同一设备上可得到相同的输出；seed 须为正数，否则将被随机种子取代
+func (r *TTSRequest) MakeDeterministic(seed int) {
+	r.Seed = seed
+	// 并行推理的分桶会改变批次组成，影响结果
+	r.ParallelInfer = Bool(false)
+	r.SplitBucket = Bool(false)
+
+	if r.TopK == 0 {
+		r.TopK = 5
+	}
+	if r.TopP == 0 {
+		r.TopP = 1
+	}
+	if r.Temperature == 0 {
+		r.Temperature = 1
+	}
+	if r.RepetitionPenalty == 0 {
+		r.RepetitionPenalty = 1.35
+	}
+	if r.TextSplitMethod == "" {
+		r.TextSplitMethod = Cut5
+	}
+	if r.BatchSize == 0 {
+		r.BatchSize = 1
+	}
+	if r.SpeedFactor == 0 {
+		r.SpeedFactor = 1
+	}
+	if r.FragmentInterval == 0 {
+		r.FragmentInterval = 0.3
+	}
+	if r.SampleSteps == 0 {
+		r.SampleSteps = 32
+	}
+}
+
+// WithDeterministic 使客户端的每个请求都以可复现模式合成，请求中显式设置的参数优先
+// 该选项修改默认参数，应位于 WithDefaultParams 之后
+func WithDeterministic(seed int) ClientOption {
+	return func(c *Client) {
+		defaults := c.Defaults()
+		defaults.MakeDeterministic(seed)
+		c.SetDefaults(defaults)
+	}
+}
@@ -0,0 +1,129 @@
+package gpt_sovits_go_sdk
+
+// 提供多说话人对话的合成编排
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/audio"
+)
+
+// DialogueLine 代表对话中的一行台词
+type DialogueLine struct {
+	Speaker  string // 说话人，对应注册表中的音色名称
+	Text     string // 台词文本
+	TextLang string // 台词语言，为空时使用 Dialogue.TextLang
+}
+
+// Dialogue 代表一段多说话人对话
+type Dialogue struct {
+	Voices   *VoiceRegistry     // 说话人音色注册表
+	Lines    []DialogueLine     // 台词
+	Gap      time.Duration      // 相邻台词之间的静音时长
+	TextLang string             // 默认台词语言
+	Base     TTSRequest         // 每行台词请求的基础参数
+	Trim     *audio.TrimOptions // 非 nil 时在拼接前裁剪每行首尾的静音
+
+	// Crossfade 为相邻台词的交叉淡化时长，为0时直接拼接
+	Crossfade time.Duration
+}
+
+// LineTiming 代表一行台词在拼接音频中的时间位置
+type LineTiming struct {
+	Index        int           // 台词序号
+	Speaker      string        // 说话人
+	Text         string        // 台词文本
+	Start        time.Duration // 起始时间
+	End          time.Duration // 结束时间
+	RefAudioPath string        // 该行使用的参考音频
+}
+
+// DialogueResult 代表对话合成结果
+type DialogueResult struct {
+	Audio []byte       // 拼接后的 WAV 音频
+	Lines []LineTiming // 每行台词的时间位置
+}
+
+// SynthesizeDialogue 逐行合成对话，按需切换模型权重与参考音频，并拼接为一条音轨
+func (c *Client) SynthesizeDialogue(ctx context.Context, d *Dialogue) (*DialogueResult, error) {
+	if d.Voices == nil {
+		return nil, fmt.Errorf("对话未设置音色注册表")
+	}
+
+	var (
+		segments []*audio.WAV
+		timings  []LineTiming
+		spoken   = make(map[string]int) // 每个说话人已合成的行数，用于参考音频轮换
+	)
+
+	for i, line := range d.Lines {
+		voice, err := d.Voices.Get(line.Speaker)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行: %w", i+1, err)
+		}
+
+		// 构建请求，拼接要求输出为 WAV
+		req := d.Base
+		voice.ApplyRef(&req, spoken[line.Speaker])
+		spoken[line.Speaker]++
+		req.TextLang = line.TextLang
+		if req.TextLang == "" {
+			req.TextLang = d.TextLang
+		}
+		req.Text, err = voice.ProcessText(line.Text, req.TextLang)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行: %w", i+1, err)
+		}
+		req.MediaType = MediaTypeWAV
+		req.StreamingMode = false
+
+		// 经模型管理器按需切换权重，本行合成完成前不允许其他会话切换
+		release, err := c.Models().acquire(ctx, voice.GPTWeights, voice.SoVITSWeights)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行: 切换模型失败: %w", i+1, err)
+		}
+		resp, err := c.TTS(ctx, req)
+		release()
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行: %w", i+1, err)
+		}
+		if err := resp.Err(); err != nil {
+			return nil, fmt.Errorf("第 %d 行: %w", i+1, err)
+		}
+
+		seg, err := audio.ParseWAV(resp.AudioData)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行: %w", i+1, err)
+		}
+		if d.Trim != nil {
+			if seg, err = audio.Trim(seg, *d.Trim); err != nil {
+				return nil, fmt.Errorf("第 %d 行: %w", i+1, err)
+			}
+		}
+
+		timings = append(timings, LineTiming{
+			Index:        i,
+			Speaker:      line.Speaker,
+			Text:         line.Text,
+			RefAudioPath: req.RefAudioPath,
+		})
+		segments = append(segments, seg)
+	}
+
+	stitched, spans, err := audio.ConcatWithSpans(segments, audio.ConcatOptions{Gap: d.Gap, Crossfade: d.Crossfade})
+	if err != nil {
+		return nil, err
+	}
+
+	// 记录每行台词在拼接音频中的时间位置
+	for i, span := range spans {
+		timings[i].Start, timings[i].End = span.Start, span.End
+	}
+
+	return &DialogueResult{
+		Audio: stitched.Bytes(),
+		Lines: timings,
+	}, nil
+}
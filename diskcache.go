@@ -0,0 +1,365 @@
+package gpt_sovits_go_sdk
+
+// 提供持久化的磁盘缓存，音频按内容哈希存放，重复构建同一本有声书时几乎无需重新合成
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCacheCorrupt 表示缓存的音频文件与索引记录的哈希不符
+var ErrCacheCorrupt = errors.New("缓存文件已损坏")
+
+// defaultDiskCacheSize 为磁盘缓存的默认容量
+const defaultDiskCacheSize = 1 << 30
+
+// 磁盘缓存的目录布局
+const (
+	diskCacheObjects = "objects"    // 音频文件目录，按哈希前两位分子目录
+	diskCacheIndex   = "index.json" // 索引文件
+	diskCacheVersion = 1            // 索引格式版本
+)
+
+// DiskCache 代表持久化的磁盘缓存，并发安全
+// 音频以内容的 SHA-256 命名存放在 objects/<前两位>/ 下，相同音频只保存一份；
+// index.json 记录每个缓存键对应的哈希、合成参数与使用时间，总大小超过容量时按最近最少使用淘汰。
+// 读取时校验哈希，文件损坏或缺失的项按未命中处理并从索引中移除。
+// 同一目录只应由一个 DiskCache 使用，退出前调用 Close 保存使用时间
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+
+	mu      sync.Mutex
+	entries map[string]*diskIndexEntry
+	refs    map[string]int // 每个哈希被引用的次数
+	sizes   map[string]int64
+	size    int64 // 去重后的音频总大小
+	dirty   bool  // 内存中的索引是否有未保存的修改
+}
+
+// diskIndexEntry 代表索引中的一项
+type diskIndexEntry struct {
+	CacheEntry
+	Hash     string    `json:"hash"`      // 音频内容的 SHA-256
+	Size     int64     `json:"size"`      // 音频字节数
+	LastUsed time.Time `json:"last_used"` // 最近使用时间
+}
+
+// diskIndex 代表索引文件
+type diskIndex struct {
+	Version int                        `json:"version"`
+	Entries map[string]*diskIndexEntry `json:"entries"`
+}
+
+// NewDiskCache 创建一个新的磁盘缓存，目录不存在时自动创建，maxBytes <=0 时为 1 GiB
+// 索引文件损坏时以空索引启动，残留的音频文件由 GC 清理
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if maxBytes <= 0 {
+		maxBytes = defaultDiskCacheSize
+	}
+	if err := os.MkdirAll(filepath.Join(dir, diskCacheObjects), 0o755); err != nil {
+		return nil, fmt.Errorf("创建缓存目录失败: %w", err)
+	}
+
+	d := &DiskCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		entries:  make(map[string]*diskIndexEntry),
+		refs:     make(map[string]int),
+		sizes:    make(map[string]int64),
+	}
+
+	// 读取索引，不存在或无法解析时从空索引开始
+	data, err := os.ReadFile(filepath.Join(dir, diskCacheIndex))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("读取缓存索引失败: %w", err)
+	}
+	var index diskIndex
+	if err == nil && json.Unmarshal(data, &index) == nil && index.Version == diskCacheVersion {
+		for key, e := range index.Entries {
+			if e != nil && validHash(e.Hash) {
+				d.add(key, e)
+			}
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d, d.evict()
+}
+
+// Get 实现 Cache 接口，音频文件损坏时返回 ErrCacheCorrupt 并移除该项
+func (d *DiskCache) Get(_ context.Context, key string) (*CacheEntry, error) {
+	d.mu.Lock()
+	e, ok := d.entries[key]
+	if ok {
+		e.LastUsed = time.Now()
+		d.dirty = true
+	}
+	d.mu.Unlock()
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+
+	// 在锁外读取并校验音频
+	audio, err := os.ReadFile(d.objectPath(e.Hash))
+	if err == nil {
+		sum := sha256.Sum256(audio)
+		if hex.EncodeToString(sum[:]) != e.Hash {
+			err = ErrCacheCorrupt
+		}
+	}
+	if err != nil {
+		d.discard(e.Hash, errors.Is(err, ErrCacheCorrupt))
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrCacheMiss
+		}
+		return nil, fmt.Errorf("读取缓存 %s: %w", key, err)
+	}
+
+	entry := e.CacheEntry
+	entry.Audio = audio
+	return &entry, nil
+}
+
+// Set 实现 Cache 接口，写入后超过容量时淘汰最久未使用的项，超过容量的单项不写入
+func (d *DiskCache) Set(_ context.Context, key string, entry *CacheEntry) error {
+	size := int64(len(entry.Audio))
+	if size > d.maxBytes {
+		return nil
+	}
+	sum := sha256.Sum256(entry.Audio)
+	hash := hex.EncodeToString(sum[:])
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// 相同内容的音频已存在时只更新索引
+	if d.refs[hash] == 0 {
+		path := d.objectPath(hash)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("创建缓存目录失败: %w", err)
+		}
+		if err := writeFileAtomic(path, entry.Audio); err != nil {
+			return fmt.Errorf("写入缓存 %s: %w", key, err)
+		}
+	}
+
+	meta := *entry
+	meta.Audio = nil
+	if meta.Request != nil {
+		// 参考音频数据体积较大，索引中只记录路径
+		req := *meta.Request
+		req.RefAudioData = nil
+		meta.Request = &req
+	}
+	old, replaced := d.entries[key]
+	orphan := d.remove(key)
+	d.add(key, &diskIndexEntry{CacheEntry: meta, Hash: hash, Size: size, LastUsed: time.Now()})
+
+	// 覆盖为不同的音频时，旧音频不再被引用则立即删除
+	if replaced && orphan && old.Hash != hash {
+		if err := os.Remove(d.objectPath(old.Hash)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("删除缓存文件失败: %w", err)
+		}
+	}
+
+	if err := d.evict(); err != nil {
+		return err
+	}
+	return d.save()
+}
+
+// Len 返回缓存的项数
+func (d *DiskCache) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.entries)
+}
+
+// Size 返回去重后的音频总字节数
+func (d *DiskCache) Size() int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.size
+}
+
+// GC 校验全部音频文件，移除损坏或缺失的项，删除未被索引引用的残留文件，并淘汰超出容量的项
+// 返回删除的文件数
+func (d *DiskCache) GC(ctx context.Context) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	removed := 0
+	root := filepath.Join(d.dir, diskCacheObjects)
+	seen := make(map[string]bool)
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		// 删除残留的临时文件、未被引用的文件与内容不符的文件
+		hash := entry.Name()
+		if d.refs[hash] > 0 && filepath.Base(filepath.Dir(path)) == hash[:2] {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("读取缓存文件失败: %w", err)
+			}
+			sum := sha256.Sum256(data)
+			if hex.EncodeToString(sum[:]) == hash {
+				seen[hash] = true
+				return nil
+			}
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("删除缓存文件失败: %w", err)
+		}
+		removed++
+		return nil
+	})
+	if err != nil {
+		return removed, err
+	}
+
+	// 移除文件已不存在的项
+	for key, e := range d.entries {
+		if !seen[e.Hash] {
+			d.remove(key)
+		}
+	}
+
+	if err := d.evict(); err != nil {
+		return removed, err
+	}
+	return removed, d.save()
+}
+
+// Close 保存索引中的使用时间，之后不应再使用该缓存
+func (d *DiskCache) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.dirty {
+		return nil
+	}
+	return d.save()
+}
+
+// objectPath 返回哈希对应的音频文件路径
+func (d *DiskCache) objectPath(hash string) string {
+	return filepath.Join(d.dir, diskCacheObjects, hash[:2], hash)
+}
+
+// add 将一项加入内存索引，调用方须持有锁或尚未发布 d
+func (d *DiskCache) add(key string, e *diskIndexEntry) {
+	d.entries[key] = e
+	if d.refs[e.Hash] == 0 {
+		d.sizes[e.Hash] = e.Size
+		d.size += e.Size
+	}
+	d.refs[e.Hash]++
+	d.dirty = true
+}
+
+// remove 从内存索引中移除一项，返回其音频是否已不再被引用，调用方须持有锁
+func (d *DiskCache) remove(key string) (orphan bool) {
+	e, ok := d.entries[key]
+	if !ok {
+		return false
+	}
+	delete(d.entries, key)
+	d.dirty = true
+
+	if d.refs[e.Hash]--; d.refs[e.Hash] > 0 {
+		return false
+	}
+	delete(d.refs, e.Hash)
+	d.size -= d.sizes[e.Hash]
+	delete(d.sizes, e.Hash)
+	return true
+}
+
+// discard 移除引用该哈希的全部项，corrupt 为 true 时同时删除音频文件
+func (d *DiskCache) discard(hash string, corrupt bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for key, e := range d.entries {
+		if e.Hash == hash {
+			d.remove(key)
+		}
+	}
+	if corrupt {
+		_ = os.Remove(d.objectPath(hash))
+	}
+	_ = d.save()
+}
+
+// evict 按最近最少使用淘汰项直至不超过容量，调用方须持有锁
+func (d *DiskCache) evict() error {
+	if d.size <= d.maxBytes {
+		return nil
+	}
+
+	keys := make([]string, 0, len(d.entries))
+	for key := range d.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return d.entries[keys[i]].LastUsed.Before(d.entries[keys[j]].LastUsed)
+	})
+
+	for _, key := range keys {
+		if d.size <= d.maxBytes {
+			break
+		}
+		hash := d.entries[key].Hash
+		if d.remove(key) {
+			if err := os.Remove(d.objectPath(hash)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("删除缓存文件失败: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// save 将内存索引写入索引文件，调用方须持有锁
+func (d *DiskCache) save() error {
+	data, err := json.Marshal(diskIndex{Version: diskCacheVersion, Entries: d.entries})
+	if err != nil {
+		return fmt.Errorf("序列化缓存索引失败: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(d.dir, diskCacheIndex), data); err != nil {
+		return fmt.Errorf("保存缓存索引失败: %w", err)
+	}
+	d.dirty = false
+	return nil
+}
+
+// validHash 判断字符串是否为十六进制的 SHA-256
+func validHash(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil && s == strings.ToLower(s)
+}
@@ -0,0 +1,176 @@
+package gpt_sovits_go_sdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+// testEntry 返回一条带请求参数与内嵌参考音频的缓存项
+func testEntry(audio string) *CacheEntry {
+	req := testRequest()
+	req.RefAudioData = []byte("reference audio")
+	return &CacheEntry{
+		Audio:       []byte(audio),
+		ContentType: "audio/wav",
+		Seed:        42,
+		Timing:      &Timing{Words: []TimedUnit{{Text: "你好", Start: 0, End: time.Second}}},
+		Request:     &req,
+		CreatedAt:   time.Unix(1700000000, 0).UTC(),
+	}
+}
+
+// checkEntry 比较读取的缓存项与写入的缓存项，内嵌参考音频不应被保存
+func checkEntry(t *testing.T, got, want *CacheEntry) {
+	t.Helper()
+	if !bytes.Equal(got.Audio, want.Audio) {
+		t.Errorf("Audio = %q，期望 %q", got.Audio, want.Audio)
+	}
+	if got.ContentType != want.ContentType || got.Seed != want.Seed || !got.CreatedAt.Equal(want.CreatedAt) {
+		t.Errorf("元数据 = %+v，期望 %+v", got, want)
+	}
+	if got.Timing == nil || len(got.Timing.Words) != 1 || got.Timing.Words[0] != want.Timing.Words[0] {
+		t.Errorf("Timing = %+v，期望 %+v", got.Timing, want.Timing)
+	}
+	if got.Request == nil || got.Request.Text != want.Request.Text {
+		t.Fatalf("Request = %+v，期望 %+v", got.Request, want.Request)
+	}
+	if len(got.Request.RefAudioData) != 0 {
+		t.Error("缓存中不应保存内嵌参考音频")
+	}
+}
+
+func TestDiskCacheRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	d, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := testEntry("RIFF audio")
+	if err := d.Set(ctx, "k1", want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := d.Get(ctx, "k1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEntry(t, got, want)
+
+	if _, err := d.Get(ctx, "missing"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("未命中错误 = %v，期望 ErrCacheMiss", err)
+	}
+
+	// 重新打开后从索引恢复
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	d, err = NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = d.Get(ctx, "k1")
+	if err != nil {
+		t.Fatalf("重新打开后读取失败: %v", err)
+	}
+	checkEntry(t, got, want)
+}
+
+func TestDiskCacheDedupAndEviction(t *testing.T) {
+	ctx := context.Background()
+	d, err := NewDiskCache(t.TempDir(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 相同音频只保存一份
+	d.Set(ctx, "a", testEntry("12345"))
+	d.Set(ctx, "b", testEntry("12345"))
+	if d.Len() != 2 || d.Size() != 5 {
+		t.Fatalf("Len = %d, Size = %d，期望 2 项共 5 字节", d.Len(), d.Size())
+	}
+
+	// 超过容量时淘汰最久未使用的项
+	d.Get(ctx, "a")
+	d.Get(ctx, "b")
+	d.Set(ctx, "c", testEntry("abcdefgh"))
+	if _, err := d.Get(ctx, "a"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatal("超出容量后最久未使用的项应被淘汰")
+	}
+	if _, err := d.Get(ctx, "c"); err != nil {
+		t.Fatalf("最近写入的项应保留: %v", err)
+	}
+
+	// 超过容量的单项不写入
+	d.Set(ctx, "huge", testEntry("0123456789abc"))
+	if _, err := d.Get(ctx, "huge"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatal("超过容量的单项不应写入")
+	}
+}
+
+func TestDiskCacheOverwrite(t *testing.T) {
+	ctx := context.Background()
+	d, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objectPath := func(audio string) string {
+		sum := sha256.Sum256([]byte(audio))
+		return d.objectPath(hex.EncodeToString(sum[:]))
+	}
+
+	// 被其他键引用的旧音频保留
+	d.Set(ctx, "a", testEntry("old"))
+	d.Set(ctx, "b", testEntry("old"))
+	d.Set(ctx, "a", testEntry("new"))
+	if _, err := os.Stat(objectPath("old")); err != nil {
+		t.Fatalf("仍被引用的旧音频应保留: %v", err)
+	}
+
+	// 不再被引用的旧音频立即删除
+	d.Set(ctx, "b", testEntry("newer"))
+	if _, err := os.Stat(objectPath("old")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("不再被引用的旧音频应被删除: %v", err)
+	}
+
+	// 以相同音频覆盖时保留文件
+	d.Set(ctx, "b", testEntry("newer"))
+	got, err := d.Get(ctx, "b")
+	if err != nil {
+		t.Fatalf("以相同音频覆盖后读取失败: %v", err)
+	}
+	checkEntry(t, got, testEntry("newer"))
+	if d.Len() != 2 || d.Size() != int64(len("new")+len("newer")) {
+		t.Fatalf("Len = %d, Size = %d", d.Len(), d.Size())
+	}
+}
+
+func TestDiskCacheCorruption(t *testing.T) {
+	ctx := context.Background()
+	d, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Set(ctx, "k", testEntry("original"))
+
+	sum := sha256.Sum256([]byte("original"))
+	if err := os.WriteFile(d.objectPath(hex.EncodeToString(sum[:])), []byte("tampered"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := d.Get(ctx, "k"); !errors.Is(err, ErrCacheCorrupt) {
+		t.Fatalf("损坏的项错误 = %v，期望 ErrCacheCorrupt", err)
+	}
+	if _, err := d.Get(ctx, "k"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatal("损坏的项应被移除")
+	}
+	if n, err := d.GC(ctx); err != nil || d.Len() != 0 {
+		t.Fatalf("GC = %d, %v，Len = %d", n, err, d.Len())
+	}
+}
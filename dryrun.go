@@ -0,0 +1,56 @@
+package gpt_sovits_go_sdk
+
+// 提供不发送请求、仅构建并返回 HTTP 请求的调试接口
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// BuildTTSRequest 构建 TTS 将要发送的 POST 请求但不发送，可用于排查服务器返回 400 的原因
+// 返回的请求已合并默认参数并完成文本预处理，但未经过中间件，因此不包含认证头与签名
+func (c *Client) BuildTTSRequest(ctx context.Context, req TTSRequest) (*http.Request, error) {
+	httpReq, _, err := c.newTTSRequest(ctx, req)
+	return httpReq, err
+}
+
+// BuildTTSGetRequest 构建 TTSGet 将要发送的 GET 请求但不发送
+func (c *Client) BuildTTSGetRequest(ctx context.Context, req TTSRequest) (*http.Request, error) {
+	httpReq, _, err := c.newTTSGetRequest(ctx, req)
+	return httpReq, err
+}
+
+// ToCurl 生成与 POST /tts 等价的 curl 命令，JSON 请求体经过格式化，便于复现与分享失败的请求
+// 仅包含请求本身的字段，不包含客户端默认参数与文本预处理的结果，需要时可先对 BuildTTSRequest 的结果排查
+func (r *TTSRequest) ToCurl(baseURL string) string {
+	// 格式化 JSON，保留原始字符不做 HTML 转义
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return ""
+	}
+	body := strings.TrimRight(buf.String(), "\n")
+
+	// 输出文件扩展名与媒体类型一致
+	ext := string(r.MediaType)
+	if ext == "" {
+		ext = string(MediaTypeWAV)
+	}
+
+	var b strings.Builder
+	b.WriteString("curl -X POST " + shellQuote(strings.TrimRight(baseURL, "/")+"/tts") + " \\\n")
+	b.WriteString("  -H 'Content-Type: application/json' \\\n")
+	b.WriteString("  --data-raw " + shellQuote(body) + " \\\n")
+	b.WriteString("  -o output." + ext)
+	return b.String()
+}
+
+// shellQuote 以单引号包裹字符串供 POSIX shell 使用，内部的单引号以结束引用、转义、重新开始引用的方式处理
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,59 @@
+package gpt_sovits_go_sdk
+
+// 提供对空音频与过短音频的检测，服务器推理静默失败时可能返回状态码 200 与仅含文件头的 WAV
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrEmptyAudio 表示服务器返回了空音频或过短的音频，可用 errors.Is 判断后重试
+var ErrEmptyAudio = errors.New("音频为空或过短")
+
+// EmptyAudioError 代表空音频或过短音频的详细信息
+type EmptyAudioError struct {
+	Size     int           // 响应体字节数
+	Duration time.Duration // 检测到的音频时长
+	Min      time.Duration // 要求的最短时长
+}
+
+// Error 实现 error 接口
+func (e *EmptyAudioError) Error() string {
+	if e.Min > 0 {
+		return fmt.Sprintf("音频过短: 时长 %v，要求至少 %v（%d 字节）", e.Duration, e.Min, e.Size)
+	}
+	return fmt.Sprintf("音频为空（%d 字节）", e.Size)
+}
+
+// Is 使 errors.Is(err, ErrEmptyAudio) 成立
+func (e *EmptyAudioError) Is(target error) bool {
+	return target == ErrEmptyAudio
+}
+
+// WithMinAudioDuration 设置成功响应的最短音频时长，低于该时长时返回 EmptyAudioError
+// 未设置时仅将无音频数据的响应视为错误；无法计算时长的格式（ogg、aac）仅检查是否为空
+func WithMinAudioDuration(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.minAudioDuration = d
+	}
+}
+
+// checkAudio 检查成功响应中的音频是否为空或过短
+func (c *Client) checkAudio(ttsResp *TTSResponse) error {
+	if len(ttsResp.AudioData) == 0 {
+		return &EmptyAudioError{}
+	}
+
+	samples, d, ok := audioLength(ttsResp)
+	if !ok {
+		return nil
+	}
+	if samples == 0 {
+		return &EmptyAudioError{Size: len(ttsResp.AudioData)}
+	}
+	if c.minAudioDuration > 0 && d < c.minAudioDuration {
+		return &EmptyAudioError{Size: len(ttsResp.AudioData), Duration: d, Min: c.minAudioDuration}
+	}
+	return nil
+}
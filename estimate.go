@@ -0,0 +1,170 @@
+package gpt_sovits_go_sdk
+
+// 提供服务器未返回对齐信息时的词级时间戳估计，按字符数在音频时长内成比例分配，足以用于字幕高亮
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 估计时间戳的置信度，服务器返回的对齐信息置信度为 1
+const (
+	maxEstimatedConfidence = 0.5 // 短文本估计的置信度
+	minEstimatedConfidence = 0.1 // 置信度下限
+	confidentWords         = 10  // 不超过该词数时使用 maxEstimatedConfidence
+)
+
+// 标点在时间分配中折算的字符数，用于近似停顿
+const (
+	sentencePauseWeight = 2 // 句末标点
+	clausePauseWeight   = 1 // 其他标点
+)
+
+// EstimateTiming 按字符数估计文本在 [start, end) 区间内的词级时间戳
+// 中文、日文假名与韩文每个字视为一个词，其他文字按空白与标点分词；标点不产生词，但计入停顿时长。
+// 估计结果的 Estimated 为 true，Confidence 不超过 0.5，词数越多越低；文本中没有词或区间为空时返回 nil
+func EstimateTiming(text string, start, end time.Duration) *Timing {
+	if end <= start {
+		return nil
+	}
+
+	// 分词并记录每个词之前累计的权重
+	type word struct {
+		text        string
+		from, until int
+	}
+	var (
+		words  []word
+		weight int
+		cur    strings.Builder
+	)
+	flush := func() {
+		if cur.Len() == 0 {
+			return
+		}
+		n := utf8.RuneCountInString(cur.String())
+		words = append(words, word{text: cur.String(), from: weight, until: weight + n})
+		weight += n
+		cur.Reset()
+	}
+	for _, r := range text {
+		switch {
+		case isSyllabic(r):
+			flush()
+			words = append(words, word{text: string(r), from: weight, until: weight + 1})
+			weight++
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || r == '\'' || r == '-':
+			cur.WriteRune(r)
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			if strings.ContainsRune(sentenceEnds, r) {
+				weight += sentencePauseWeight
+			} else if unicode.IsPunct(r) {
+				weight += clausePauseWeight
+			}
+		}
+	}
+	flush()
+	if len(words) == 0 {
+		return nil
+	}
+
+	// 末尾的停顿计入最后一个词，使时间戳覆盖整个区间
+	span := end - start
+	at := func(w int) time.Duration {
+		return start + time.Duration(int64(span)*int64(w)/int64(words[len(words)-1].until))
+	}
+	timing := &Timing{
+		Words:      make([]TimedUnit, len(words)),
+		Estimated:  true,
+		Confidence: estimatedConfidence(len(words)),
+	}
+	for i, w := range words {
+		timing.Words[i] = TimedUnit{Text: w.text, Start: at(w.from), End: at(w.until)}
+	}
+	timing.Words[len(words)-1].End = end
+	return timing
+}
+
+// estimatedConfidence 返回估计 n 个词的时间戳的置信度
+func estimatedConfidence(n int) float64 {
+	if n <= confidentWords {
+		return maxEstimatedConfidence
+	}
+	return max(minEstimatedConfidence, maxEstimatedConfidence*confidentWords/float64(n))
+}
+
+// isSyllabic 判断字符是否按单字计为一个词
+func isSyllabic(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// WordTiming 返回响应的词级时间戳，服务器未返回词级对齐信息时按合成文本与音频时长估计
+// text 为本次合成的文本；无法计算音频时长时返回 nil
+func (r *TTSResponse) WordTiming(text string) *Timing {
+	if r.Timing != nil && len(r.Timing.Words) > 0 {
+		return r.Timing
+	}
+	return EstimateTiming(text, 0, r.Duration)
+}
+
+// Timing 返回长文本合成结果中全部块的词级时间戳，时间位置相对于拼接后的音频
+// 合并后的 Confidence 取各块的最低值，任一块为估计值时 Estimated 为 true
+func (r *LongTextResult) Timing() *Timing {
+	timing := &Timing{Confidence: 1}
+	for _, ch := range r.Chunks {
+		if ch.Timing == nil {
+			continue
+		}
+		timing.Words = append(timing.Words, ch.Timing.Words...)
+		timing.Phonemes = append(timing.Phonemes, ch.Timing.Phonemes...)
+		timing.Estimated = timing.Estimated || ch.Timing.Estimated
+		timing.Confidence = min(timing.Confidence, ch.Timing.Confidence)
+	}
+	if len(timing.Words) == 0 {
+		return nil
+	}
+	return timing
+}
+
+// chunkTiming 返回块在拼接音频中的词级时间戳
+// 服务器返回了词级对齐信息且未裁剪静音时平移到块的起始位置，否则按块的实际时长估计
+func chunkTiming(ch Chunk, trimmed bool) *Timing {
+	if t := ch.Timing; t != nil && len(t.Words) > 0 && !trimmed {
+		return t.shift(ch.Start)
+	}
+	return EstimateTiming(ch.Text, ch.Start, ch.End)
+}
+
+// shift 返回全部时间位置平移 d 后的副本
+func (t *Timing) shift(d time.Duration) *Timing {
+	out := *t
+	out.Words = shiftUnits(t.Words, d)
+	out.Phonemes = shiftUnits(t.Phonemes, d)
+	return &out
+}
+
+// shiftUnits 返回全部时间位置平移 d 后的副本
+func shiftUnits(units []TimedUnit, d time.Duration) []TimedUnit {
+	if units == nil {
+		return nil
+	}
+	out := make([]TimedUnit, len(units))
+	for i, u := range units {
+		out[i] = TimedUnit{Text: u.Text, Start: u.Start + d, End: u.End + d}
+	}
+	return out
+}
+
+// Cues 返回每个词对应的字幕，用于逐词高亮
+func (t *Timing) Cues() []Cue {
+	cues := make([]Cue, len(t.Words))
+	for i, w := range t.Words {
+		cues[i] = Cue{Start: w.Start, End: w.End, Text: w.Text}
+	}
+	return cues
+}
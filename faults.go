@@ -0,0 +1,273 @@
+package gpt_sovits_go_sdk
+
+// 提供故障注入，按概率注入延迟、超时、5xx 错误与截断的音频，用于测试应用的重试与降级逻辑
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrInjectedFault 表示错误由故障注入产生
+var ErrInjectedFault = errors.New("注入的故障")
+
+// FaultKind 代表注入的故障类型
+type FaultKind string
+
+// 可注入的故障类型
+const (
+	FaultTimeout  FaultKind = "timeout"  // 请求无响应直至超时
+	FaultTruncate FaultKind = "truncate" // 响应体读到一半连接中断
+)
+
+// FaultError 代表注入的故障，可用 errors.Is(err, ErrInjectedFault) 判断
+type FaultError struct {
+	Kind FaultKind // 故障类型
+}
+
+// Error 实现 error 接口
+func (e *FaultError) Error() string {
+	switch e.Kind {
+	case FaultTimeout:
+		return "注入的故障: 请求超时"
+	case FaultTruncate:
+		return "注入的故障: 连接中断"
+	}
+	return "注入的故障: " + string(e.Kind)
+}
+
+// Is 使 errors.Is(err, ErrInjectedFault) 成立
+func (e *FaultError) Is(target error) bool {
+	return target == ErrInjectedFault
+}
+
+// Timeout 使超时故障与网络超时错误一样被识别
+func (e *FaultError) Timeout() bool {
+	return e.Kind == FaultTimeout
+}
+
+// FaultInjector 代表故障注入器，并发安全
+// 每个请求依次按概率决定是否注入延迟、超时、错误响应与截断，概率为 0~1，为0时不注入该故障；
+// 注入的故障与真实故障一样经过客户端的重试、熔断与断点续传逻辑
+type FaultInjector struct {
+	Latency       time.Duration // 注入的延迟
+	LatencyJitter time.Duration // 延迟的随机抖动，实际延迟在 [Latency, Latency+LatencyJitter) 内
+	LatencyRate   float64       // 注入延迟的概率
+
+	TimeoutRate float64       // 注入超时的概率
+	Hang        time.Duration // 超时故障的挂起时长，为0时挂起直至请求上下文结束
+
+	ErrorRate   float64 // 返回错误响应的概率
+	ErrorStatus int     // 错误响应的状态码，为0时为 503
+
+	TruncateRate     float64 // 截断响应体的概率
+	TruncateFraction float64 // 截断前保留的响应体比例，<=0 或 >=1 时为 0.5
+
+	// Paths 非空时只对路径以其中之一开头的请求注入故障，如 "/tts"
+	Paths []string
+	// Seed 非0时以固定种子生成随机数，使故障序列可复现
+	Seed uint64
+
+	mu   sync.Mutex
+	rand *rand.Rand
+
+	requests, delayed, timedOut, errored, truncated atomic.Int64
+}
+
+// FaultStats 代表注入器的统计
+type FaultStats struct {
+	Requests  int64 // 经过注入器的请求数
+	Delayed   int64 // 注入延迟的请求数
+	TimedOut  int64 // 注入超时的请求数
+	Errors    int64 // 返回错误响应的请求数
+	Truncated int64 // 截断响应体的请求数
+}
+
+// Stats 返回注入器的统计
+func (f *FaultInjector) Stats() FaultStats {
+	return FaultStats{
+		Requests:  f.requests.Load(),
+		Delayed:   f.delayed.Load(),
+		TimedOut:  f.timedOut.Load(),
+		Errors:    f.errored.Load(),
+		Truncated: f.truncated.Load(),
+	}
+}
+
+// WithFaultInjection 为客户端设置故障注入器，注入器位于中间件链最内层，注入的故障经过全部中间件（如熔断器与限流器）
+func WithFaultInjection(f *FaultInjector) ClientOption {
+	return func(c *Client) {
+		c.faults = f
+	}
+}
+
+// Middleware 返回注入故障的中间件，可通过 Client.Use 注册
+func (f *FaultInjector) Middleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if !f.matches(req) {
+				return next(req)
+			}
+			f.requests.Add(1)
+			ctx := req.Context()
+
+			// 注入延迟
+			if f.hit(f.LatencyRate) {
+				f.delayed.Add(1)
+				if err := sleepCtx(ctx, f.Latency+f.jitter()); err != nil {
+					return nil, err
+				}
+			}
+
+			// 注入超时，挂起后返回超时错误
+			if f.hit(f.TimeoutRate) {
+				f.timedOut.Add(1)
+				if f.Hang <= 0 {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				}
+				if err := sleepCtx(ctx, f.Hang); err != nil {
+					return nil, err
+				}
+				return nil, &FaultError{Kind: FaultTimeout}
+			}
+
+			// 注入错误响应，不发送到服务器
+			if f.hit(f.ErrorRate) {
+				f.errored.Add(1)
+				return faultResponse(req, f.ErrorStatus), nil
+			}
+
+			resp, err := next(req)
+			if err != nil || resp.StatusCode != http.StatusOK || !f.hit(f.TruncateRate) {
+				return resp, err
+			}
+
+			// 截断响应体，读取到一定比例后返回连接中断
+			f.truncated.Add(1)
+			fraction := f.TruncateFraction
+			if fraction <= 0 || fraction >= 1 {
+				fraction = 0.5
+			}
+			limit := int64(-1)
+			if resp.ContentLength > 0 {
+				limit = int64(float64(resp.ContentLength) * fraction)
+			}
+			resp.Body = &truncatedBody{body: resp.Body, limit: limit}
+			return resp, nil
+		}
+	}
+}
+
+// matches 判断请求是否需要注入故障
+func (f *FaultInjector) matches(req *http.Request) bool {
+	if len(f.Paths) == 0 {
+		return true
+	}
+	for _, p := range f.Paths {
+		if strings.HasPrefix(req.URL.Path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// hit 以概率 p 返回 true
+func (f *FaultInjector) hit(p float64) bool {
+	if p <= 0 {
+		return false
+	}
+	return f.float() < p
+}
+
+// jitter 返回随机抖动
+func (f *FaultInjector) jitter() time.Duration {
+	if f.LatencyJitter <= 0 {
+		return 0
+	}
+	return time.Duration(f.float() * float64(f.LatencyJitter))
+}
+
+// float 返回 [0, 1) 内的随机数
+func (f *FaultInjector) float() float64 {
+	if f.Seed == 0 {
+		return rand.Float64()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.rand == nil {
+		f.rand = rand.New(rand.NewPCG(f.Seed, f.Seed))
+	}
+	return f.rand.Float64()
+}
+
+// sleepCtx 等待 d 或直至上下文结束
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// faultResponse 构造注入的错误响应，响应体与 api_v2 的错误格式一致
+func faultResponse(req *http.Request, status int) *http.Response {
+	if status == 0 {
+		status = http.StatusServiceUnavailable
+	}
+	body := fmt.Sprintf(`{"message": "注入的故障", "Exception": "%s"}`, http.StatusText(status))
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// truncatedBody 代表读取 limit 字节后中断的响应体，limit 为 -1 时在首次读取后中断
+type truncatedBody struct {
+	body  io.ReadCloser
+	limit int64
+	read  int64
+}
+
+// Read 实现 io.Reader 接口
+func (b *truncatedBody) Read(p []byte) (int, error) {
+	if b.limit >= 0 && b.read >= b.limit || b.limit < 0 && b.read > 0 {
+		return 0, &FaultError{Kind: FaultTruncate}
+	}
+	switch {
+	case b.limit >= 0 && int64(len(p)) > b.limit-b.read:
+		p = p[:b.limit-b.read]
+	case b.limit < 0 && len(p) > 1:
+		// 长度未知时首次读取只交付一部分
+		p = p[:len(p)/2]
+	}
+	n, err := b.body.Read(p)
+	b.read += int64(n)
+	return n, err
+}
+
+// Close 实现 io.Closer 接口
+func (b *truncatedBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,144 @@
+package gpt_sovits_go_sdk
+
+// 提供进行中请求的跟踪，用于切换模型前排空请求
+
+import (
+	"context"
+	"sync"
+)
+
+// requestGate 跟踪进行中的合成请求，排空期间阻止新请求进入，零值可直接使用
+type requestGate struct {
+	mu       sync.Mutex
+	active   int           // 进行中的请求数
+	draining bool          // 是否正在排空
+	closed   bool          // 客户端是否已关闭
+	changed  chan struct{} // 状态变化时关闭并替换
+}
+
+// notifyLocked 唤醒所有等待者，调用方必须持有锁
+func (g *requestGate) notifyLocked() {
+	if g.changed != nil {
+		close(g.changed)
+	}
+	g.changed = make(chan struct{})
+}
+
+// waitChanLocked 返回下一次状态变化时关闭的通道，调用方必须持有锁
+func (g *requestGate) waitChanLocked() chan struct{} {
+	if g.changed == nil {
+		g.changed = make(chan struct{})
+	}
+	return g.changed
+}
+
+// enter 登记一个新请求，排空期间阻塞等待，客户端关闭后返回 ErrClientClosed
+func (g *requestGate) enter(ctx context.Context) error {
+	g.mu.Lock()
+	for g.draining && !g.closed {
+		ch := g.waitChanLocked()
+		g.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+		g.mu.Lock()
+	}
+	if g.closed {
+		g.mu.Unlock()
+		return ErrClientClosed
+	}
+	g.active++
+	g.mu.Unlock()
+	return nil
+}
+
+// leave 注销一个已完成的请求
+func (g *requestGate) leave() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.active--
+	if g.active == 0 {
+		g.notifyLocked()
+	}
+}
+
+// drain 阻止新请求进入并等待进行中的请求完成，成功时返回恢复请求的函数
+func (g *requestGate) drain(ctx context.Context) (func(), error) {
+	g.mu.Lock()
+	// 同一时间只允许一个排空操作
+	for g.draining {
+		ch := g.waitChanLocked()
+		g.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ch:
+		}
+		g.mu.Lock()
+	}
+	g.draining = true
+
+	release := func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		g.draining = false
+		g.notifyLocked()
+	}
+
+	for g.active > 0 {
+		ch := g.waitChanLocked()
+		g.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		case <-ch:
+		}
+		g.mu.Lock()
+	}
+	g.mu.Unlock()
+
+	return release, nil
+}
+
+// close 阻止新请求进入，已进入的请求不受影响
+func (g *requestGate) close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.closed = true
+	g.notifyLocked()
+}
+
+// idle 等待进行中的请求全部完成
+func (g *requestGate) idle(ctx context.Context) error {
+	g.mu.Lock()
+	for g.active > 0 {
+		ch := g.waitChanLocked()
+		g.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+		g.mu.Lock()
+	}
+	g.mu.Unlock()
+	return nil
+}
+
+// InFlight 返回客户端当前进行中的合成请求数
+func (c *Client) InFlight() int {
+	c.gate.mu.Lock()
+	defer c.gate.mu.Unlock()
+
+	return c.gate.active
+}
@@ -0,0 +1,292 @@
+// Package golden 提供金样音频回归测试工具，以固定种子合成一组语料并与保存的摘要和时长比较，
+// 用于在下游项目的 CI 中发现模型或服务器升级引起的输出变化
+package golden
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	gsv "github.com/ssdomei232/gpt_sovits_go_sdk"
+)
+
+// UpdateEnv 为重新生成金样文件的环境变量，设置为非空值时 Test 以本次输出覆盖金样
+const UpdateEnv = "GSV_UPDATE_GOLDEN"
+
+// defaultTolerance 为时长的默认容差
+const defaultTolerance = 50 * time.Millisecond
+
+// Case 代表语料中的一条用例
+type Case struct {
+	Name    string         // 用例名称，在语料中唯一
+	Request gsv.TTSRequest // 合成请求，Text 必填
+	Seed    int            // 固定的随机种子，须为正数
+}
+
+// Config 代表回归测试配置
+type Config struct {
+	Client gsv.Synthesizer // 被测客户端，可为 *gsv.Client 或 *gsv.PoolClient
+	Cases  []Case          // 语料
+
+	// Tolerance 为时长允许的绝对偏差，<=0 时为 50ms
+	Tolerance time.Duration
+	// RelTolerance 为时长允许的相对偏差，如 0.02 表示 2%，与 Tolerance 取较大者
+	RelTolerance float64
+	// Strict 为 true 时摘要不同即视为失败；默认只报告摘要变化，
+	// 因为不同 GPU 或驱动版本下即使种子相同，输出也可能有细微差异
+	Strict bool
+}
+
+// Golden 代表一条用例的金样
+type Golden struct {
+	Name     string        `json:"name"`     // 用例名称
+	Text     string        `json:"text"`     // 合成文本，用于发现语料被修改
+	Seed     int           `json:"seed"`     // 随机种子
+	SHA256   string        `json:"sha256"`   // 音频的 SHA-256
+	Duration time.Duration `json:"duration"` // 音频时长
+	Bytes    int           `json:"bytes"`    // 音频字节数
+}
+
+// File 代表金样文件
+type File struct {
+	Goldens []Golden `json:"goldens"` // 按用例名称排列
+}
+
+// Result 代表一条用例的比较结果
+type Result struct {
+	Name        string        `json:"name"`                   // 用例名称
+	Got         Golden        `json:"got"`                    // 本次输出
+	Want        *Golden       `json:"want,omitempty"`         // 金样，不存在时为 nil
+	HashChanged bool          `json:"hash_changed,omitempty"` // 摘要是否变化
+	DurationOff time.Duration `json:"duration_off,omitempty"` // 本次时长与金样时长之差
+	Failed      bool          `json:"failed,omitempty"`       // 是否判定为失败
+	Reason      string        `json:"reason,omitempty"`       // 失败或变化的原因
+}
+
+// Report 代表回归测试报告
+type Report struct {
+	Results []Result `json:"results"` // 按语料顺序排列
+}
+
+// Passed 判断全部用例是否通过
+func (r *Report) Passed() bool {
+	for _, res := range r.Results {
+		if res.Failed {
+			return false
+		}
+	}
+	return true
+}
+
+// Failures 返回失败的用例
+func (r *Report) Failures() []Result {
+	var out []Result
+	for _, res := range r.Results {
+		if res.Failed {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// File 返回以本次输出生成的金样文件
+func (r *Report) File() *File {
+	f := &File{Goldens: make([]Golden, len(r.Results))}
+	for i, res := range r.Results {
+		f.Goldens[i] = res.Got
+	}
+	sort.Slice(f.Goldens, func(i, j int) bool { return f.Goldens[i].Name < f.Goldens[j].Name })
+	return f
+}
+
+// Synthesize 以固定种子依次合成语料，返回每条用例的输出摘要与时长
+// 请求以可复现模式发送并跳过客户端缓存；合成失败时返回错误
+func Synthesize(ctx context.Context, cfg Config) ([]Golden, error) {
+	if cfg.Client == nil {
+		return nil, fmt.Errorf("未设置被测客户端")
+	}
+	if err := validate(cfg.Cases); err != nil {
+		return nil, err
+	}
+
+	out := make([]Golden, len(cfg.Cases))
+	for i, tc := range cfg.Cases {
+		req := tc.Request
+		req.MakeDeterministic(tc.Seed)
+
+		resp, err := cfg.Client.TTS(ctx, req, gsv.WithChecksum(), gsv.WithoutCache())
+		if err == nil {
+			err = resp.Err()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("用例 %s: %w", tc.Name, err)
+		}
+		if resp.Duration <= 0 {
+			return nil, fmt.Errorf("用例 %s: 无法计算音频时长，请使用 wav 或 raw 格式", tc.Name)
+		}
+
+		out[i] = Golden{
+			Name:     tc.Name,
+			Text:     tc.Request.Text,
+			Seed:     tc.Seed,
+			SHA256:   resp.SHA256,
+			Duration: resp.Duration,
+			Bytes:    len(resp.AudioData),
+		}
+	}
+	return out, nil
+}
+
+// Compare 将本次输出与金样文件比较
+// 时长偏差超出容差、金样缺失或用例文本与种子与金样不一致时判定为失败，Strict 时摘要变化也判定为失败
+func Compare(cfg Config, got []Golden, want *File) *Report {
+	goldens := make(map[string]*Golden)
+	if want != nil {
+		for i := range want.Goldens {
+			goldens[want.Goldens[i].Name] = &want.Goldens[i]
+		}
+	}
+
+	report := &Report{Results: make([]Result, len(got))}
+	for i, g := range got {
+		res := Result{Name: g.Name, Got: g, Want: goldens[g.Name]}
+		switch w := res.Want; {
+		case w == nil:
+			res.Failed, res.Reason = true, "缺少金样"
+		case w.Text != g.Text || w.Seed != g.Seed:
+			res.Failed, res.Reason = true, "用例的文本或种子与金样不一致，需重新生成金样"
+		default:
+			res.HashChanged = w.SHA256 != g.SHA256
+			res.DurationOff = g.Duration - w.Duration
+
+			var reasons []string
+			if tol := tolerance(cfg, w.Duration); res.DurationOff > tol || res.DurationOff < -tol {
+				res.Failed = true
+				reasons = append(reasons, fmt.Sprintf("时长 %v 与金样 %v 相差 %v，超出容差 %v", g.Duration, w.Duration, res.DurationOff, tol))
+			}
+			if res.HashChanged {
+				res.Failed = res.Failed || cfg.Strict
+				reasons = append(reasons, "音频摘要变化")
+			}
+			res.Reason = strings.Join(reasons, "；")
+		}
+		report.Results[i] = res
+	}
+	return report
+}
+
+// Check 合成语料并与 path 处的金样文件比较
+func Check(ctx context.Context, cfg Config, path string) (*Report, error) {
+	want, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	got, err := Synthesize(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(cfg, got, want), nil
+}
+
+// Test 在测试中合成语料并与 path 处的金样文件比较，失败的用例以 t.Errorf 报告，摘要变化以 t.Logf 记录
+// 环境变量 GSV_UPDATE_GOLDEN 非空时以本次输出覆盖金样文件
+func Test(t testing.TB, ctx context.Context, cfg Config, path string) {
+	t.Helper()
+
+	got, err := Synthesize(ctx, cfg)
+	if err != nil {
+		t.Fatalf("合成金样语料失败: %v", err)
+	}
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := Save(path, Compare(cfg, got, nil).File()); err != nil {
+			t.Fatalf("%v", err)
+		}
+		t.Logf("已更新金样文件 %s", path)
+		return
+	}
+
+	want, err := Load(path)
+	if err != nil {
+		t.Fatalf("%v（设置 %s=1 以生成金样）", err, UpdateEnv)
+	}
+	for _, res := range Compare(cfg, got, want).Results {
+		switch {
+		case res.Failed:
+			t.Errorf("用例 %s: %s", res.Name, res.Reason)
+		case res.Reason != "":
+			t.Logf("用例 %s: %s", res.Name, res.Reason)
+		}
+	}
+}
+
+// Load 读取金样文件
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("金样文件不存在: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取金样文件失败: %w", err)
+	}
+
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("解析金样文件失败: %w", err)
+	}
+	return &f, nil
+}
+
+// Save 将金样文件写入 path，目录不存在时自动创建
+func Save(path string, f *File) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化金样文件失败: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("创建金样目录失败: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("写入金样文件失败: %w", err)
+	}
+	return nil
+}
+
+// validate 校验语料
+func validate(cases []Case) error {
+	if len(cases) == 0 {
+		return fmt.Errorf("语料为空")
+	}
+	seen := make(map[string]bool)
+	for i, tc := range cases {
+		switch {
+		case tc.Name == "":
+			return fmt.Errorf("第 %d 条用例未设置名称", i+1)
+		case seen[tc.Name]:
+			return fmt.Errorf("用例名称重复: %s", tc.Name)
+		case tc.Request.Text == "":
+			return fmt.Errorf("用例 %s 的文本为空", tc.Name)
+		case tc.Seed <= 0:
+			return fmt.Errorf("用例 %s 的种子须为正数", tc.Name)
+		}
+		seen[tc.Name] = true
+	}
+	return nil
+}
+
+// tolerance 返回时长为 d 的金样允许的偏差
+func tolerance(cfg Config, d time.Duration) time.Duration {
+	tol := cfg.Tolerance
+	if tol <= 0 {
+		tol = defaultTolerance
+	}
+	return max(tol, time.Duration(float64(d)*cfg.RelTolerance))
+}
@@ -0,0 +1,281 @@
+package gpt_sovits_go_sdk
+
+// 提供面向浏览器的 HTTP 处理器，使 Web 应用无需暴露 GPT-SoVITS 服务器
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 处理器的默认限制
+const (
+	defaultHandlerMaxText = 500      // 默认最大文本长度（字符数）
+	defaultHandlerMaxBody = 64 << 10 // 默认最大请求体字节数
+)
+
+// HandlerOptions 代表 HTTP 处理器的配置
+type HandlerOptions struct {
+	Defaults      TTSRequest     // 服务端默认参数，参考音频等字段不受前端控制
+	Voices        *VoiceRegistry // 可选择的音色，为 nil 时使用客户端通过 WithVoices 设置的注册表
+	AllowedVoices []string       // 允许前端选择的音色名称，为空时允许注册表中的全部音色
+	MaxTextLength int            // 单次请求的最大文本长度（字符数），默认 500，负数表示不限制
+	MaxBodyBytes  int64          // 请求体的最大字节数，默认 64 KiB
+	MinSpeed      float64        // 允许的最小语速，默认 0.5
+	MaxSpeed      float64        // 允许的最大语速，默认 2
+	Streaming     bool           // 无 Range 请求头时以流式模式边合成边转发
+	SSE           SSEOptions     // 请求头 Accept 为 text/event-stream 时的 SSE 配置
+
+	// ErrorLog 记录上游与传输错误的详情，前端只收到通用错误信息；为 nil 时使用 log 包的默认日志
+	ErrorLog *log.Logger
+}
+
+// HandlerRequest 代表前端发送的合成请求，POST 时为 JSON 请求体，GET 时为同名查询参数
+type HandlerRequest struct {
+	Text        string  `json:"text"`                   // 需要合成的文本
+	TextLang    string  `json:"text_lang,omitempty"`    // 文本语言，为空时使用默认参数
+	Voice       string  `json:"voice,omitempty"`        // 音色名称
+	Style       string  `json:"style,omitempty"`        // 音色风格
+	SpeedFactor float64 `json:"speed_factor,omitempty"` // 语速
+}
+
+// HTTPHandler 代表将前端请求转发为 TTS 合成的 http.Handler
+// 非流式响应支持 Range 请求，可直接作为 <audio> 元素的 src
+type HTTPHandler struct {
+	client *Client
+	opts   HandlerOptions
+}
+
+// NewHTTPHandler 创建一个新的 HTTP 处理器
+func NewHTTPHandler(client *Client, opts HandlerOptions) *HTTPHandler {
+	if opts.Voices == nil {
+		opts.Voices = client.voices
+	}
+	if opts.MaxTextLength == 0 {
+		opts.MaxTextLength = defaultHandlerMaxText
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = defaultHandlerMaxBody
+	}
+	if opts.MinSpeed <= 0 {
+		opts.MinSpeed = 0.5
+	}
+	if opts.MaxSpeed <= 0 {
+		opts.MaxSpeed = 2
+	}
+	if opts.Defaults.MediaType == "" {
+		opts.Defaults.MediaType = MediaTypeWAV
+	}
+	h := &HTTPHandler{client: client, opts: opts}
+	if h.opts.SSE.ErrorMessage == nil {
+		h.opts.SSE.ErrorMessage = func(err error) string {
+			_, msg := h.synthesisError(err)
+			return msg
+		}
+	}
+	return h
+}
+
+// ServeHTTP 实现 http.Handler
+func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		writeHandlerError(w, http.StatusMethodNotAllowed, errors.New("不支持的请求方法"))
+		return
+	}
+
+	// 解析并校验前端请求
+	in, err := h.parse(w, r)
+	if err != nil {
+		writeHandlerError(w, http.StatusBadRequest, err)
+		return
+	}
+	req, status, err := h.build(in)
+	if err != nil {
+		writeHandlerError(w, status, err)
+		return
+	}
+	contentType := req.MediaType.ContentType()
+
+	// EventSource 客户端以 SSE 事件接收音频
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		_, _ = h.client.TTSStreamSSE(r.Context(), req, w, h.opts.SSE)
+		return
+	}
+
+	// 流式转发，首个数据块到达后才写出响应头，失败时仍可返回错误状态
+	if h.opts.Streaming && r.Header.Get("Range") == "" && r.Method != http.MethodHead {
+		lw := &lazyHeaderWriter{w: w, contentType: contentType}
+		if _, err := h.client.TTSStreamTo(r.Context(), req, lw); err != nil && !lw.wrote {
+			status, msg := h.synthesisError(err)
+			writeHandlerError(w, status, errors.New(msg))
+		}
+		return
+	}
+
+	// 合成完整音频后由 ServeContent 处理 Range 与 HEAD 请求
+	resp, err := h.client.TTS(r.Context(), req)
+	if err == nil {
+		err = resp.Err()
+	}
+	if err != nil {
+		status, msg := h.synthesisError(err)
+		writeHandlerError(w, status, errors.New(msg))
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+	if resp.RequestID != "" {
+		w.Header().Set(RequestIDHeader, resp.RequestID)
+	}
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(resp.AudioData))
+}
+
+// parse 从请求体或查询参数中解析前端请求
+func (h *HTTPHandler) parse(w http.ResponseWriter, r *http.Request) (HandlerRequest, error) {
+	var in HandlerRequest
+	if r.Method == http.MethodPost {
+		body := http.MaxBytesReader(w, r.Body, h.opts.MaxBodyBytes)
+		if err := json.NewDecoder(body).Decode(&in); err != nil {
+			return in, fmt.Errorf("解析请求体失败: %w", err)
+		}
+		return in, nil
+	}
+
+	q := r.URL.Query()
+	in.Text = q.Get("text")
+	in.TextLang = q.Get("text_lang")
+	in.Voice = q.Get("voice")
+	in.Style = q.Get("style")
+	if s := q.Get("speed_factor"); s != "" {
+		speed, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return in, fmt.Errorf("语速无效: %w", err)
+		}
+		in.SpeedFactor = speed
+	}
+	return in, nil
+}
+
+// build 应用服务端默认参数与限制，返回实际发送的请求，失败时返回对应的状态码
+func (h *HTTPHandler) build(in HandlerRequest) (TTSRequest, int, error) {
+	req := h.opts.Defaults
+
+	// 校验文本
+	if in.Text == "" {
+		return req, http.StatusBadRequest, errors.New("文本不能为空")
+	}
+	if n := utf8.RuneCountInString(in.Text); h.opts.MaxTextLength > 0 && n > h.opts.MaxTextLength {
+		return req, http.StatusRequestEntityTooLarge, fmt.Errorf("文本长度 %d 超过上限 %d", n, h.opts.MaxTextLength)
+	}
+
+	// 应用音色与风格
+	if in.Voice != "" {
+		if h.opts.Voices == nil || (len(h.opts.AllowedVoices) > 0 && !slices.Contains(h.opts.AllowedVoices, in.Voice)) {
+			return req, http.StatusForbidden, fmt.Errorf("不允许使用音色 %s", in.Voice)
+		}
+		v, err := h.opts.Voices.Get(in.Voice)
+		if err != nil {
+			return req, http.StatusForbidden, err
+		}
+		if err := v.ApplyStyle(&req, in.Style); err != nil {
+			return req, http.StatusBadRequest, err
+		}
+		if in.Text, err = v.ProcessText(in.Text, in.TextLang); err != nil {
+			return req, http.StatusInternalServerError, err
+		}
+	}
+
+	req.Text = in.Text
+	if in.TextLang != "" {
+		req.TextLang = in.TextLang
+	}
+
+	// 语速限制在允许范围内
+	if in.SpeedFactor != 0 {
+		if in.SpeedFactor < h.opts.MinSpeed || in.SpeedFactor > h.opts.MaxSpeed {
+			return req, http.StatusBadRequest, fmt.Errorf("语速 %g 超出范围 [%g, %g]", in.SpeedFactor, h.opts.MinSpeed, h.opts.MaxSpeed)
+		}
+		req.SpeedFactor = in.SpeedFactor
+	}
+
+	return req, http.StatusOK, nil
+}
+
+// synthesisError 将合成错误映射为状态码与返回给前端的信息
+// 内容过滤、配额、音色授权与文本长度等本地拒绝返回对应的 4xx；
+// 上游与传输错误只返回通用信息并在服务端记录详情，避免向浏览器泄露后端地址
+func (h *HTTPHandler) synthesisError(err error) (int, string) {
+	var (
+		blocked *ContentBlockedError
+		tooLong *TextTooLongError
+	)
+	switch {
+	case errors.As(err, &blocked):
+		return http.StatusUnprocessableEntity, blocked.Error()
+	case errors.As(err, &tooLong):
+		return http.StatusRequestEntityTooLarge, tooLong.Error()
+	case errors.Is(err, ErrQuotaExceeded):
+		return http.StatusTooManyRequests, ErrQuotaExceeded.Error()
+	case errors.Is(err, ErrVoiceNotAuthorized):
+		// 授权错误可能包含服务器上的参考音频路径
+		return http.StatusForbidden, ErrVoiceNotAuthorized.Error()
+	}
+
+	h.logf("合成失败: %v", err)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, "合成超时"
+	case errors.Is(err, ErrCircuitOpen):
+		return http.StatusServiceUnavailable, "合成服务暂不可用"
+	default:
+		return http.StatusBadGateway, "合成失败"
+	}
+}
+
+// logf 记录服务端错误日志
+func (h *HTTPHandler) logf(format string, args ...any) {
+	if h.opts.ErrorLog != nil {
+		h.opts.ErrorLog.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// lazyHeaderWriter 在首次写入时设置响应头
+type lazyHeaderWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	wrote       bool
+}
+
+// Write 实现 io.Writer，每次写入后刷新，使浏览器尽早开始播放
+func (l *lazyHeaderWriter) Write(p []byte) (int, error) {
+	if !l.wrote {
+		l.w.Header().Set("Content-Type", l.contentType)
+		l.w.Header().Set("Cache-Control", "no-store")
+		l.w.WriteHeader(http.StatusOK)
+		l.wrote = true
+	}
+	n, err := l.w.Write(p)
+	if f, ok := l.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return n, err
+}
+
+// writeHandlerError 以 JSON 返回错误信息
+func writeHandlerError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
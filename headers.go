@@ -0,0 +1,26 @@
+package gpt_sovits_go_sdk
+
+// 提供单次调用的自定义请求头，供推理服务器前的网关读取租户、优先级或链路追踪信息
+
+import (
+	"maps"
+	"net/http"
+)
+
+// WithHeaders 为本次调用附加请求头，多次使用时合并，同名请求头以后设置的为准
+// 其中的 X-Request-ID 优先于 WithRequestID 与自动生成的请求 ID
+func WithHeaders(headers map[string]string) CallOption {
+	return func(o *callOptions) {
+		if o.headers == nil {
+			o.headers = make(map[string]string, len(headers))
+		}
+		maps.Copy(o.headers, headers)
+	}
+}
+
+// setCallHeaders 将本次调用的自定义请求头写入请求
+func setCallHeaders(httpReq *http.Request, o *callOptions) {
+	for k, v := range o.headers {
+		httpReq.Header.Set(k, v)
+	}
+}
@@ -0,0 +1,179 @@
+package gpt_sovits_go_sdk
+
+// 提供任务队列工作进程的健康检查端点，供 Kubernetes 等环境的存活与就绪探针使用
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// 健康检查的默认配置
+const (
+	defaultHealthPingTimeout = 2 * time.Second // 默认后端探测超时
+	defaultHealthShutdown    = 5 * time.Second // 默认健康检查服务器关闭超时
+)
+
+// HealthOptions 代表健康检查的配置
+type HealthOptions struct {
+	PingTimeout time.Duration // 探测后端的超时时间，默认 2 秒
+
+	// MaxStall 为存在等待任务时允许的最长无成功时间，超过后存活检查失败，使工作进程被重启
+	// 计时从最近一次成功或健康检查创建时开始，<=0 表示不检查
+	MaxStall time.Duration
+}
+
+// HealthStatus 代表任务队列工作进程的健康状态
+type HealthStatus struct {
+	Live  bool `json:"live"`  // 存活检查是否通过
+	Ready bool `json:"ready"` // 就绪检查是否通过
+
+	BackendReachable bool          `json:"backend_reachable"`       // 后端是否可达
+	BackendError     string        `json:"backend_error,omitempty"` // 后端不可达的原因
+	BackendLatency   time.Duration `json:"backend_latency"`         // 探测后端的耗时
+
+	Pending int `json:"pending"` // 等待执行的任务数，包括执行中的任务
+	Running int `json:"running"` // 执行中的任务数
+	Failed  int `json:"failed"`  // 重试次数耗尽后失败的任务数
+
+	LastSuccess *time.Time `json:"last_success,omitempty"` // 最近一次任务成功的时间，尚未成功时为 nil
+	Closed      bool       `json:"closed"`                 // 队列是否已关闭
+	Error       string     `json:"error,omitempty"`        // 读取任务存储失败的原因
+}
+
+// HealthHandler 代表报告任务队列健康状态的 http.Handler
+//
+// 提供以下端点，均返回 JSON 格式的 HealthStatus：
+//   - /healthz 存活检查，队列已关闭或任务长时间无进展时返回 503
+//   - /readyz 就绪检查，后端不可达、任务存储不可读或队列已关闭时返回 503
+type HealthHandler struct {
+	queue   *JobQueue
+	opts    HealthOptions
+	started time.Time
+	mux     *http.ServeMux
+}
+
+// NewHealthHandler 创建一个新的健康检查处理器
+func NewHealthHandler(q *JobQueue, opts HealthOptions) *HealthHandler {
+	if opts.PingTimeout <= 0 {
+		opts.PingTimeout = defaultHealthPingTimeout
+	}
+
+	h := &HealthHandler{queue: q, opts: opts, started: time.Now(), mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		status := h.Check(r.Context(), false)
+		writeHealth(w, status, status.Live)
+	})
+	h.mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
+		status := h.Check(r.Context(), true)
+		writeHealth(w, status, status.Ready)
+	})
+	return h
+}
+
+// ServeHTTP 实现 http.Handler
+func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Check 返回当前的健康状态，probe 为 true 时探测后端连通性，否则 Ready 始终为 false
+func (h *HealthHandler) Check(ctx context.Context, probe bool) HealthStatus {
+	q := h.queue
+	status := HealthStatus{
+		Running: int(q.running.Load()),
+		Closed:  q.isClosed(),
+	}
+	since := h.started
+	if ns := q.lastSuccess.Load(); ns != 0 {
+		last := time.Unix(0, ns)
+		status.LastSuccess = &last
+		since = last
+	}
+
+	// 统计任务存储中的任务
+	jobs, err := q.Store.List()
+	if err != nil {
+		status.Error = err.Error()
+	}
+	for _, job := range jobs {
+		switch job.Status {
+		case JobPending, JobRunning:
+			status.Pending++
+		case JobFailed:
+			status.Failed++
+		}
+	}
+
+	// 存在等待任务但长时间没有成功时视为卡死
+	status.Live = !status.Closed
+	if h.opts.MaxStall > 0 && status.Pending > 0 && time.Since(since) > h.opts.MaxStall {
+		status.Live = false
+	}
+
+	if probe {
+		pingCtx, cancel := context.WithTimeout(ctx, h.opts.PingTimeout)
+		start := time.Now()
+		err := q.Client.Ping(pingCtx)
+		cancel()
+
+		status.BackendLatency = time.Since(start)
+		status.BackendReachable = err == nil
+		if err != nil {
+			status.BackendError = err.Error()
+		}
+	}
+	status.Ready = status.BackendReachable && status.Error == "" && !status.Closed
+	return status
+}
+
+// writeHealth 写出健康状态，ok 为 false 时返回 503
+func writeHealth(w http.ResponseWriter, status HealthStatus, ok bool) {
+	code := http.StatusOK
+	if !ok {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// ServeHealth 在 addr 上启动内嵌的健康检查服务器，阻塞直至 ctx 取消后关闭服务器
+// 通常与 Run 在不同的 goroutine 中运行，ctx 取消时返回 nil
+func (q *JobQueue) ServeHealth(ctx context.Context, addr string, opts HealthOptions) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("启动健康检查服务器失败: %w", err)
+	}
+
+	srv := &http.Server{
+		Handler:           NewHealthHandler(q, opts),
+		ReadHeaderTimeout: 5 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), defaultHealthShutdown)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+// Package ws 提供仅依赖标准库的最小 WebSocket (RFC 6455) 客户端实现
+package ws
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Opcode 代表 WebSocket 帧类型
+type Opcode byte
+
+const (
+	OpContinuation Opcode = 0x0 // 延续帧
+	OpText         Opcode = 0x1 // 文本帧
+	OpBinary       Opcode = 0x2 // 二进制帧
+	OpClose        Opcode = 0x8 // 关闭帧
+	OpPing         Opcode = 0x9 // ping 帧
+	OpPong         Opcode = 0xA // pong 帧
+)
+
+// acceptGUID 为计算 Sec-WebSocket-Accept 使用的固定 GUID
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxMessageSize 为单条消息的最大长度
+const maxMessageSize = 64 << 20
+
+// ErrClosed 表示连接已被对端关闭
+var ErrClosed = errors.New("websocket 连接已关闭")
+
+// Conn 代表一个客户端 WebSocket 连接，写操作并发安全，读操作需由单个协程执行
+type Conn struct {
+	conn net.Conn
+	br   *bufio.Reader
+	wmu  sync.Mutex
+
+	// OnPong 在收到 pong 帧时调用
+	OnPong func(data []byte)
+}
+
+// Dial 建立 WebSocket 连接，支持 ws:// 与 wss://
+func Dial(ctx context.Context, rawURL string, header http.Header, tlsConfig *tls.Config) (*Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("无效的 WebSocket 地址: %w", err)
+	}
+
+	host := u.Host
+	switch u.Scheme {
+	case "ws":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "80")
+		}
+	case "wss":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "443")
+		}
+	default:
+		return nil, fmt.Errorf("不支持的 WebSocket 协议 %q", u.Scheme)
+	}
+
+	// 建立底层连接
+	var d net.Dialer
+	nc, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, fmt.Errorf("连接 WebSocket 服务器失败: %w", err)
+	}
+	if u.Scheme == "wss" {
+		cfg := tlsConfig.Clone()
+		if cfg == nil {
+			cfg = &tls.Config{}
+		}
+		if cfg.ServerName == "" {
+			cfg.ServerName = u.Hostname()
+		}
+		tc := tls.Client(nc, cfg)
+		if err := tc.HandshakeContext(ctx); err != nil {
+			nc.Close()
+			return nil, fmt.Errorf("TLS 握手失败: %w", err)
+		}
+		nc = tc
+	}
+
+	// 握手期间遵守上下文截止时间
+	if deadline, ok := ctx.Deadline(); ok {
+		nc.SetDeadline(deadline)
+		defer nc.SetDeadline(time.Time{})
+	}
+
+	c := &Conn{conn: nc, br: bufio.NewReader(nc)}
+	if err := c.handshake(u, header); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// handshake 执行 HTTP Upgrade 握手
+func (c *Conn) handshake(u *url.URL, header http.Header) error {
+	keyBytes := make([]byte, 16)
+	if _, err := rand.Read(keyBytes); err != nil {
+		return fmt.Errorf("生成握手密钥失败: %w", err)
+	}
+	key := base64.StdEncoding.EncodeToString(keyBytes)
+
+	req := &http.Request{
+		Method:     http.MethodGet,
+		URL:        &url.URL{Path: u.Path, RawPath: u.RawPath, RawQuery: u.RawQuery},
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Host:       u.Host,
+	}
+	if req.URL.Path == "" {
+		req.URL.Path = "/"
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+
+	if err := req.Write(c.conn); err != nil {
+		return fmt.Errorf("发送握手请求失败: %w", err)
+	}
+
+	resp, err := http.ReadResponse(c.br, req)
+	if err != nil {
+		return fmt.Errorf("读取握手响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+		return fmt.Errorf("WebSocket 握手失败，状态码 %d: %s", resp.StatusCode, string(body))
+	}
+
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	if resp.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(sum[:]) {
+		return fmt.Errorf("WebSocket 握手失败: Sec-WebSocket-Accept 不匹配")
+	}
+	if !strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") {
+		return fmt.Errorf("WebSocket 握手失败: 缺少 Upgrade 响应头")
+	}
+	return nil
+}
+
+// WriteMessage 发送一条完整的消息，客户端帧按协议要求进行掩码处理
+func (c *Conn) WriteMessage(op Opcode, data []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	header := make([]byte, 0, 14)
+	header = append(header, 0x80|byte(op)) // FIN + opcode
+
+	n := len(data)
+	switch {
+	case n < 126:
+		header = append(header, 0x80|byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 0x80|126, byte(n>>8), byte(n))
+	default:
+		header = append(header, 0x80|127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	mask := make([]byte, 4)
+	if _, err := rand.Read(mask); err != nil {
+		return fmt.Errorf("生成掩码失败: %w", err)
+	}
+	header = append(header, mask...)
+
+	payload := make([]byte, n)
+	for i := range data {
+		payload[i] = data[i] ^ mask[i%4]
+	}
+
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		return fmt.Errorf("发送 WebSocket 帧失败: %w", err)
+	}
+	return nil
+}
+
+// ReadMessage 读取下一条数据消息，自动合并分片帧并应答 ping
+func (c *Conn) ReadMessage() (Opcode, []byte, error) {
+	var (
+		msgOp Opcode
+		msg   []byte
+	)
+
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch op {
+		case OpPing:
+			if err := c.WriteMessage(OpPong, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case OpPong:
+			if c.OnPong != nil {
+				c.OnPong(payload)
+			}
+			continue
+		case OpClose:
+			_ = c.WriteMessage(OpClose, payload)
+			return 0, nil, ErrClosed
+		case OpContinuation:
+			if msgOp == 0 {
+				return 0, nil, fmt.Errorf("收到意外的延续帧")
+			}
+		default:
+			msgOp = op
+			msg = msg[:0]
+		}
+
+		msg = append(msg, payload...)
+		if len(msg) > maxMessageSize {
+			return 0, nil, fmt.Errorf("WebSocket 消息过大")
+		}
+		if fin {
+			return msgOp, msg, nil
+		}
+	}
+}
+
+// readFrame 读取单个帧
+func (c *Conn) readFrame() (fin bool, op Opcode, payload []byte, err error) {
+	var head [2]byte
+	if _, err = io.ReadFull(c.br, head[:]); err != nil {
+		return false, 0, nil, fmt.Errorf("读取 WebSocket 帧失败: %w", err)
+	}
+
+	fin = head[0]&0x80 != 0
+	op = Opcode(head[0] & 0x0F)
+	masked := head[1]&0x80 != 0
+
+	n := uint64(head[1] & 0x7F)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, fmt.Errorf("读取 WebSocket 帧失败: %w", err)
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, fmt.Errorf("读取 WebSocket 帧失败: %w", err)
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	if n > maxMessageSize {
+		return false, 0, nil, fmt.Errorf("WebSocket 帧过大")
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.br, mask[:]); err != nil {
+			return false, 0, nil, fmt.Errorf("读取 WebSocket 帧失败: %w", err)
+		}
+	}
+
+	payload = make([]byte, n)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, fmt.Errorf("读取 WebSocket 帧失败: %w", err)
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return fin, op, payload, nil
+}
+
+// Ping 发送 ping 帧
+func (c *Conn) Ping(data []byte) error {
+	return c.WriteMessage(OpPing, data)
+}
+
+// Close 发送关闭帧并关闭底层连接
+func (c *Conn) Close() error {
+	_ = c.WriteMessage(OpClose, []byte{0x03, 0xE8}) // 1000 正常关闭
+	return c.conn.Close()
+}
@@ -0,0 +1,512 @@
+package gpt_sovits_go_sdk
+
+// 提供可持久化的批量合成任务队列，进程重启后可恢复未完成的任务
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/storage"
+)
+
+// JobStatus 代表任务状态
+type JobStatus string
+
+const (
+	JobPending JobStatus = "pending" // 等待执行
+	JobRunning JobStatus = "running" // 执行中
+	JobDone    JobStatus = "done"    // 已完成
+	JobFailed  JobStatus = "failed"  // 重试次数耗尽后失败
+)
+
+// ErrJobNotFound 表示任务不存在
+var ErrJobNotFound = errors.New("任务不存在")
+
+// ErrQueueClosed 表示任务队列已关闭
+var ErrQueueClosed = errors.New("任务队列已关闭")
+
+// Job 代表一个持久化的合成任务
+type Job struct {
+	ID          string     `json:"id"`                     // 任务ID
+	Request     TTSRequest `json:"request"`                // 合成请求
+	Status      JobStatus  `json:"status"`                 // 任务状态
+	Attempts    int        `json:"attempts"`               // 已尝试次数
+	LastError   string     `json:"last_error,omitempty"`   // 最近一次失败的错误信息
+	Output      string     `json:"output,omitempty"`       // 输出文件路径，设置了 Storage 时为对象 URL
+	OutputKey   string     `json:"output_key,omitempty"`   // 设置了 Storage 时的对象键，预签名 URL 过期后可据此重新获取
+	CreatedAt   time.Time  `json:"created_at"`             // 创建时间
+	UpdatedAt   time.Time  `json:"updated_at"`             // 更新时间
+	NextAttempt time.Time  `json:"next_attempt,omitempty"` // 下次允许重试的时间
+}
+
+// JobStore 代表任务的持久化存储，实现必须是并发安全的
+type JobStore interface {
+	Save(job *Job) error          // 创建或更新任务
+	Load(id string) (*Job, error) // 读取任务，不存在时返回 ErrJobNotFound
+	List() ([]*Job, error)        // 列出全部任务
+	Delete(id string) error       // 删除任务
+}
+
+// FileJobStore 代表以目录存储任务的 JobStore，每个任务对应一个 JSON 文件
+type FileJobStore struct {
+	Dir string // 任务文件所在目录
+
+	mu sync.Mutex
+}
+
+// NewFileJobStore 创建一个基于目录的任务存储，目录不存在时自动创建
+func NewFileJobStore(dir string) (*FileJobStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("创建任务目录失败: %w", err)
+	}
+	return &FileJobStore{Dir: dir}, nil
+}
+
+// path 返回任务文件路径
+func (s *FileJobStore) path(id string) string {
+	return filepath.Join(s.Dir, id+".json")
+}
+
+// Save 以先写临时文件再重命名的方式原子地保存任务
+func (s *FileJobStore) Save(job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return fmt.Errorf("任务序列化失败: %w", err)
+	}
+	return writeFileAtomic(s.path(job.ID), data)
+}
+
+// Load 读取任务
+func (s *FileJobStore) Load(id string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取任务失败: %w", err)
+	}
+
+	var job Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("任务反序列化失败: %w", err)
+	}
+	return &job, nil
+}
+
+// List 列出目录中的全部任务
+func (s *FileJobStore) List() ([]*Job, error) {
+	s.mu.Lock()
+	entries, err := os.ReadDir(s.Dir)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("读取任务目录失败: %w", err)
+	}
+
+	var jobs []*Job
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		job, err := s.Load(strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// Delete 删除任务
+func (s *FileJobStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := os.Remove(s.path(id))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("删除任务失败: %w", err)
+	}
+	return nil
+}
+
+// JobQueue 代表可持久化的合成任务队列
+type JobQueue struct {
+	Client      *Client               // 用于合成的客户端
+	Store       JobStore              // 任务存储
+	OutputDir   string                // 输出目录
+	Storage     storage.Storage       // 输出存储，设置时音频上传至存储而不写入 OutputDir
+	NameFunc    func(job *Job) string // 输出文件命名规则，为空时使用 "<任务ID>.<媒体类型>"
+	MaxAttempts int                   // 每个任务的最大尝试次数，<=0 时为 3
+	RetryDelay  time.Duration         // 失败后重试的等待时间
+	Concurrency int                   // 同时执行的任务数，<=0 时为 1
+
+	notify chan struct{}
+
+	mu      sync.Mutex
+	closed  bool               // 是否已关闭，关闭后不再开始新任务
+	done    chan struct{}      // 关闭时关闭
+	abort   context.Context    // 关闭超时时取消，用于中断执行中的任务
+	aborted context.CancelFunc // 取消 abort
+	active  sync.WaitGroup     // 执行中的任务
+
+	running     atomic.Int64 // 执行中的任务数
+	lastSuccess atomic.Int64 // 最近一次任务成功的时间（Unix 纳秒），0 表示尚未成功
+}
+
+// NewJobQueue 创建一个新的任务队列，客户端的 Shutdown 会一并关闭该队列
+func NewJobQueue(client *Client, store JobStore, outputDir string) *JobQueue {
+	abort, aborted := context.WithCancel(context.Background())
+	q := &JobQueue{
+		Client:     client,
+		Store:      store,
+		OutputDir:  outputDir,
+		RetryDelay: 5 * time.Second,
+		notify:     make(chan struct{}, 1),
+		done:       make(chan struct{}),
+		abort:      abort,
+		aborted:    aborted,
+	}
+	if client != nil {
+		client.registerQueue(q)
+	}
+	return q
+}
+
+// Enqueue 持久化一个新任务并唤醒正在运行的 Run，队列关闭后返回 ErrQueueClosed
+func (q *JobQueue) Enqueue(req TTSRequest) (*Job, error) {
+	if q.isClosed() {
+		return nil, ErrQueueClosed
+	}
+
+	now := time.Now()
+	job := &Job{
+		ID:        newJobID(now),
+		Request:   req,
+		Status:    JobPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := q.Store.Save(job); err != nil {
+		return nil, err
+	}
+
+	// 非阻塞地通知工作循环
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+
+	return job, nil
+}
+
+// Pending 返回尚未完成的任务，按创建时间排序
+// 状态为 running 的任务视为上次进程崩溃时中断的任务，同样会被返回
+func (q *JobQueue) Pending() ([]*Job, error) {
+	jobs, err := q.Store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*Job
+	for _, job := range jobs {
+		if job.Status == JobPending || job.Status == JobRunning {
+			pending = append(pending, job)
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+	return pending, nil
+}
+
+// Process 执行一轮所有到期的未完成任务，包括上次中断的任务
+// 队列关闭后不再开始新任务，未开始的任务保持等待状态
+func (q *JobQueue) Process(ctx context.Context) error {
+	jobs, err := q.Pending()
+	if err != nil {
+		return err
+	}
+
+	// 关闭超时时中断执行中的任务
+	if q.abort != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		stop := context.AfterFunc(q.abort, cancel)
+		defer stop()
+	}
+
+	concurrency := q.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+
+	now := time.Now()
+	for _, job := range jobs {
+		if job.NextAttempt.After(now) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+		if !q.begin() {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(job *Job) {
+			defer wg.Done()
+			defer q.active.Done()
+			defer func() { <-sem }()
+
+			if err := q.runJob(ctx, job); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(job)
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// isClosed 返回队列是否已关闭
+func (q *JobQueue) isClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.closed
+}
+
+// begin 登记一个即将开始的任务，队列已关闭时返回 false
+func (q *JobQueue) begin() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return false
+	}
+	q.active.Add(1)
+	return true
+}
+
+// Shutdown 关闭队列：不再接受与开始新任务，等待执行中的任务完成
+// 上下文到期时中断执行中的任务，被中断的任务恢复为等待状态保存在存储中，下次启动时继续执行
+func (q *JobQueue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		if q.done != nil {
+			close(q.done)
+		}
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.active.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	// 超时后中断执行中的任务，并等待其保存状态
+	if q.aborted != nil {
+		q.aborted()
+	}
+	<-done
+	return fmt.Errorf("等待任务完成超时: %w", ctx.Err())
+}
+
+// Run 持续处理任务直至上下文取消或队列关闭，启动时会先恢复未完成的任务
+// 队列关闭后返回 ErrQueueClosed
+func (q *JobQueue) Run(ctx context.Context) error {
+	delay := q.RetryDelay
+	if delay <= 0 {
+		delay = 5 * time.Second
+	}
+
+	for {
+		err := q.Process(ctx)
+		if q.isClosed() {
+			return ErrQueueClosed
+		}
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+
+		// 等待新任务或重试时间到达
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-q.notify:
+			timer.Stop()
+		case <-q.done:
+			timer.Stop()
+			return ErrQueueClosed
+		case <-timer.C:
+		}
+	}
+}
+
+// runJob 执行单个任务并持久化结果，只有存储失败时才返回错误
+func (q *JobQueue) runJob(ctx context.Context, job *Job) error {
+	q.running.Add(1)
+	defer q.running.Add(-1)
+
+	job.Status = JobRunning
+	job.Attempts++
+	job.UpdatedAt = time.Now()
+	if err := q.Store.Save(job); err != nil {
+		return err
+	}
+
+	jobErr := q.synthesize(ctx, job)
+
+	// 上下文取消时任务回到等待状态，不计入尝试次数
+	if jobErr != nil && ctx.Err() != nil {
+		job.Status = JobPending
+		job.Attempts--
+		job.UpdatedAt = time.Now()
+		return q.Store.Save(job)
+	}
+
+	maxAttempts := q.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	job.UpdatedAt = time.Now()
+	switch {
+	case jobErr == nil:
+		job.Status = JobDone
+		job.LastError = ""
+		q.lastSuccess.Store(job.UpdatedAt.UnixNano())
+	case job.Attempts >= maxAttempts:
+		job.Status = JobFailed
+		job.LastError = jobErr.Error()
+	default:
+		job.Status = JobPending
+		job.LastError = jobErr.Error()
+		job.NextAttempt = job.UpdatedAt.Add(q.RetryDelay)
+	}
+
+	return q.Store.Save(job)
+}
+
+// synthesize 发送合成请求并将音频写入输出目录
+func (q *JobQueue) synthesize(ctx context.Context, job *Job) error {
+	resp, err := q.Client.TTS(ctx, job.Request)
+	if err != nil {
+		return err
+	}
+	if err := resp.Err(); err != nil {
+		return err
+	}
+
+	// 生成输出文件名
+	name := ""
+	if q.NameFunc != nil {
+		name = q.NameFunc(job)
+	}
+	if name == "" {
+		ext := string(job.Request.MediaType)
+		if ext == "" {
+			ext = string(MediaTypeWAV)
+		}
+		name = job.ID + "." + ext
+	}
+
+	// 上传至存储后端，记录稳定的 URL
+	if q.Storage != nil {
+		ct := job.Request.MediaType.ContentType()
+		if job.Request.MediaType == "" {
+			ct = MediaTypeWAV.ContentType()
+		}
+		if err := q.Storage.Put(ctx, name, bytes.NewReader(resp.AudioData), ct); err != nil {
+			return fmt.Errorf("上传音频失败: %w", err)
+		}
+		u, err := q.Storage.URL(ctx, name)
+		if err != nil {
+			return fmt.Errorf("获取音频 URL 失败: %w", err)
+		}
+		job.Output = u
+		job.OutputKey = name
+		return nil
+	}
+
+	path := filepath.Join(q.OutputDir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+	if err := writeFileAtomic(path, resp.AudioData); err != nil {
+		return err
+	}
+
+	job.Output = path
+	return nil
+}
+
+// newJobID 生成按时间排序的任务ID
+func newJobID(now time.Time) string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%d-%s", now.UnixNano(), hex.EncodeToString(b))
+}
+
+// writeFileAtomic 先写入临时文件再重命名，避免崩溃时留下不完整的文件
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("创建临时文件失败: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("写入临时文件失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("关闭临时文件失败: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("重命名文件失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package lang
+
+// 提供基于字符脚本的纯 Go 语言检测启发式
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// script 代表字符所属的书写系统
+type script int
+
+const (
+	scriptNeutral script = iota // 数字、标点、空白等不区分语言的字符
+	scriptHan                   // 汉字
+	scriptKana                  // 平假名与片假名
+	scriptHangul                // 韩文
+	scriptLatin                 // 拉丁字母
+)
+
+// Segment 代表一段单一语言的文本
+type Segment struct {
+	Text string // 文本内容
+	Lang string // GPT-SoVITS 语言参数
+}
+
+// classify 判断字符所属的书写系统
+func classify(r rune) script {
+	switch {
+	case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+		return scriptKana
+	case unicode.Is(unicode.Hangul, r):
+		return scriptHangul
+	case unicode.Is(unicode.Han, r):
+		return scriptHan
+	case unicode.Is(unicode.Latin, r):
+		return scriptLatin
+	default:
+		return scriptNeutral
+	}
+}
+
+// scan 统计文本中出现的书写系统
+func scan(text string) map[script]bool {
+	found := make(map[script]bool)
+	for _, r := range text {
+		if s := classify(r); s != scriptNeutral {
+			found[s] = true
+		}
+	}
+	return found
+}
+
+// Detect 根据文本内容推断 GPT-SoVITS 语言参数
+// 单一语种返回对应的纯语种参数，与英文混合时返回混合参数，其余多语种情况返回 Auto
+func Detect(text string) string {
+	found := scan(text)
+
+	// 含假名的汉字按日文处理
+	if found[scriptKana] {
+		found[scriptHan] = false
+	}
+
+	cjk := 0
+	for _, s := range []script{scriptHan, scriptKana, scriptHangul} {
+		if found[s] {
+			cjk++
+		}
+	}
+
+	switch {
+	case cjk == 0 && found[scriptLatin]:
+		return English
+	case cjk == 0:
+		return Auto
+	case cjk > 1:
+		return Auto
+	}
+
+	latin := found[scriptLatin]
+	switch {
+	case found[scriptHan] && latin:
+		return MixedChinese
+	case found[scriptHan]:
+		return Chinese
+	case found[scriptKana] && latin:
+		return MixedJapanese
+	case found[scriptKana]:
+		return Japanese
+	case found[scriptHangul] && latin:
+		return MixedKorean
+	default:
+		return Korean
+	}
+}
+
+// DetectCantonese 与 Detect 相同，但将中文结果映射为对应的粤语参数
+func DetectCantonese(text string) string {
+	switch v := Detect(text); v {
+	case Chinese:
+		return Cantonese
+	case MixedChinese:
+		return MixedCantonese
+	case Auto:
+		return AutoCantonese
+	default:
+		return v
+	}
+}
+
+// sentenceEnds 为句末标点，判断汉字是否属于日文时不跨越句子
+const sentenceEnds = "。！？!?\n"
+
+// run 代表同一书写系统的一段连续字符
+type run struct {
+	script     script
+	start, end int // 在原文本中的字节范围
+}
+
+// runs 将文本按书写系统拆分为连续的字符段
+func runs(text string) []run {
+	var out []run
+	for i, r := range text {
+		s := classify(r)
+		if n := len(out); n > 0 && out[n-1].script == s {
+			out[n-1].end = i + utf8.RuneLen(r)
+			continue
+		}
+		out = append(out, run{script: s, start: i, end: i + utf8.RuneLen(r)})
+	}
+	return out
+}
+
+// nearKana 判断第 i 段汉字在同一句中前后最近的非中性字符段是否为假名
+func nearKana(text string, rs []run, i int) bool {
+	for _, step := range []int{-1, 1} {
+		for j := i + step; j >= 0 && j < len(rs); j += step {
+			if rs[j].script != scriptNeutral {
+				if rs[j].script == scriptKana {
+					return true
+				}
+				break
+			}
+			if strings.ContainsAny(text[rs[j].start:rs[j].end], sentenceEnds) {
+				break
+			}
+		}
+	}
+	return false
+}
+
+// Split 将混合语言文本按书写系统拆分为若干单一语言片段
+// 数字与标点归入相邻片段，同一句中与假名相邻的汉字按日文处理
+func Split(text string) []Segment {
+	rs := runs(text)
+
+	langOf := func(i int) string {
+		switch rs[i].script {
+		case scriptHan:
+			if nearKana(text, rs, i) {
+				return Japanese
+			}
+			return Chinese
+		case scriptKana:
+			return Japanese
+		case scriptHangul:
+			return Korean
+		case scriptLatin:
+			return English
+		default:
+			return ""
+		}
+	}
+
+	var segments []Segment
+	var buf strings.Builder
+	current := ""
+
+	for i, r := range rs {
+		l := langOf(i)
+		if l != "" && current != "" && l != current {
+			segments = append(segments, Segment{Text: buf.String(), Lang: current})
+			buf.Reset()
+		}
+		if l != "" {
+			current = l
+		}
+		buf.WriteString(text[r.start:r.end])
+	}
+
+	if buf.Len() > 0 {
+		if current == "" {
+			current = Auto
+		}
+		segments = append(segments, Segment{Text: buf.String(), Lang: current})
+	}
+
+	// 去除片段首尾空白，并丢弃空片段
+	result := segments[:0]
+	for _, seg := range segments {
+		seg.Text = strings.TrimSpace(seg.Text)
+		if seg.Text != "" {
+			result = append(result, seg)
+		}
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+// Package lang 提供常见语言代码到 GPT-SoVITS 语言参数的规范化与校验
+package lang
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GPT-SoVITS 接受的语言参数
+const (
+	Chinese        = "all_zh"   // 纯中文
+	English        = "en"       // 英文
+	Japanese       = "all_ja"   // 纯日文
+	Cantonese      = "all_yue"  // 纯粤语
+	Korean         = "all_ko"   // 纯韩文
+	MixedChinese   = "zh"       // 中英混合
+	MixedJapanese  = "ja"       // 日英混合
+	MixedCantonese = "yue"      // 粤英混合
+	MixedKorean    = "ko"       // 韩英混合
+	Auto           = "auto"     // 多语种自动识别
+	AutoCantonese  = "auto_yue" // 多语种自动识别（粤语优先）
+)
+
+// native 为 GPT-SoVITS 原生支持的全部语言参数
+var native = map[string]bool{
+	Chinese:        true,
+	English:        true,
+	Japanese:       true,
+	Cantonese:      true,
+	Korean:         true,
+	MixedChinese:   true,
+	MixedJapanese:  true,
+	MixedCantonese: true,
+	MixedKorean:    true,
+	Auto:           true,
+	AutoCantonese:  true,
+}
+
+// aliases 为常见语言代码到原生语言参数的映射，键均为小写且使用 "-" 分隔
+// 与原生参数同名的代码（如 "zh"、"ja"）不在此列，按原生参数原样返回
+var aliases = map[string]string{
+	"zh-cn":     Chinese,
+	"zh-sg":     Chinese,
+	"zh-tw":     Chinese,
+	"zh-hans":   Chinese,
+	"zh-hant":   Chinese,
+	"cmn":       Chinese,
+	"chinese":   Chinese,
+	"en-us":     English,
+	"en-gb":     English,
+	"en-au":     English,
+	"english":   English,
+	"ja-jp":     Japanese,
+	"jp":        Japanese,
+	"japanese":  Japanese,
+	"zh-hk":     Cantonese,
+	"zh-mo":     Cantonese,
+	"cantonese": Cantonese,
+	"ko-kr":     Korean,
+	"korean":    Korean,
+	"zh-en":     MixedChinese,
+	"ja-en":     MixedJapanese,
+	"yue-en":    MixedCantonese,
+	"ko-en":     MixedKorean,
+}
+
+// UnsupportedError 表示请求了不受支持的语言
+type UnsupportedError struct {
+	Code string // 原始语言代码
+}
+
+// Error 实现 error 接口
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("不支持的语言 %q，可用的语言代码: %s", e.Code, strings.Join(Supported(), ", "))
+}
+
+// Normalize 将常见语言代码（如 "en-US"、"zh-CN"、"ja-JP"）转换为 GPT-SoVITS 所需的语言参数
+// 已是原生参数的值（如 "zh"、"all_zh"、"ja"）原样返回，其中 "zh"、"ja"、"yue"、"ko" 为与英文的混合模式；
+// 需要纯语种时请使用 "zh-CN" 等地区代码或直接使用 Chinese 等常量
+func Normalize(code string) (string, error) {
+	key := strings.ToLower(strings.TrimSpace(code))
+
+	// 已是原生参数时原样返回
+	if v := strings.ReplaceAll(key, "-", "_"); native[v] {
+		return v, nil
+	}
+
+	if v, ok := aliases[strings.ReplaceAll(key, "_", "-")]; ok {
+		return v, nil
+	}
+
+	return "", &UnsupportedError{Code: code}
+}
+
+// MustNormalize 与 Normalize 相同，但在语言不受支持时 panic
+func MustNormalize(code string) string {
+	v, err := Normalize(code)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Validate 校验给定值是否为 GPT-SoVITS 原生支持的语言参数
+func Validate(value string) error {
+	if !native[value] {
+		return &UnsupportedError{Code: value}
+	}
+	return nil
+}
+
+// IsSupported 判断语言代码是否可以被规范化
+func IsSupported(code string) bool {
+	_, err := Normalize(code)
+	return err == nil
+}
+
+// Supported 返回全部可识别的语言代码（别名与原生参数），按字母序排列
+func Supported() []string {
+	seen := make(map[string]bool)
+	for k := range aliases {
+		seen[k] = true
+	}
+	for k := range native {
+		seen[k] = true
+	}
+
+	codes := make([]string, 0, len(seen))
+	for k := range seen {
+		codes = append(codes, k)
+	}
+	sort.Strings(codes)
+	return codes
+}
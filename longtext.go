@@ -0,0 +1,162 @@
+package gpt_sovits_go_sdk
+
+// 提供长文本的分块合成与拼接
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/audio"
+)
+
+// LongTextOptions 代表长文本合成选项
+type LongTextOptions struct {
+	Splitter *TextSplitter      // 文本切分器，为空时使用默认切分器
+	Gap      time.Duration      // 相邻块之间插入的静音时长
+	Trim     *audio.TrimOptions // 非 nil 时在拼接前裁剪每块首尾的静音
+
+	// Crossfade 为相邻块的交叉淡化时长，为0时直接拼接
+	Crossfade time.Duration
+
+	// Voice 非 nil 时按音色的轮换策略为每块选择参考音频
+	Voice *Voice
+
+	// MinChunkChars 为截止时间临近时块可缩小到的最小字符数，<=0 时为 10
+	MinChunkChars int
+	// DeadlineMargin 为截止时间前预留给拼接等收尾工作的时间
+	DeadlineMargin time.Duration
+}
+
+// Chunk 代表长文本中的一块及其在拼接音频中的时间位置
+type Chunk struct {
+	Index        int           // 块序号
+	Text         string        // 块文本
+	Start        time.Duration // 起始时间
+	End          time.Duration // 结束时间
+	RefAudioPath string        // 该块使用的参考音频，为空时表示使用客户端默认参数
+	Timing       *Timing       // 块的词级时间戳，相对于拼接音频；服务器未返回对齐信息时为估计值
+}
+
+// LongTextResult 代表长文本合成结果
+type LongTextResult struct {
+	Audio  []byte  // 拼接后的 WAV 音频
+	Chunks []Chunk // 每块的时间位置
+}
+
+// TTSLong 将长文本切分为若干块依次合成，并拼接为一条 WAV 音轨
+//
+// ctx 设置了截止时间时按近期的实时率统计估计每块的合成耗时：剩余时间不足以合成下一块时将其缩小，
+// 缩小至 MinChunkChars 仍来不及时提前结束，返回已完成部分的拼接结果与 *DeadlineError；
+// 尚未完成任何块时不返回结果，调用方可据此快速失败
+func (c *Client) TTSLong(ctx context.Context, req TTSRequest, opts *LongTextOptions) (*LongTextResult, error) {
+	if opts == nil {
+		opts = &LongTextOptions{}
+	}
+
+	texts := opts.Splitter.Split(req.Text)
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("待合成文本为空")
+	}
+
+	minChars := opts.MinChunkChars
+	if minChars <= 0 {
+		minChars = defaultMinChunkChars
+	}
+	deadline, hasDeadline := ctx.Deadline()
+
+	var (
+		segments []*audio.WAV
+		chunks   []Chunk
+	)
+
+	// 在截止时间前结束，返回已完成的部分
+	stop := func(estimated time.Duration) (*LongTextResult, error) {
+		deadlineErr := &DeadlineError{Done: len(chunks), Left: time.Until(deadline), Estimated: estimated}
+		for _, text := range texts {
+			deadlineErr.Remaining += utf8.RuneCountInString(text)
+		}
+		if len(segments) == 0 {
+			return nil, deadlineErr
+		}
+		result, err := stitchLong(segments, chunks, opts)
+		if err != nil {
+			return nil, err
+		}
+		return result, deadlineErr
+	}
+
+	for len(texts) > 0 {
+		i := len(chunks)
+
+		// 预计来不及合成时缩小当前块，剩余部分放回队列
+		if perChar, ok := c.rtf.perChar(); hasDeadline && ok && perChar > 0 {
+			fit := int((time.Until(deadline) - opts.DeadlineMargin) / perChar)
+			if fit < utf8.RuneCountInString(texts[0]) {
+				if fit < minChars {
+					return stop(perChar * time.Duration(minChars))
+				}
+				pieces := (&TextSplitter{MaxChars: fit}).Split(texts[0])
+				texts = append(pieces, texts[1:]...)
+			}
+		}
+		text := texts[0]
+
+		// 拼接要求输出为 WAV
+		chunkReq := req
+		chunkReq.Text = text
+		if opts.Voice != nil {
+			opts.Voice.ApplyRef(&chunkReq, i)
+		}
+		chunkReq.MediaType = MediaTypeWAV
+		chunkReq.StreamingMode = false
+
+		resp, err := c.TTS(ctx, chunkReq)
+		if err == nil {
+			err = resp.Err()
+		}
+		if err != nil {
+			// 截止时间已到时交付已完成的部分
+			if hasDeadline && errors.Is(err, context.DeadlineExceeded) {
+				return stop(0)
+			}
+			return nil, fmt.Errorf("第 %d 块: %w", i+1, err)
+		}
+
+		seg, err := audio.ParseWAV(resp.AudioData)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 块: %w", i+1, err)
+		}
+		if opts.Trim != nil {
+			if seg, err = audio.Trim(seg, *opts.Trim); err != nil {
+				return nil, fmt.Errorf("第 %d 块: %w", i+1, err)
+			}
+		}
+
+		texts = texts[1:]
+		chunks = append(chunks, Chunk{Index: i, Text: text, RefAudioPath: chunkReq.RefAudioPath, Timing: resp.Timing})
+		segments = append(segments, seg)
+	}
+
+	return stitchLong(segments, chunks, opts)
+}
+
+// stitchLong 拼接各块音频并记录每块在拼接音频中的时间位置
+func stitchLong(segments []*audio.WAV, chunks []Chunk, opts *LongTextOptions) (*LongTextResult, error) {
+	stitched, spans, err := audio.ConcatWithSpans(segments, audio.ConcatOptions{Gap: opts.Gap, Crossfade: opts.Crossfade})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, span := range spans {
+		chunks[i].Start, chunks[i].End = span.Start, span.End
+		chunks[i].Timing = chunkTiming(chunks[i], opts.Trim != nil)
+	}
+
+	return &LongTextResult{
+		Audio:  stitched.Bytes(),
+		Chunks: chunks,
+	}, nil
+}
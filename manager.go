@@ -0,0 +1,132 @@
+package gpt_sovits_go_sdk
+
+// 提供多租户客户端管理，每个租户对应独立的 GPT-SoVITS 部署
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// ErrTenantNotFound 表示租户未注册
+var ErrTenantNotFound = errors.New("租户未注册")
+
+// TenantConfig 代表单个租户的客户端配置
+type TenantConfig struct {
+	BaseURL   string         // 该租户的 API 基础URL
+	Defaults  TTSRequest     // 该租户的默认 TTS 参数
+	RateLimit float64        // 每秒最多请求数，<=0 表示不限制
+	Burst     int            // 允许的突发请求数
+	Options   []ClientOption // 该租户额外的客户端可选项，在管理器的公共可选项之后应用，含连接相关可选项时该租户使用独立的连接池
+}
+
+// ClientManager 代表按名称管理多个租户客户端的管理器，并发安全
+// 客户端在首次获取时创建，未单独设置连接相关可选项的客户端共享同一个连接池
+type ClientManager struct {
+	opts      []ClientOption
+	transport *http.Transport
+
+	mu      sync.Mutex
+	tenants map[string]TenantConfig
+	clients map[string]*Client
+}
+
+// NewClientManager 创建一个新的客户端管理器，opts 应用于每个租户的客户端
+// 连接相关的可选项（如 WithMaxIdleConnsPerHost、WithTLSConfig）作用于共享的连接池
+func NewClientManager(opts ...ClientOption) *ClientManager {
+	// 以原型客户端配置共享的传输层
+	proto := NewClient("", opts...)
+	return &ClientManager{
+		opts:      opts,
+		transport: proto.transport,
+		tenants:   make(map[string]TenantConfig),
+		clients:   make(map[string]*Client),
+	}
+}
+
+// Register 注册或替换租户配置，替换时已创建的客户端在下次获取时按新配置重建
+func (m *ClientManager) Register(name string, cfg TenantConfig) error {
+	if cfg.BaseURL == "" {
+		return fmt.Errorf("租户 %s 未设置基础URL", name)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tenants[name] = cfg
+	delete(m.clients, name)
+	return nil
+}
+
+// Get 返回租户的客户端，首次获取时创建
+func (m *ClientManager) Get(name string) (*Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.clients[name]; ok {
+		return c, nil
+	}
+	cfg, ok := m.tenants[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTenantNotFound, name)
+	}
+
+	c := m.newClient(name, cfg)
+	m.clients[name] = c
+	return c, nil
+}
+
+// newClient 按租户配置创建使用共享传输层的客户端
+func (m *ClientManager) newClient(name string, cfg TenantConfig) *Client {
+	opts := append(slices.Clone(m.opts), WithTenant(name))
+	// 公共可选项已作用于共享的传输层，只记录租户可选项对传输层的调整
+	opts = append(opts, func(c *Client) {
+		c.transportSet = false
+	})
+	// 租户默认参数优先，未设置的字段沿用公共默认参数
+	opts = append(opts, func(c *Client) {
+		c.SetDefaults(c.applyDefaults(cfg.Defaults))
+	})
+	if cfg.RateLimit > 0 {
+		opts = append(opts, WithRateLimit(cfg.RateLimit, cfg.Burst))
+	}
+	opts = append(opts, cfg.Options...)
+
+	c := NewClient(cfg.BaseURL, opts...)
+
+	// 未替换 HTTP 客户端且租户未调整传输层时改用共享的传输层，否则保留租户独立的连接池
+	if !c.transportSet && c.HTTPClient.Transport == http.RoundTripper(c.transport) {
+		c.transport = m.transport
+		c.HTTPClient.Transport = m.transport
+	}
+	return c
+}
+
+// Remove 注销租户，已取得的客户端仍可继续使用
+func (m *ClientManager) Remove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.tenants, name)
+	delete(m.clients, name)
+}
+
+// Names 返回已注册的租户名称，按字母顺序排列
+func (m *ClientManager) Names() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.tenants))
+	for name := range m.tenants {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// CloseIdleConnections 关闭共享连接池中的空闲连接
+func (m *ClientManager) CloseIdleConnections() {
+	m.transport.CloseIdleConnections()
+}
@@ -0,0 +1,115 @@
+package gpt_sovits_go_sdk
+
+// 提供输出媒体类型的常量、校验与格式检测
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+)
+
+// MediaType 代表输出音频媒体类型
+type MediaType string
+
+// 服务器支持的输出媒体类型
+const (
+	MediaTypeWAV MediaType = "wav" // 带文件头的 WAV
+	MediaTypeOGG MediaType = "ogg" // OGG 容器
+	MediaTypeAAC MediaType = "aac" // ADTS 封装的 AAC
+	MediaTypeRAW MediaType = "raw" // 无文件头的 16 位 PCM
+)
+
+// defaultRawSampleRate 为 raw 格式的默认采样率
+const defaultRawSampleRate = 32000
+
+// Validate 校验媒体类型是否受服务器支持
+func (m MediaType) Validate() error {
+	switch m {
+	case MediaTypeWAV, MediaTypeOGG, MediaTypeAAC, MediaTypeRAW:
+		return nil
+	default:
+		return fmt.Errorf("不支持的媒体类型 %q，支持 wav、ogg、aac、raw", string(m))
+	}
+}
+
+// ContentType 返回媒体类型对应的 MIME 类型
+func (m MediaType) ContentType() string {
+	switch m {
+	case MediaTypeWAV:
+		return "audio/wav"
+	case MediaTypeOGG:
+		return "audio/ogg"
+	case MediaTypeAAC:
+		return "audio/aac"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// DetectMediaType 根据音频数据的文件头检测格式，无法识别时视为 raw
+func DetectMediaType(data []byte) MediaType {
+	switch {
+	case len(data) >= 12 && bytes.Equal(data[0:4], []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WAVE")):
+		return MediaTypeWAV
+	case len(data) >= 4 && bytes.Equal(data[0:4], []byte("OggS")):
+		return MediaTypeOGG
+	case len(data) >= 2 && data[0] == 0xFF && data[1]&0xF6 == 0xF0:
+		return MediaTypeAAC
+	default:
+		return MediaTypeRAW
+	}
+}
+
+// fillMediaInfo 根据HTTP响应与音频数据填充 TTSResponse 中的格式与对齐信息
+func (c *Client) fillMediaInfo(ttsResp *TTSResponse, resp *http.Response) {
+	ttsResp.ContentType = resp.Header.Get("Content-Type")
+
+	// 仅对成功响应检测音频格式
+	if resp.StatusCode != http.StatusOK {
+		return
+	}
+
+	// 部分服务器分支以 multipart 或 JSON 同时返回音频与对齐信息
+	var err error
+	if ttsResp.AudioData, ttsResp.ContentType, ttsResp.Timing, err = extractTiming(ttsResp.AudioData, ttsResp.ContentType); err != nil {
+		ttsResp.Error = err
+		return
+	}
+	c.fillAudioInfo(ttsResp)
+}
+
+// fillAudioInfo 根据音频数据检测格式、采样率与声道数
+func (c *Client) fillAudioInfo(ttsResp *TTSResponse) {
+	if len(ttsResp.AudioData) == 0 {
+		return
+	}
+
+	ttsResp.Format = DetectMediaType(ttsResp.AudioData)
+	switch ttsResp.Format {
+	case MediaTypeWAV:
+		ttsResp.SampleRate, ttsResp.Channels = parseWAVFormat(ttsResp.AudioData)
+	case MediaTypeRAW:
+		ttsResp.SampleRate = c.RawSampleRate
+		if ttsResp.SampleRate <= 0 {
+			ttsResp.SampleRate = defaultRawSampleRate
+		}
+		ttsResp.Channels = 1
+	}
+}
+
+// parseWAVFormat 从 WAV 文件头的 fmt 块中解析采样率与声道数
+func parseWAVFormat(data []byte) (sampleRate, channels int) {
+	// 跳过 RIFF 头，逐块查找 fmt 块
+	for off := 12; off+8 <= len(data); {
+		id := string(data[off : off+4])
+		size := int(binary.LittleEndian.Uint32(data[off+4 : off+8]))
+		if id == "fmt " && off+8+16 <= len(data) {
+			channels = int(binary.LittleEndian.Uint16(data[off+10 : off+12]))
+			sampleRate = int(binary.LittleEndian.Uint32(data[off+12 : off+16]))
+			return sampleRate, channels
+		}
+		off += 8 + size + size%2
+	}
+	return 0, 0
+}
@@ -0,0 +1,53 @@
+package gpt_sovits_go_sdk
+
+// 提供请求/响应拦截器（中间件）链
+
+import (
+	"net/http"
+)
+
+// RoundTripFunc 代表一次 HTTP 往返调用
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware 代表请求/响应拦截器，包装下一个 RoundTripFunc
+// 可用于注入自定义请求头、请求签名、日志记录或故障注入
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Use 为客户端注册中间件，先注册的中间件位于调用链最外层
+// 应在发起请求前完成注册，Use 本身不是并发安全的
+func (c *Client) Use(mws ...Middleware) {
+	c.middlewares = append(c.middlewares, mws...)
+}
+
+// do 经过中间件链发送 HTTP 请求
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	next := c.roundTrip()
+	if c.faults != nil {
+		next = c.faults.Middleware()(next)
+	}
+
+	// 逆序包装，使先注册的中间件最先执行
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		next = c.middlewares[i](next)
+	}
+
+	return next(req)
+}
+
+// roundTrip 返回位于中间件链最内层的传输调用
+func (c *Client) roundTrip() RoundTripFunc {
+	// 优先使用自定义传输层
+	if c.Transport != nil {
+		return c.compress(c.Transport.Do)
+	}
+
+	// 设置了单次调用超时时，以上下文截止时间取代客户端的总超时
+	return c.compress(func(req *http.Request) (*http.Response, error) {
+		if hasCallTimeout(req.Context()) && c.HTTPClient.Timeout > 0 {
+			hc := *c.HTTPClient
+			hc.Timeout = 0
+			return hc.Do(req)
+		}
+		return c.HTTPClient.Do(req)
+	})
+}
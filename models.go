@@ -0,0 +1,152 @@
+package gpt_sovits_go_sdk
+
+// 提供模型权重管理，切换模型前排空进行中的合成请求
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrDrainTimeout 表示在超时前未能等到进行中的请求完成
+var ErrDrainTimeout = errors.New("等待进行中的请求完成超时")
+
+// defaultDrainTimeout 为默认的排空超时时间
+const defaultDrainTimeout = 2 * time.Minute
+
+// ModelManager 代表客户端的模型权重管理器，切换权重前等待进行中的合成请求完成
+type ModelManager struct {
+	DrainTimeout time.Duration // 等待进行中请求完成的最长时间，<=0 时为 2 分钟
+	Warmup       bool          // 切换成功后使用客户端默认参数发送一次预热调用，预热失败不影响切换结果
+
+	client *Client
+	mu     sync.Mutex
+	inUse  sync.RWMutex // 会话合成期间持有读锁，切换权重时持有写锁
+	gpt    string       // 当前 GPT 权重路径
+	sovits string       // 当前 SoVITS 权重路径
+}
+
+// NewModelManager 创建一个新的模型权重管理器
+func NewModelManager(client *Client) *ModelManager {
+	return &ModelManager{client: client}
+}
+
+// Current 返回通过管理器最近一次设置成功的权重路径
+func (m *ModelManager) Current() (gpt, sovits string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.gpt, m.sovits
+}
+
+// SetGPTWeights 排空进行中的请求后更新 GPT 模型权重
+func (m *ModelManager) SetGPTWeights(ctx context.Context, weightsPath string) error {
+	return m.SetWeights(ctx, weightsPath, "")
+}
+
+// SetSoVITSWeights 排空进行中的请求后更新 SoVITS 模型权重
+func (m *ModelManager) SetSoVITSWeights(ctx context.Context, weightsPath string) error {
+	return m.SetWeights(ctx, "", weightsPath)
+}
+
+// SetWeights 排空进行中的请求后依次更新 GPT 与 SoVITS 权重，路径为空的一项不做更新
+// 切换期间新的合成请求会被阻塞，直至切换完成
+func (m *ModelManager) SetWeights(ctx context.Context, gpt, sovits string) error {
+	m.inUse.Lock()
+	err := m.setWeights(ctx, gpt, sovits)
+	m.inUse.Unlock()
+	if err != nil {
+		return err
+	}
+	if m.Warmup {
+		_, _ = m.client.Warmup(ctx, nil)
+	}
+	return nil
+}
+
+// setWeights 排空进行中的请求后更新权重
+func (m *ModelManager) setWeights(ctx context.Context, gpt, sovits string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	timeout := m.DrainTimeout
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+
+	// 排空进行中的请求
+	drainCtx, cancel := context.WithTimeout(ctx, timeout)
+	release, err := m.client.gate.drain(drainCtx)
+	cancel()
+	if err != nil {
+		if ctx.Err() == nil {
+			return fmt.Errorf("%w: 仍有 %d 个请求进行中", ErrDrainTimeout, m.client.InFlight())
+		}
+		return err
+	}
+	defer release()
+
+	// api.py 可在一次请求中同时切换两个权重
+	if m.client.apiVersion == APIV1 && gpt != "" && sovits != "" {
+		if err := m.client.setModelV1(ctx, gpt, sovits); err != nil {
+			return err
+		}
+		m.gpt, m.sovits = gpt, sovits
+		return nil
+	}
+
+	if gpt != "" {
+		if err := m.client.SetGPTWeights(ctx, gpt); err != nil {
+			return err
+		}
+		m.gpt = gpt
+	}
+	if sovits != "" {
+		if err := m.client.SetSoVITSWeights(ctx, sovits); err != nil {
+			return err
+		}
+		m.sovits = sovits
+	}
+	return nil
+}
+
+// acquire 确保给定权重处于激活状态并阻止其他切换，直至调用返回的 release，路径为空的一项不做要求
+func (m *ModelManager) acquire(ctx context.Context, gpt, sovits string) (release func(), err error) {
+	for {
+		m.inUse.RLock()
+		cur, curSoVITS := m.Current()
+		if (gpt == "" || gpt == cur) && (sovits == "" || sovits == curSoVITS) {
+			return m.inUse.RUnlock, nil
+		}
+		m.inUse.RUnlock()
+
+		// 仅切换与当前不一致的一项，切换完成后重新检查
+		m.inUse.Lock()
+		cur, curSoVITS = m.Current()
+		switchGPT, switchSoVITS := gpt, sovits
+		if switchGPT == cur {
+			switchGPT = ""
+		}
+		if switchSoVITS == curSoVITS {
+			switchSoVITS = ""
+		}
+		err := m.setWeights(ctx, switchGPT, switchSoVITS)
+		m.inUse.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		if m.Warmup {
+			_, _ = m.client.Warmup(ctx, nil)
+		}
+	}
+}
+
+// Models 返回客户端共享的模型权重管理器，会话通过它协调权重切换
+func (c *Client) Models() *ModelManager {
+	c.modelsOnce.Do(func() {
+		c.models = NewModelManager(c)
+	})
+	return c.models
+}
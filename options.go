@@ -0,0 +1,258 @@
+package gpt_sovits_go_sdk
+
+// 提供客户端与单次调用的可选配置
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/textproc"
+)
+
+// ClientOption 代表创建客户端时的可选项
+type ClientOption func(*Client)
+
+// WithTimeout 设置客户端的总超时时间，包括连接、等待响应与读取响应体
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.HTTPClient.Timeout = d
+	}
+}
+
+// WithConnectTimeout 设置建立TCP连接的超时时间
+func WithConnectTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.httpTransport().DialContext = (&net.Dialer{
+			Timeout:   d,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+}
+
+// WithDialContext 设置建立连接所用的拨号函数，会覆盖 WithConnectTimeout 的设置
+func WithDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) ClientOption {
+	return func(c *Client) {
+		c.httpTransport().DialContext = dial
+	}
+}
+
+// WithUnixSocket 通过 Unix 域套接字连接同一主机上的 API 服务器
+// BaseURL 仍需为 http 地址（如 http://localhost:9880），其中的主机与端口仅用于构造请求
+func WithUnixSocket(path string) ClientOption {
+	return func(c *Client) {
+		dialer := &net.Dialer{}
+		c.httpTransport().DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", path)
+		}
+	}
+}
+
+// WithResponseHeaderTimeout 设置发送请求后等待响应头的超时时间
+// 非流式合成时服务器在推理完成后才返回响应头，应按最长文本的推理耗时设置
+func WithResponseHeaderTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.httpTransport().ResponseHeaderTimeout = d
+	}
+}
+
+// WithMaxIdleConns 设置所有主机的最大空闲连接数，0 表示不限制
+func WithMaxIdleConns(n int) ClientOption {
+	return func(c *Client) {
+		c.httpTransport().MaxIdleConns = n
+	}
+}
+
+// WithMaxIdleConnsPerHost 设置每个主机保留的最大空闲连接数
+// 标准库默认值为 2，大量并发短请求时应调大以避免频繁建立连接
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return func(c *Client) {
+		c.httpTransport().MaxIdleConnsPerHost = n
+	}
+}
+
+// WithMaxConnsPerHost 设置每个主机的最大连接数（含使用中的连接），0 表示不限制
+func WithMaxConnsPerHost(n int) ClientOption {
+	return func(c *Client) {
+		c.httpTransport().MaxConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout 设置空闲连接的保留时间
+func WithIdleConnTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.httpTransport().IdleConnTimeout = d
+	}
+}
+
+// WithHTTP2 强制使用 HTTP/2，http 地址使用明文 HTTP/2（h2c），要求服务器支持
+func WithHTTP2() ClientOption {
+	return func(c *Client) {
+		var p http.Protocols
+		p.SetHTTP2(true)
+		p.SetUnencryptedHTTP2(true)
+		c.httpTransport().Protocols = &p
+		c.httpTransport().ForceAttemptHTTP2 = true
+	}
+}
+
+// WithHTTP1 强制使用 HTTP/1.1，禁用 HTTP/2 协商
+func WithHTTP1() ClientOption {
+	return func(c *Client) {
+		var p http.Protocols
+		p.SetHTTP1(true)
+		c.httpTransport().Protocols = &p
+		c.httpTransport().ForceAttemptHTTP2 = false
+	}
+}
+
+// WithProxy 通过指定代理发送请求，支持 http、https 与 socks5 代理（如 SSH 动态端口转发）
+// 代理地址无效时在发送请求时返回错误
+func WithProxy(proxyURL string) ClientOption {
+	return func(c *Client) {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			c.httpTransport().Proxy = func(*http.Request) (*url.URL, error) {
+				return nil, fmt.Errorf("代理地址无效: %w", err)
+			}
+			return
+		}
+		c.httpTransport().Proxy = http.ProxyURL(u)
+	}
+}
+
+// WithProxyFromEnvironment 按 HTTP_PROXY、HTTPS_PROXY 与 NO_PROXY 环境变量选择代理
+// 这是默认行为，可用于覆盖之前设置的代理
+func WithProxyFromEnvironment() ClientOption {
+	return func(c *Client) {
+		c.httpTransport().Proxy = http.ProxyFromEnvironment
+	}
+}
+
+// WithTLSConfig 设置 HTTPS 连接使用的 TLS 配置
+func WithTLSConfig(cfg *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.httpTransport().TLSClientConfig = cfg.Clone()
+	}
+}
+
+// WithCACert 信任 PEM 编码的 CA 证书，用于私有 CA 签发的服务器证书，系统根证书仍然有效
+// 证书无效时在建立 HTTPS 连接时返回错误
+func WithCACert(pem []byte) ClientOption {
+	return func(c *Client) {
+		cfg := c.tlsConfig()
+
+		// 在系统根证书的基础上追加
+		pool := cfg.RootCAs
+		if pool == nil {
+			var err error
+			if pool, err = x509.SystemCertPool(); err != nil {
+				pool = x509.NewCertPool()
+			}
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			c.httpTransport().DialTLSContext = func(context.Context, string, string) (net.Conn, error) {
+				return nil, errors.New("CA 证书无效: 未能解析任何 PEM 证书")
+			}
+			return
+		}
+		cfg.RootCAs = pool
+	}
+}
+
+// WithInsecureSkipVerify 跳过服务器证书校验，仅应用于测试环境的自签名证书
+func WithInsecureSkipVerify() ClientOption {
+	return func(c *Client) {
+		c.tlsConfig().InsecureSkipVerify = true
+	}
+}
+
+// tlsConfig 返回可修改的 TLS 配置，未设置时创建
+func (c *Client) tlsConfig() *tls.Config {
+	t := c.httpTransport()
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{}
+	}
+	return t.TLSClientConfig
+}
+
+// httpTransport 返回供可选项调整的默认传输层，并记录传输层已被调整
+func (c *Client) httpTransport() *http.Transport {
+	c.transportSet = true
+	return c.transport
+}
+
+// WithTextProcessor 设置合成前对文本执行的预处理，如发音词典替换与数字规范化
+func WithTextProcessor(p textproc.Processor) ClientOption {
+	return func(c *Client) {
+		c.textProcessor = p
+	}
+}
+
+// CallOption 代表单次调用的可选项
+type CallOption func(*callOptions)
+
+// callOptions 代表单次调用的配置
+type callOptions struct {
+	progress          ProgressFunc      // 进度回调
+	timeout           time.Duration     // 单次调用超时
+	requestID         string            // 请求 ID
+	checksum          bool              // 是否计算音频校验和
+	headers           map[string]string // 自定义请求头
+	priority          Priority          // 异步请求优先级
+	usageVoice        string            // 用量记录中的音色名称
+	usageModel        string            // 用量记录中的模型
+	usageKey          string            // 用量记录中的调用方标识
+	noUsage           bool              // 不记录用量且不检查配额
+	skipAuthorization bool              // 跳过音色授权检查
+	noCache           bool              // 跳过缓存与请求合并
+	voice             *Voice            // 本次合成使用的音色，供授权检查
+	usageTag          string            // 本次合成声明的用途
+}
+
+// newCallOptions 合并调用可选项
+func newCallOptions(opts []CallOption) *callOptions {
+	o := &callOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithProgress 设置接收音频数据时的进度回调
+func WithProgress(fn ProgressFunc) CallOption {
+	return func(o *callOptions) {
+		o.progress = fn
+	}
+}
+
+// WithCallTimeout 设置单次调用的超时时间，取代客户端的总超时，适用于长文本合成
+func WithCallTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+// callTimeoutKey 为标记单次调用超时的上下文键
+type callTimeoutKey struct{}
+
+// withTimeout 在设置了单次调用超时时派生带截止时间的上下文
+func (o *callOptions) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.timeout <= 0 {
+		return ctx, func() {}
+	}
+	ctx = context.WithValue(ctx, callTimeoutKey{}, true)
+	return context.WithTimeout(ctx, o.timeout)
+}
+
+// hasCallTimeout 判断上下文是否设置了单次调用超时
+func hasCallTimeout(ctx context.Context) bool {
+	v, _ := ctx.Value(callTimeoutKey{}).(bool)
+	return v
+}
@@ -0,0 +1,34 @@
+package gpt_sovits_go_sdk
+
+// 提供流式合成中断时对已接收音频的保留
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPartial 表示合成在接收音频途中被中断（如上下文取消、连接断开），已接收的部分音频仍可使用
+var ErrPartial = errors.New("合成中断，仅收到部分音频")
+
+// PartialError 代表中断时已接收的音频，可用 errors.As 取得
+// errors.Is 对 ErrPartial 与中断原因（如 context.Canceled）均成立
+type PartialError struct {
+	Audio []byte // 已接收的音频数据，仅在缓冲完整音频的调用（如 TTS）中填充，流式写出的调用中为 nil
+	Bytes int64  // 已接收的字节数
+	Err   error  // 中断原因
+}
+
+// Error 实现 error 接口
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("合成中断，已接收 %d 字节: %v", e.Bytes, e.Err)
+}
+
+// Is 使 errors.Is(err, ErrPartial) 成立
+func (e *PartialError) Is(target error) bool {
+	return target == ErrPartial
+}
+
+// Unwrap 返回中断原因
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,245 @@
+package gpt_sovits_go_sdk
+
+// 提供多后端负载均衡客户端
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BalanceStrategy 代表负载均衡策略
+type BalanceStrategy int
+
+const (
+	// RoundRobin 轮询分发请求
+	RoundRobin BalanceStrategy = iota
+	// LeastPending 优先选择进行中请求最少的后端
+	LeastPending
+)
+
+// ErrNoBackend 表示连接池中没有可用的后端
+var ErrNoBackend = errors.New("没有可用的后端")
+
+// defaultHealthCheckInterval 为后台健康检查的默认间隔
+const defaultHealthCheckInterval = 10 * time.Second
+
+// poolBackend 代表连接池中的单个后端
+type poolBackend struct {
+	client  *Client
+	pending atomic.Int64 // 进行中的请求数
+	healthy atomic.Bool  // 是否健康
+}
+
+// PoolClient 代表多个 GPT-SoVITS 后端组成的负载均衡客户端
+type PoolClient struct {
+	Strategy   BalanceStrategy // 负载均衡策略
+	MaxRetries int             // 连接失败时最多尝试的后端数，<=0 表示尝试全部后端
+
+	backends []*poolBackend
+	counter  atomic.Uint64
+	stop     chan struct{}
+	stopOnce sync.Once
+	checks   sync.WaitGroup // 后台健康检查协程
+}
+
+// NewPoolClient 创建一个新的负载均衡客户端，每个基础URL对应一个后端
+func NewPoolClient(baseURLs []string, strategy BalanceStrategy) *PoolClient {
+	p := &PoolClient{
+		Strategy: strategy,
+		stop:     make(chan struct{}),
+	}
+
+	for _, baseURL := range baseURLs {
+		b := &poolBackend{client: NewClient(baseURL)}
+		b.healthy.Store(true)
+		p.backends = append(p.backends, b)
+	}
+
+	return p
+}
+
+// Clients 返回连接池中所有后端的客户端，可用于单独配置中间件等
+func (p *PoolClient) Clients() []*Client {
+	clients := make([]*Client, len(p.backends))
+	for i, b := range p.backends {
+		clients[i] = b.client
+	}
+	return clients
+}
+
+// Healthy 返回当前健康后端的基础URL
+func (p *PoolClient) Healthy() []string {
+	var urls []string
+	for _, b := range p.backends {
+		if b.healthy.Load() {
+			urls = append(urls, b.client.BaseURL)
+		}
+	}
+	return urls
+}
+
+// TTS 选择一个后端发送文本转语音请求，连接失败或熔断器拒绝时自动重试其他后端
+func (p *PoolClient) TTS(ctx context.Context, req TTSRequest, opts ...CallOption) (*TTSResponse, error) {
+	if len(p.backends) == 0 {
+		return &TTSResponse{Error: ErrNoBackend}, nil
+	}
+
+	// 计算最大尝试次数
+	attempts := len(p.backends)
+	if p.MaxRetries > 0 && p.MaxRetries < attempts {
+		attempts = p.MaxRetries
+	}
+
+	tried := make(map[*poolBackend]bool)
+	var lastResp *TTSResponse
+	for i := 0; i < attempts; i++ {
+		b := p.pick(tried)
+		if b == nil {
+			break
+		}
+		tried[b] = true
+
+		b.pending.Add(1)
+		resp, err := b.client.TTS(ctx, req, opts...)
+		b.pending.Add(-1)
+		if err != nil {
+			return resp, err
+		}
+
+		// 仅在请求未送达后端或被熔断器拒绝时重试，HTTP错误响应与请求校验、配额、内容过滤等本地错误直接返回
+		circuitOpen := errors.Is(resp.Error, ErrCircuitOpen)
+		if !circuitOpen && !errors.Is(resp.Error, ErrTransport) {
+			return resp, nil
+		}
+
+		// 上下文已取消时不再重试
+		if ctx.Err() != nil {
+			return resp, nil
+		}
+
+		// 连接失败时摘除该后端；熔断器已在冷却后自行探测，不改变健康状态
+		if !circuitOpen {
+			b.healthy.Store(false)
+		}
+		lastResp = resp
+	}
+
+	if lastResp == nil {
+		return &TTSResponse{Error: ErrNoBackend}, nil
+	}
+	return lastResp, nil
+}
+
+// pick 按策略选择一个未尝试过的后端，没有健康后端时退化为在全部后端中选择
+func (p *PoolClient) pick(tried map[*poolBackend]bool) *poolBackend {
+	var candidates []*poolBackend
+	for _, b := range p.backends {
+		if !tried[b] && b.healthy.Load() {
+			candidates = append(candidates, b)
+		}
+	}
+	if len(candidates) == 0 {
+		for _, b := range p.backends {
+			if !tried[b] {
+				candidates = append(candidates, b)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	switch p.Strategy {
+	case LeastPending:
+		best := candidates[0]
+		for _, b := range candidates[1:] {
+			if b.pending.Load() < best.pending.Load() {
+				best = b
+			}
+		}
+		return best
+	default:
+		n := p.counter.Add(1) - 1
+		return candidates[n%uint64(len(candidates))]
+	}
+}
+
+// CheckHealth 探测所有后端并更新其健康状态
+func (p *PoolClient) CheckHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, b := range p.backends {
+		wg.Add(1)
+		go func(b *poolBackend) {
+			defer wg.Done()
+			b.healthy.Store(b.client.Ping(ctx) == nil)
+		}(b)
+	}
+	wg.Wait()
+}
+
+// StartHealthCheck 启动后台健康检查，按给定间隔探测所有后端，interval <=0 时为 10 秒
+func (p *PoolClient) StartHealthCheck(interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	p.checks.Add(1)
+	go func() {
+		defer p.checks.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// Stop 时取消进行中的探测，使其尽快返回
+		stopCtx, stop := context.WithCancel(context.Background())
+		defer stop()
+		go func() {
+			<-p.stop
+			stop()
+		}()
+
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(stopCtx, interval)
+				p.CheckHealth(ctx)
+				cancel()
+			}
+		}
+	}()
+}
+
+// Stop 停止后台健康检查，取消进行中的探测并等待其返回
+func (p *PoolClient) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+	p.checks.Wait()
+}
+
+// Ping 检查服务器是否可达，收到任意HTTP响应即视为可达
+func (c *Client) Ping(ctx context.Context) error {
+	// 构建请求URL
+	url := fmt.Sprintf("%s/", c.BaseURL)
+
+	// 创建GET请求
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("创建探测请求失败: %w", err)
+	}
+
+	// 发送请求
+	resp, err := c.do(httpReq)
+	if err != nil {
+		return fmt.Errorf("服务器不可达: %w", err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package gpt_sovits_go_sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/audio"
+)
+
+// newTestBackend 启动一个以给定状态码响应 /tts 的测试服务器，并统计收到的请求数
+func newTestBackend(t *testing.T, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	hits := new(atomic.Int32)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"message":"backend error"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "audio/wav")
+		w.Write(audio.WrapPCMAsWAV(make([]byte, 320), 16000, 1, 16))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, hits
+}
+
+// deadURL 返回一个已关闭的服务器地址，连接时必然失败
+func deadURL() string {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	return srv.URL
+}
+
+// testRequest 返回一个可通过本地校验的请求
+func testRequest() TTSRequest {
+	return TTSRequest{Text: "你好", TextLang: "zh", RefAudioPath: "ref.wav", PromptText: "提示", PromptLang: "zh"}
+}
+
+func TestPoolClientFailover(t *testing.T) {
+	ok, okHits := newTestBackend(t, http.StatusOK)
+	failing, failingHits := newTestBackend(t, http.StatusInternalServerError)
+
+	tests := []struct {
+		name        string
+		urls        []string
+		req         func() TTSRequest
+		wantFail    bool  // 是否期望合成失败
+		wantErr     error // 非 nil 时期望 TTSResponse.Error 满足 errors.Is
+		wantHealthy int   // 调用后健康的后端数
+		wantOKHits  int32
+		wantFailing int32
+	}{
+		{
+			name:        "连接失败时切换到下一个后端并摘除失败后端",
+			urls:        []string{deadURL(), ok.URL},
+			req:         testRequest,
+			wantHealthy: 1,
+			wantOKHits:  1,
+		},
+		{
+			name:        "全部后端不可达时返回传输错误",
+			urls:        []string{deadURL(), deadURL()},
+			req:         testRequest,
+			wantFail:    true,
+			wantErr:     ErrTransport,
+			wantHealthy: 0,
+		},
+		{
+			name:        "HTTP 错误响应不重试且不影响健康状态",
+			urls:        []string{failing.URL, ok.URL},
+			req:         testRequest,
+			wantFail:    true,
+			wantErr:     ErrAPI,
+			wantHealthy: 2,
+			wantFailing: 1,
+		},
+		{
+			name: "本地校验错误不重试且不影响健康状态",
+			urls: []string{ok.URL, failing.URL},
+			req: func() TTSRequest {
+				req := testRequest()
+				req.MediaType = "bogus"
+				return req
+			},
+			wantFail:    true,
+			wantHealthy: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			okHits.Store(0)
+			failingHits.Store(0)
+
+			p := NewPoolClient(tt.urls, RoundRobin)
+			resp, err := p.TTS(context.Background(), tt.req())
+			if err != nil {
+				t.Fatalf("TTS 返回错误: %v", err)
+			}
+
+			if failed := resp.Err() != nil; failed != tt.wantFail {
+				t.Fatalf("合成错误 = %v，期望失败 = %v", resp.Err(), tt.wantFail)
+			}
+			if tt.wantErr != nil && !errors.Is(resp.Error, tt.wantErr) {
+				t.Fatalf("错误 = %v，期望 %v", resp.Error, tt.wantErr)
+			}
+
+			if got := len(p.Healthy()); got != tt.wantHealthy {
+				t.Errorf("健康后端数 = %d，期望 %d", got, tt.wantHealthy)
+			}
+			if got := okHits.Load(); got != tt.wantOKHits {
+				t.Errorf("正常后端请求数 = %d，期望 %d", got, tt.wantOKHits)
+			}
+			if got := failingHits.Load(); got != tt.wantFailing {
+				t.Errorf("失败后端请求数 = %d，期望 %d", got, tt.wantFailing)
+			}
+		})
+	}
+}
+
+func TestPoolClientCircuitOpen(t *testing.T) {
+	first, firstHits := newTestBackend(t, http.StatusOK)
+	second, secondHits := newTestBackend(t, http.StatusOK)
+
+	p := NewPoolClient([]string{first.URL, second.URL}, RoundRobin)
+	p.MaxRetries = 2
+	breaker := NewCircuitBreaker(1, time.Hour)
+	breaker.record(false)
+	p.Clients()[0].Use(breaker.Middleware())
+
+	// 熔断器拒绝时切换到下一个后端，且不摘除被熔断的后端
+	resp, err := p.TTS(context.Background(), testRequest())
+	if err != nil {
+		t.Fatalf("TTS 返回错误: %v", err)
+	}
+	if resp.Err() != nil {
+		t.Fatalf("合成错误 = %v，期望切换到下一个后端", resp.Err())
+	}
+	if firstHits.Load() != 0 || secondHits.Load() != 1 {
+		t.Fatalf("后端请求数 = %d, %d，期望 0, 1", firstHits.Load(), secondHits.Load())
+	}
+	if got := len(p.Healthy()); got != 2 {
+		t.Errorf("健康后端数 = %d，期望 2", got)
+	}
+
+	// 熔断器拒绝不属于传输错误
+	resp, _ = p.Clients()[0].TTS(context.Background(), testRequest())
+	if !errors.Is(resp.Error, ErrCircuitOpen) || errors.Is(resp.Error, ErrTransport) {
+		t.Fatalf("错误 = %v，期望 ErrCircuitOpen 且不是 ErrTransport", resp.Error)
+	}
+}
+
+func TestPoolClientMaxRetries(t *testing.T) {
+	ok, hits := newTestBackend(t, http.StatusOK)
+
+	p := NewPoolClient([]string{deadURL(), ok.URL}, RoundRobin)
+	p.MaxRetries = 1
+	resp, err := p.TTS(context.Background(), testRequest())
+	if err != nil {
+		t.Fatalf("TTS 返回错误: %v", err)
+	}
+	if !errors.Is(resp.Error, ErrTransport) {
+		t.Fatalf("错误 = %v，期望只尝试 1 个后端后返回传输错误", resp.Error)
+	}
+	if hits.Load() != 0 {
+		t.Fatal("超出 MaxRetries 的后端不应收到请求")
+	}
+}
@@ -0,0 +1,78 @@
+package gpt_sovits_go_sdk
+
+// 提供零样本/少样本模式与质量/速度预设
+
+// PresetZeroShot 返回零样本模式的请求模板：只提供参考音频而不提供提示文本
+// 无需转写参考音频，但音色相似度通常低于少样本模式
+func PresetZeroShot(refAudio string) TTSRequest {
+	return TTSRequest{
+		RefAudioPath: refAudio,
+		PromptLang:   "auto",
+		TextLang:     "auto",
+		MediaType:    MediaTypeWAV,
+	}
+}
+
+// PresetFewShot 返回少样本模式的请求模板：提供参考音频及其准确的提示文本
+// 提示文本应与参考音频内容逐字一致，这是获得较好克隆效果的推荐用法
+func PresetFewShot(refAudio, promptText, promptLang string) TTSRequest {
+	return TTSRequest{
+		RefAudioPath: refAudio,
+		PromptText:   promptText,
+		PromptLang:   promptLang,
+		TextLang:     "auto",
+		MediaType:    MediaTypeWAV,
+	}
+}
+
+// QualityPreset 代表推理质量与速度的预设档位
+type QualityPreset int
+
+const (
+	// PresetFast 优先速度：大批次并行推理、较少采样步数
+	PresetFast QualityPreset = iota
+	// PresetBalanced 兼顾速度与质量，接近服务器默认值
+	PresetBalanced
+	// PresetQuality 优先质量：逐句推理、更多采样步数并开启超采样
+	PresetQuality
+)
+
+// String 返回预设名称
+func (p QualityPreset) String() string {
+	switch p {
+	case PresetFast:
+		return "fast"
+	case PresetBalanced:
+		return "balanced"
+	case PresetQuality:
+		return "quality"
+	default:
+		return "unknown"
+	}
+}
+
+// ApplyQuality 按预设设置 batch_size、sample_steps、parallel_infer 与 super_sampling 等参数
+// sample_steps 与 super_sampling 仅对 V3 及以上模型生效，其他模型会忽略
+func (r *TTSRequest) ApplyQuality(p QualityPreset) {
+	switch p {
+	case PresetFast:
+		r.BatchSize = 20
+		r.ParallelInfer = Bool(true)
+		r.SplitBucket = Bool(true)
+		r.SampleSteps = 16
+		r.SuperSampling = false
+		r.TextSplitMethod = Cut5
+	case PresetBalanced:
+		r.BatchSize = 4
+		r.ParallelInfer = Bool(true)
+		r.SplitBucket = Bool(true)
+		r.SampleSteps = 32
+		r.SuperSampling = false
+	case PresetQuality:
+		r.BatchSize = 1
+		r.ParallelInfer = Bool(false)
+		r.SplitBucket = Bool(false)
+		r.SampleSteps = 64
+		r.SuperSampling = true
+	}
+}
@@ -0,0 +1,61 @@
+package gpt_sovits_go_sdk
+
+// 提供长时间合成任务的进度回调
+
+import (
+	"io"
+	"time"
+)
+
+// ProgressFunc 代表进度回调，参数为已接收的字节数与已耗时间
+type ProgressFunc func(bytesReceived int64, elapsed time.Duration)
+
+// progressWriter 在每次写入后触发进度回调
+type progressWriter struct {
+	w     io.Writer
+	fn    ProgressFunc
+	start time.Time
+	total int64
+}
+
+// Write 实现 io.Writer
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.total += int64(n)
+	p.fn(p.total, time.Since(p.start))
+	return n, err
+}
+
+// progressReader 在每次读取后触发进度回调
+type progressReader struct {
+	r     io.Reader
+	fn    ProgressFunc
+	start time.Time
+	total int64
+}
+
+// Read 实现 io.Reader
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.total += int64(n)
+		p.fn(p.total, time.Since(p.start))
+	}
+	return n, err
+}
+
+// wrapProgress 在设置了进度回调时包装响应体，start 为调用开始时间
+func wrapProgress(r io.Reader, fn ProgressFunc, start time.Time) io.Reader {
+	if fn == nil {
+		return r
+	}
+	return &progressReader{r: r, fn: fn, start: start}
+}
+
+// wrapProgressFrom 与 wrapProgress 相同，但已接收的字节数从 offset 开始计算，用于续传
+func wrapProgressFrom(r io.Reader, fn ProgressFunc, start time.Time, offset int64) io.Reader {
+	if fn == nil {
+		return r
+	}
+	return &progressReader{r: r, fn: fn, start: start, total: offset}
+}
@@ -0,0 +1,141 @@
+package gpt_sovits_go_sdk
+
+// 提供基于用量统计的客户端配额限制
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrQuotaExceeded 表示调用方已用尽配额，可用 errors.Is 判断
+var ErrQuotaExceeded = errors.New("配额已用尽")
+
+// QuotaPeriod 代表配额的统计周期
+type QuotaPeriod int
+
+const (
+	// QuotaDaily 按自然日（本地时间）统计
+	QuotaDaily QuotaPeriod = iota
+	// QuotaMonthly 按自然月（本地时间）统计
+	QuotaMonthly
+)
+
+// String 返回统计周期名称
+func (p QuotaPeriod) String() string {
+	switch p {
+	case QuotaDaily:
+		return "daily"
+	case QuotaMonthly:
+		return "monthly"
+	default:
+		return "unknown"
+	}
+}
+
+// start 返回 now 所在周期的起始时间
+func (p QuotaPeriod) start(now time.Time) time.Time {
+	y, m, d := now.Date()
+	if p == QuotaMonthly {
+		return time.Date(y, m, 1, 0, 0, 0, 0, now.Location())
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+}
+
+// Quota 代表一项配额，Tenant 与 Key 为空时匹配任意值，均为空时限制全部调用的合计
+type Quota struct {
+	Tenant          string      `json:"tenant,omitempty"`            // 适用的租户名称
+	Key             string      `json:"key,omitempty"`               // 适用的调用方标识，由 WithUsageKey 设置
+	Period          QuotaPeriod `json:"period"`                      // 统计周期
+	MaxCharacters   int         `json:"max_characters,omitempty"`    // 周期内最多合成的字符数，0 表示不限制
+	MaxAudioSeconds float64     `json:"max_audio_seconds,omitempty"` // 周期内最多生成的音频秒数，0 表示不限制
+}
+
+// matches 判断用量记录是否计入该配额
+func (q *Quota) matches(tenant, key string) bool {
+	return (q.Tenant == "" || q.Tenant == tenant) && (q.Key == "" || q.Key == key)
+}
+
+// QuotaExceededError 代表超出配额的详细信息
+type QuotaExceededError struct {
+	Quota        Quota   // 超出的配额
+	Characters   int     // 周期内已合成的字符数
+	AudioSeconds float64 // 周期内已生成的音频秒数
+	Requested    int     // 本次请求的字符数
+}
+
+// Error 实现 error 接口
+func (e *QuotaExceededError) Error() string {
+	who := "全部调用"
+	switch {
+	case e.Quota.Tenant != "" && e.Quota.Key != "":
+		who = e.Quota.Tenant + "/" + e.Quota.Key
+	case e.Quota.Tenant != "":
+		who = e.Quota.Tenant
+	case e.Quota.Key != "":
+		who = e.Quota.Key
+	}
+	if e.Quota.MaxCharacters > 0 && e.Characters+e.Requested > e.Quota.MaxCharacters {
+		return fmt.Sprintf("配额已用尽（%s，%s）: 已用 %d 字符，本次 %d 字符，上限 %d 字符",
+			who, e.Quota.Period, e.Characters, e.Requested, e.Quota.MaxCharacters)
+	}
+	return fmt.Sprintf("配额已用尽（%s，%s）: 已用 %.1f 秒音频，上限 %.1f 秒",
+		who, e.Quota.Period, e.AudioSeconds, e.Quota.MaxAudioSeconds)
+}
+
+// Is 使 errors.Is(err, ErrQuotaExceeded) 成立
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// WithQuotas 在发送请求前检查配额，超出时返回 QuotaExceededError 而不发送请求
+// 用量取自 WithUsageTracker 设置的统计器，未设置时配额不生效；并发请求可能使用量略微超出上限
+func WithQuotas(quotas ...Quota) ClientOption {
+	return func(c *Client) {
+		c.quotas = append(c.quotas, quotas...)
+	}
+}
+
+// WithUsageKey 设置本次调用的调用方标识（如 API 密钥或用户 ID），用于用量记录与配额匹配
+func WithUsageKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.usageKey = key
+	}
+}
+
+// checkQuota 检查本次请求是否超出配额
+func (c *Client) checkQuota(sent TTSRequest, o *callOptions) error {
+	if c.usage == nil || len(c.quotas) == 0 || o.noUsage {
+		return nil
+	}
+
+	requested := utf8.RuneCountInString(sent.Text)
+	now := time.Now()
+	for _, q := range c.quotas {
+		if !q.matches(c.tenant, o.usageKey) {
+			continue
+		}
+
+		records, err := c.usage.Store.List(q.Period.start(now), time.Time{})
+		if err != nil {
+			return fmt.Errorf("读取用量失败: %w", err)
+		}
+
+		// 累计周期内匹配的用量
+		e := &QuotaExceededError{Quota: q, Requested: requested}
+		for _, rec := range records {
+			if q.matches(rec.Tenant, rec.Key) {
+				e.Characters += rec.Characters
+				e.AudioSeconds += rec.AudioSeconds
+			}
+		}
+		if q.MaxCharacters > 0 && e.Characters+requested > q.MaxCharacters {
+			return e
+		}
+		if q.MaxAudioSeconds > 0 && e.AudioSeconds >= q.MaxAudioSeconds {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package gpt_sovits_go_sdk
+
+// 提供令牌桶限流器，限制客户端发往后端的请求速率
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter 代表令牌桶限流器，并发安全
+type RateLimiter struct {
+	Rate  float64 // 每秒补充的令牌数
+	Burst int     // 桶容量，即允许的突发请求数
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter 创建一个新的限流器，burst 小于 1 时按 1 处理
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{Rate: rate, Burst: burst, tokens: float64(burst), last: time.Now()}
+}
+
+// Wait 阻塞直至取得一个令牌或上下文结束
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		d := l.reserve()
+		if d <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(d)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve 尝试取得令牌，失败时返回需要等待的时长
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.Rate <= 0 {
+		return 0
+	}
+
+	// 按流逝时间补充令牌
+	now := time.Now()
+	l.tokens = min(float64(l.Burst), l.tokens+now.Sub(l.last).Seconds()*l.Rate)
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.Rate * float64(time.Second))
+}
+
+// Middleware 返回在发送请求前等待令牌的中间件，可通过 Client.Use 注册
+func (l *RateLimiter) Middleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := l.Wait(req.Context()); err != nil {
+				return nil, fmt.Errorf("等待限流令牌失败: %w", err)
+			}
+			return next(req)
+		}
+	}
+}
+
+// WithRateLimit 限制客户端每秒最多发送 rate 个请求，允许 burst 个突发请求
+func WithRateLimit(rate float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.Use(NewRateLimiter(rate, burst).Middleware())
+	}
+}
@@ -0,0 +1,291 @@
+package gpt_sovits_go_sdk
+
+// 提供从 io.Reader 增量读取超长文本、分块合成并流式输出拼接音频的接口
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/audio"
+)
+
+// readerBlockSize 为每次从输入读取的字节数
+const readerBlockSize = 4096
+
+// ReaderOptions 代表从 io.Reader 合成的选项
+type ReaderOptions struct {
+	Request     TTSRequest    // 每块请求的基础参数，Text 字段被忽略
+	Splitter    *TextSplitter // 文本切分器，为空时使用默认切分器
+	Parallelism int           // 同时合成的块数，<=1 时顺序合成，输出顺序始终与文本一致
+	Gap         time.Duration // 相邻块之间插入的静音时长
+}
+
+// ReaderResult 代表从 io.Reader 合成的结果
+type ReaderResult struct {
+	Bytes  int64   // 写出的总字节数（含 WAV 文件头）
+	Chunks []Chunk // 每块的时间位置
+}
+
+// TTSFromReader 从 r 增量读取文本，切分后逐块合成，并将拼接后的 WAV 音频流式写入 w
+// 总长度在写出文件头时未知，文件头中的长度字段标记为未知；w 实现 io.WriteSeeker 时结束后回填实际长度
+func (c *Client) TTSFromReader(ctx context.Context, r io.Reader, w io.Writer, opts *ReaderOptions) (*ReaderResult, error) {
+	if opts == nil {
+		opts = &ReaderOptions{}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// 读取并切分文本
+	texts := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(texts)
+		readErr <- readChunks(ctx, r, opts.Splitter, texts)
+	}()
+
+	// 按文本顺序提交合成，最多同时进行 Parallelism 块
+	parallelism := max(opts.Parallelism, 1)
+	type pending struct {
+		text string
+		done chan struct{}
+		seg  *audio.WAV
+		err  error
+	}
+	queue := make(chan *pending, parallelism)
+	sem := make(chan struct{}, parallelism)
+	go func() {
+		defer close(queue)
+		for text := range texts {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			p := &pending{text: text, done: make(chan struct{})}
+			go func() {
+				defer func() { <-sem }()
+				defer close(p.done)
+				p.seg, p.err = c.synthesizeChunk(ctx, opts.Request, text)
+			}()
+			select {
+			case queue <- p:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	sw := &wavStreamWriter{w: w}
+	result := &ReaderResult{}
+	var offset time.Duration
+	var index int
+	for p := range queue {
+		<-p.done
+		if p.err != nil {
+			return result, fmt.Errorf("第 %d 块: %w", index+1, p.err)
+		}
+
+		// 首块决定输出格式，先写出文件头
+		if index == 0 {
+			if err := sw.writeHeader(p.seg); err != nil {
+				return result, err
+			}
+		} else {
+			if err := sw.writeGap(p.seg, opts.Gap); err != nil {
+				return result, err
+			}
+			offset += opts.Gap
+		}
+		if err := sw.writePCM(p.seg); err != nil {
+			return result, fmt.Errorf("第 %d 块: %w", index+1, err)
+		}
+
+		result.Chunks = append(result.Chunks, Chunk{
+			Index:        index,
+			Text:         p.text,
+			Start:        offset,
+			End:          offset + p.seg.Duration(),
+			RefAudioPath: opts.Request.RefAudioPath,
+		})
+		offset += p.seg.Duration()
+		index++
+	}
+	result.Bytes = sw.written
+
+	if err := <-readErr; err != nil {
+		return result, err
+	}
+	if index == 0 {
+		return result, fmt.Errorf("待合成文本为空")
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	return result, sw.finish()
+}
+
+// readChunks 增量读取文本并按切分器输出完整的块，末尾不完整的句子留待后续数据补全
+func readChunks(ctx context.Context, r io.Reader, splitter *TextSplitter, out chan<- string) error {
+	maxChars := defaultMaxChunkChars
+	if splitter != nil && splitter.MaxChars > 0 {
+		maxChars = splitter.MaxChars
+	}
+
+	var buf, partial []byte
+	block := make([]byte, readerBlockSize)
+	for {
+		n, err := r.Read(block)
+		partial = append(partial, block[:n]...)
+
+		// 只处理完整的 UTF-8 字符，截断的字节留到下次读取
+		valid := len(partial)
+		if err == nil {
+			valid = validUTF8Prefix(partial)
+		}
+		buf = append(buf, partial[:valid]...)
+		partial = append(partial[:0], partial[valid:]...)
+
+		eof := err == io.EOF
+		if err != nil && !eof {
+			return fmt.Errorf("读取文本失败: %w", err)
+		}
+
+		// 积累足够文本后切分，保留最后一块与后续文本合并
+		if eof || utf8.RuneCount(buf) >= 4*maxChars {
+			text := string(buf)
+			chunks := splitter.Split(text)
+			if !eof && len(chunks) > 0 {
+				last := chunks[len(chunks)-1]
+				chunks = chunks[:len(chunks)-1]
+				buf = []byte(text[strings.LastIndex(text, last):])
+			} else {
+				buf = buf[:0]
+			}
+
+			for _, chunk := range chunks {
+				select {
+				case out <- chunk:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+
+		if eof {
+			return nil
+		}
+	}
+}
+
+// validUTF8Prefix 返回不含末尾截断字符的前缀长度
+func validUTF8Prefix(b []byte) int {
+	// UTF-8 字符最长 4 字节，只需检查末尾
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if !utf8.FullRune(b[i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return len(b)
+}
+
+// synthesizeChunk 合成一块文本并解析为 WAV
+func (c *Client) synthesizeChunk(ctx context.Context, base TTSRequest, text string) (*audio.WAV, error) {
+	req := base
+	req.Text = text
+	req.MediaType = MediaTypeWAV
+	req.StreamingMode = false
+
+	resp, err := c.TTS(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+	return audio.ParseWAV(resp.AudioData)
+}
+
+// wavStreamWriter 代表以未知总长度流式写出 WAV 的写入器
+type wavStreamWriter struct {
+	w       io.Writer
+	format  *audio.WAV
+	written int64
+}
+
+// writeHeader 按首块的格式写出长度未知的 WAV 文件头
+func (s *wavStreamWriter) writeHeader(first *audio.WAV) error {
+	s.format = &audio.WAV{SampleRate: first.SampleRate, Channels: first.Channels, BitDepth: first.BitDepth}
+	header := s.format.Bytes()
+	binary.LittleEndian.PutUint32(header[4:8], 0xFFFFFFFF)
+	binary.LittleEndian.PutUint32(header[40:44], 0xFFFFFFFF)
+	return s.write(header)
+}
+
+// writeGap 写出块间静音
+func (s *wavStreamWriter) writeGap(seg *audio.WAV, gap time.Duration) error {
+	if gap <= 0 {
+		return nil
+	}
+	return s.write(audio.Silence(gap, seg.SampleRate, seg.Channels, seg.BitDepth))
+}
+
+// writePCM 写出一块的 PCM 数据，格式须与首块一致
+func (s *wavStreamWriter) writePCM(seg *audio.WAV) error {
+	f := s.format
+	if seg.SampleRate != f.SampleRate || seg.Channels != f.Channels || seg.BitDepth != f.BitDepth {
+		return fmt.Errorf("音频格式 (%dHz/%d声道/%d位) 与首块 (%dHz/%d声道/%d位) 不一致",
+			seg.SampleRate, seg.Channels, seg.BitDepth, f.SampleRate, f.Channels, f.BitDepth)
+	}
+	return s.write(seg.Data)
+}
+
+// write 写出数据并累计字节数
+func (s *wavStreamWriter) write(p []byte) error {
+	n, err := s.w.Write(p)
+	s.written += int64(n)
+	if err != nil {
+		return fmt.Errorf("写出音频数据失败: %w", err)
+	}
+	return nil
+}
+
+// finish 在输出可定位时回填文件头中的实际长度
+func (s *wavStreamWriter) finish() error {
+	ws, ok := s.w.(io.WriteSeeker)
+	if !ok || s.written < 44 {
+		return nil
+	}
+
+	dataLen := s.written - 44
+	var patch bytes.Buffer
+	_ = binary.Write(&patch, binary.LittleEndian, uint32(36+dataLen))
+	// 标准输出等不可定位的输出保持长度未知
+	if _, err := ws.Seek(4, io.SeekStart); err != nil {
+		return nil
+	}
+	if _, err := ws.Write(patch.Bytes()); err != nil {
+		return fmt.Errorf("回填 WAV 文件头失败: %w", err)
+	}
+
+	patch.Reset()
+	_ = binary.Write(&patch, binary.LittleEndian, uint32(dataLen))
+	if _, err := ws.Seek(40, io.SeekStart); err != nil {
+		return fmt.Errorf("回填 WAV 文件头失败: %w", err)
+	}
+	if _, err := ws.Write(patch.Bytes()); err != nil {
+		return fmt.Errorf("回填 WAV 文件头失败: %w", err)
+	}
+	_, err := ws.Seek(0, io.SeekEnd)
+	return err
+}
@@ -0,0 +1,375 @@
+package gpt_sovits_go_sdk
+
+// 提供基于 Redis 的分布式缓存，多台应用服务器共享已合成的音频，相同的短语只需合成一次
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Redis 缓存的默认参数
+const (
+	defaultRedisPrefix      = "gsv:tts:"
+	defaultRedisMaxIdle     = 4
+	defaultRedisDialTimeout = 5 * time.Second
+	defaultRedisIOTimeout   = 5 * time.Second
+	defaultRedisMaxEntry    = 8 << 20
+)
+
+// redisMagic 为缓存值的格式标识，其后依次为标志字节、元数据长度、元数据 JSON 与音频
+var redisMagic = []byte("GSV1")
+
+// redisGzip 为缓存值中表示音频经 gzip 压缩的标志位
+const redisGzip = 1
+
+// RedisCache 代表基于 Redis 的缓存，并发安全，连接按需建立并复用
+// 每条缓存以一个字符串键保存元数据与音频，由 TTL 控制过期；不支持集群模式的重定向
+type RedisCache struct {
+	Addr      string        // 服务地址，如 127.0.0.1:6379
+	Username  string        // ACL 用户名，为空时仅使用密码认证
+	Password  string        // 密码，为空时不认证
+	DB        int           // 数据库编号
+	TLSConfig *tls.Config   // 非 nil 时通过 TLS 连接
+	Prefix    string        // 键前缀，为空时为 "gsv:tts:"
+	TTL       time.Duration // 缓存的有效期，<=0 时不过期
+
+	// MaxEntrySize 为单条缓存（压缩后）的最大字节数，超过时不写入，<=0 时为 8 MiB
+	MaxEntrySize int
+	// Compress 为 true 时以 gzip 压缩音频，压缩无收益时仍保存原始数据
+	Compress bool
+
+	DialTimeout time.Duration // 建立连接的超时时间，<=0 时为 5 秒
+	MaxIdle     int           // 保留的最大空闲连接数，<=0 时为 4
+
+	// IOTimeout 为 ctx 未设置截止时间时单条命令的读写超时时间，<=0 时为 5 秒
+	IOTimeout time.Duration
+
+	mu     sync.Mutex
+	idle   []*redisConn
+	closed bool
+}
+
+// redisConn 代表一条 Redis 连接
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	w    *bufio.Writer
+}
+
+// RedisError 代表 Redis 返回的错误回复
+type RedisError struct {
+	Message string // 错误信息，如 "WRONGPASS invalid username-password pair"
+}
+
+// Error 实现 error 接口
+func (e *RedisError) Error() string {
+	return "Redis 返回错误: " + e.Message
+}
+
+// Get 实现 Cache 接口
+func (r *RedisCache) Get(ctx context.Context, key string) (*CacheEntry, error) {
+	var value []byte
+	err := r.with(ctx, func(c *redisConn) error {
+		var err error
+		value, err = c.do("GET", r.key(key))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("读取 Redis 缓存失败: %w", err)
+	}
+	if value == nil {
+		return nil, ErrCacheMiss
+	}
+	return decodeRedisEntry(value)
+}
+
+// Set 实现 Cache 接口，超过 MaxEntrySize 的项不写入
+func (r *RedisCache) Set(ctx context.Context, key string, entry *CacheEntry) error {
+	value, err := encodeRedisEntry(entry, r.Compress)
+	if err != nil {
+		return err
+	}
+	maxSize := r.MaxEntrySize
+	if maxSize <= 0 {
+		maxSize = defaultRedisMaxEntry
+	}
+	if len(value) > maxSize {
+		return nil
+	}
+
+	args := []string{"SET", r.key(key), string(value)}
+	if r.TTL > 0 {
+		args = append(args, "PX", strconv.FormatInt(max(r.TTL.Milliseconds(), 1), 10))
+	}
+	err = r.with(ctx, func(c *redisConn) error {
+		_, err := c.do(args...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("写入 Redis 缓存失败: %w", err)
+	}
+	return nil
+}
+
+// Close 关闭全部空闲连接，之后的调用返回 ErrClientClosed
+func (r *RedisCache) Close() error {
+	r.mu.Lock()
+	idle := r.idle
+	r.idle, r.closed = nil, true
+	r.mu.Unlock()
+
+	var errs []error
+	for _, c := range idle {
+		errs = append(errs, c.conn.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// key 返回带前缀的键
+func (r *RedisCache) key(key string) string {
+	if r.Prefix == "" {
+		return defaultRedisPrefix + key
+	}
+	return r.Prefix + key
+}
+
+// with 取得一条连接执行 fn，ctx 取消时中断读写；出错的连接不再复用
+func (r *RedisCache) with(ctx context.Context, fn func(c *redisConn) error) error {
+	c, err := r.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 以截止时间控制读写，ctx 取消时立即使阻塞的读写返回，避免服务器无响应时无限期阻塞
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		timeout := r.IOTimeout
+		if timeout <= 0 {
+			timeout = defaultRedisIOTimeout
+		}
+		deadline = time.Now().Add(timeout)
+	}
+	_ = c.conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() {
+		_ = c.conn.SetDeadline(time.Unix(1, 0))
+	})
+
+	err = fn(c)
+	if !stop() || (err != nil && !isRedisReply(err)) {
+		c.conn.Close()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	r.put(c)
+	return err
+}
+
+// get 取得一条空闲连接，没有时新建连接并完成认证与选库
+func (r *RedisCache) get(ctx context.Context) (*redisConn, error) {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil, ErrClientClosed
+	}
+	if n := len(r.idle); n > 0 {
+		c := r.idle[n-1]
+		r.idle = r.idle[:n-1]
+		r.mu.Unlock()
+		return c, nil
+	}
+	r.mu.Unlock()
+
+	timeout := r.DialTimeout
+	if timeout <= 0 {
+		timeout = defaultRedisDialTimeout
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if r.TLSConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: r.TLSConfig}).DialContext(ctx, "tcp", r.Addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", r.Addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("连接 Redis 失败: %w", err)
+	}
+	c := &redisConn{conn: conn, r: bufio.NewReader(conn), w: bufio.NewWriter(conn)}
+
+	// 认证并选择数据库
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(timeout)
+	}
+	_ = conn.SetDeadline(deadline)
+	if r.Password != "" {
+		args := []string{"AUTH", r.Password}
+		if r.Username != "" {
+			args = []string{"AUTH", r.Username, r.Password}
+		}
+		if _, err := c.do(args...); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("Redis 认证失败: %w", err)
+		}
+	}
+	if r.DB != 0 {
+		if _, err := c.do("SELECT", strconv.Itoa(r.DB)); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("选择 Redis 数据库失败: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// put 归还连接，空闲连接已满或缓存已关闭时关闭连接
+func (r *RedisCache) put(c *redisConn) {
+	maxIdle := r.MaxIdle
+	if maxIdle <= 0 {
+		maxIdle = defaultRedisMaxIdle
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || len(r.idle) >= maxIdle {
+		c.conn.Close()
+		return
+	}
+	r.idle = append(r.idle, c)
+}
+
+// do 发送一条命令并读取回复，空回复返回 nil
+func (c *redisConn) do(args ...string) ([]byte, error) {
+	// 命令以 RESP 数组编码，每个参数为一个批量字符串
+	fmt.Fprintf(c.w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(c.w, "$%d\r\n", len(arg))
+		c.w.WriteString(arg)
+		c.w.WriteString("\r\n")
+	}
+	if err := c.w.Flush(); err != nil {
+		return nil, err
+	}
+	return c.read()
+}
+
+// read 读取一条回复，支持简单字符串、错误、整数与批量字符串
+func (c *redisConn) read() ([]byte, error) {
+	line, err := c.r.ReadSlice('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("Redis 回复格式无效")
+	}
+	kind, payload := line[0], string(line[1:len(line)-2])
+
+	switch kind {
+	case '+', ':':
+		return []byte(payload), nil
+	case '-':
+		return nil, &RedisError{Message: payload}
+	case '$':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("Redis 回复长度无效: %q", payload)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	default:
+		return nil, fmt.Errorf("不支持的 Redis 回复类型 %q", kind)
+	}
+}
+
+// isRedisReply 判断错误是否为 Redis 的错误回复，此时连接仍可复用
+func isRedisReply(err error) bool {
+	var redisErr *RedisError
+	return errors.As(err, &redisErr)
+}
+
+// encodeRedisEntry 将缓存项编码为缓存值，compress 为 true 且压缩有收益时压缩音频
+func encodeRedisEntry(entry *CacheEntry, compress bool) ([]byte, error) {
+	m := *entry
+	if m.Request != nil {
+		// 参考音频数据体积较大，元数据中只记录路径
+		req := *m.Request
+		req.RefAudioData = nil
+		m.Request = &req
+	}
+	meta, err := json.Marshal(&m)
+	if err != nil {
+		return nil, fmt.Errorf("序列化缓存项失败: %w", err)
+	}
+
+	audio, flags := entry.Audio, byte(0)
+	if compress {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(entry.Audio); err != nil {
+			return nil, fmt.Errorf("压缩音频失败: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("压缩音频失败: %w", err)
+		}
+		if buf.Len() < len(audio) {
+			audio, flags = buf.Bytes(), redisGzip
+		}
+	}
+
+	value := make([]byte, 0, len(redisMagic)+5+len(meta)+len(audio))
+	value = append(value, redisMagic...)
+	value = append(value, flags)
+	value = binary.BigEndian.AppendUint32(value, uint32(len(meta)))
+	value = append(value, meta...)
+	return append(value, audio...), nil
+}
+
+// decodeRedisEntry 解码缓存值，格式无法识别时按未命中处理
+func decodeRedisEntry(value []byte) (*CacheEntry, error) {
+	header := len(redisMagic) + 5
+	if len(value) < header || !bytes.Equal(value[:len(redisMagic)], redisMagic) {
+		return nil, ErrCacheMiss
+	}
+	flags := value[len(redisMagic)]
+	metaLen := int(binary.BigEndian.Uint32(value[len(redisMagic)+1 : header]))
+	if metaLen > len(value)-header {
+		return nil, fmt.Errorf("%w: 元数据长度无效", ErrCacheCorrupt)
+	}
+
+	entry := &CacheEntry{}
+	if err := json.Unmarshal(value[header:header+metaLen], entry); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCacheCorrupt, err)
+	}
+	entry.Audio = value[header+metaLen:]
+	if flags&redisGzip != 0 {
+		zr, err := gzip.NewReader(bytes.NewReader(entry.Audio))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCacheCorrupt, err)
+		}
+		if entry.Audio, err = io.ReadAll(zr); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCacheCorrupt, err)
+		}
+	}
+	return entry, nil
+}
@@ -0,0 +1,244 @@
+package gpt_sovits_go_sdk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis 代表仅实现 AUTH、SELECT、GET、SET 的内存 RESP 服务器
+type fakeRedis struct {
+	ln       net.Listener
+	password string
+
+	mu       sync.Mutex
+	data     map[string]string
+	ttl      map[string]string // 键的 PX 参数
+	commands []string          // 收到的命令名
+}
+
+// newFakeRedis 启动一个内存 RESP 服务器，测试结束时关闭
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeRedis{ln: ln, password: password, data: make(map[string]string), ttl: make(map[string]string)}
+	go s.serve()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+// serve 接受连接
+func (s *fakeRedis) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle 逐条处理一条连接上的命令
+func (s *fakeRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+	authed := s.password == ""
+
+	for {
+		args, err := readRESPArray(r)
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(args[0])
+
+		s.mu.Lock()
+		s.commands = append(s.commands, cmd)
+		switch {
+		case cmd == "AUTH":
+			if args[len(args)-1] == s.password {
+				authed = true
+				w.WriteString("+OK\r\n")
+			} else {
+				w.WriteString("-WRONGPASS invalid username-password pair\r\n")
+			}
+		case !authed:
+			w.WriteString("-NOAUTH Authentication required.\r\n")
+		case cmd == "SELECT":
+			w.WriteString("+OK\r\n")
+		case cmd == "SET":
+			s.data[args[1]] = args[2]
+			if len(args) == 5 && strings.ToUpper(args[3]) == "PX" {
+				s.ttl[args[1]] = args[4]
+			}
+			w.WriteString("+OK\r\n")
+		case cmd == "GET":
+			if v, ok := s.data[args[1]]; ok {
+				fmt.Fprintf(w, "$%d\r\n%s\r\n", len(v), v)
+			} else {
+				w.WriteString("$-1\r\n")
+			}
+		default:
+			fmt.Fprintf(w, "-ERR unknown command '%s'\r\n", args[0])
+		}
+		s.mu.Unlock()
+
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// readRESPArray 读取一条以批量字符串数组编码的命令
+func readRESPArray(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected %q", line)
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func TestRedisCacheRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		compress bool
+		audio    string
+	}{
+		{name: "不压缩", audio: "RIFF audio"},
+		{name: "压缩", compress: true, audio: strings.Repeat("RIFF", 1024)},
+		{name: "压缩无收益时保存原始数据", compress: true, audio: "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeRedis(t, "secret")
+			r := &RedisCache{Addr: srv.ln.Addr().String(), Password: "secret", DB: 2, Compress: tt.compress, TTL: time.Hour}
+			defer r.Close()
+
+			want := testEntry(tt.audio)
+			if err := r.Set(ctx, "k", want); err != nil {
+				t.Fatal(err)
+			}
+			got, err := r.Get(ctx, "k")
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkEntry(t, got, want)
+
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			value, ok := srv.data["gsv:tts:k"]
+			if !ok {
+				t.Fatalf("键应使用默认前缀，实际键: %v", srv.data)
+			}
+			if bytes.Contains([]byte(value), []byte("reference audio")) || strings.Contains(value, "cmVmZXJlbmNlIGF1ZGlv") {
+				t.Error("缓存值中不应包含内嵌参考音频")
+			}
+			if srv.ttl["gsv:tts:k"] != "3600000" {
+				t.Errorf("PX = %q，期望 3600000", srv.ttl["gsv:tts:k"])
+			}
+			// 同一连接只认证与选择数据库一次
+			if got := strings.Join(srv.commands, " "); got != "AUTH SELECT SET GET" {
+				t.Errorf("命令序列 = %s", got)
+			}
+		})
+	}
+}
+
+func TestRedisCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeRedis(t, "secret")
+
+	r := &RedisCache{Addr: srv.ln.Addr().String(), Password: "secret"}
+	defer r.Close()
+	if _, err := r.Get(ctx, "missing"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("未命中错误 = %v，期望 ErrCacheMiss", err)
+	}
+
+	// 超过 MaxEntrySize 的项不写入
+	small := &RedisCache{Addr: srv.ln.Addr().String(), Password: "secret", MaxEntrySize: 16}
+	defer small.Close()
+	if err := small.Set(ctx, "big", testEntry("RIFF audio")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Get(ctx, "big"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatal("超过 MaxEntrySize 的项不应写入")
+	}
+
+	// 密码错误时返回 RedisError
+	wrong := &RedisCache{Addr: srv.ln.Addr().String(), Password: "wrong"}
+	defer wrong.Close()
+	var redisErr *RedisError
+	if _, err := wrong.Get(ctx, "k"); !errors.As(err, &redisErr) || !strings.HasPrefix(redisErr.Message, "WRONGPASS") {
+		t.Fatalf("认证失败错误 = %v，期望 WRONGPASS", err)
+	}
+}
+
+func TestRedisCacheIOTimeout(t *testing.T) {
+	// 服务器接受连接后读取命令但不回复
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(io.Discard, conn)
+			}()
+		}
+	}()
+
+	r := &RedisCache{Addr: ln.Addr().String(), IOTimeout: 50 * time.Millisecond}
+	defer r.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := r.Get(context.Background(), "k")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		var netErr net.Error
+		if !errors.As(err, &netErr) || !netErr.Timeout() {
+			t.Fatalf("错误 = %v，期望读写超时", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ctx 未设置截止时间时应按 IOTimeout 超时返回")
+	}
+}
@@ -0,0 +1,74 @@
+package gpt_sovits_go_sdk
+
+// 提供音色多参考音频的轮换，使长文本合成的语气更自然
+
+import (
+	"math/rand/v2"
+)
+
+// RefAudio 代表一组参考音频及其提示文本
+type RefAudio struct {
+	Path       string   `json:"path"`                // 参考音频路径
+	AuxPaths   []string `json:"aux_paths,omitempty"` // 辅助参考音频路径
+	PromptText string   `json:"prompt_text"`         // 参考音频的提示文本
+	PromptLang string   `json:"prompt_lang"`         // 参考音频提示文本的语言，为空时使用音色的 PromptLang
+}
+
+// RefRotation 代表多参考音频的选择策略
+type RefRotation string
+
+const (
+	RotationFixed      RefRotation = "fixed"       // 始终使用第一组参考音频
+	RotationRoundRobin RefRotation = "round_robin" // 按片段序号轮流使用
+	RotationRandom     RefRotation = "random"      // 每个片段随机选择
+)
+
+// refAudios 返回音色的全部候选参考音频，音色自身的参考音频位于首位
+func (v *Voice) refAudios() []RefAudio {
+	refs := make([]RefAudio, 0, len(v.RefAudios)+1)
+	if v.RefAudioPath != "" {
+		refs = append(refs, RefAudio{
+			Path:       v.RefAudioPath,
+			AuxPaths:   v.AuxRefAudioPaths,
+			PromptText: v.PromptText,
+			PromptLang: v.PromptLang,
+		})
+	}
+	return append(refs, v.RefAudios...)
+}
+
+// RefAudioFor 按轮换策略返回第 i 个片段使用的参考音频，未设置策略时等同于 RotationFixed
+func (v *Voice) RefAudioFor(i int) RefAudio {
+	refs := v.refAudios()
+	if len(refs) == 0 {
+		return RefAudio{}
+	}
+
+	var ref RefAudio
+	switch v.Rotation {
+	case RotationRoundRobin:
+		ref = refs[i%len(refs)]
+	case RotationRandom:
+		ref = refs[rand.IntN(len(refs))]
+	default:
+		ref = refs[0]
+	}
+	if ref.PromptLang == "" {
+		ref.PromptLang = v.PromptLang
+	}
+	return ref
+}
+
+// ApplyRef 将音色写入请求，参考音频按轮换策略选择第 i 个片段使用的一组
+func (v *Voice) ApplyRef(req *TTSRequest, i int) {
+	v.Apply(req)
+
+	ref := v.RefAudioFor(i)
+	if ref.Path == "" {
+		return
+	}
+	req.RefAudioPath = ref.Path
+	req.AuxRefAudioPaths = ref.AuxPaths
+	req.PromptText = ref.PromptText
+	req.PromptLang = ref.PromptLang
+}
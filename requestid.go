@@ -0,0 +1,105 @@
+package gpt_sovits_go_sdk
+
+// 提供请求 ID 传递与服务器耗时响应头解析，便于与服务器日志关联
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RequestIDHeader 为携带请求 ID 的请求头
+const RequestIDHeader = "X-Request-ID"
+
+// WithRequestID 指定本次调用的请求 ID，未指定时自动生成
+func WithRequestID(id string) CallOption {
+	return func(o *callOptions) {
+		o.requestID = id
+	}
+}
+
+// setRequestID 为请求设置请求 ID 并返回，已由调用方设置的请求头保持不变
+func setRequestID(httpReq *http.Request, o *callOptions) string {
+	if id := httpReq.Header.Get(RequestIDHeader); id != "" {
+		return id
+	}
+
+	id := o.requestID
+	if id == "" {
+		id = newRequestID()
+	}
+	httpReq.Header.Set(RequestIDHeader, id)
+	return id
+}
+
+// newRequestID 生成随机的请求 ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// responseRequestID 返回服务器回显的请求 ID，未回显时返回发送的请求 ID
+func responseRequestID(h http.Header, sent string) string {
+	if id := h.Get(RequestIDHeader); id != "" {
+		return id
+	}
+	return sent
+}
+
+// parseUpstreamTime 从响应头解析服务器处理耗时，无法解析时返回 0
+// 依次识别 Server-Timing 的 dur（毫秒）、X-Process-Time（秒）与 X-Response-Time（毫秒或带单位的时长）
+func parseUpstreamTime(h http.Header) time.Duration {
+	// Server-Timing: total;dur=123.4, infer;dur=100
+	// 存在名为 total 的指标时取其值，否则累加所有指标
+	if st := h.Values("Server-Timing"); len(st) > 0 {
+		var sum, total float64
+		hasTotal := false
+		for _, metric := range strings.Split(strings.Join(st, ","), ",") {
+			parts := strings.Split(metric, ";")
+			name := strings.TrimSpace(parts[0])
+			for _, p := range parts[1:] {
+				v, ok := strings.CutPrefix(strings.TrimSpace(p), "dur=")
+				if !ok {
+					continue
+				}
+				ms, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					continue
+				}
+				sum += ms
+				if name == "total" {
+					total, hasTotal = ms, true
+				}
+			}
+		}
+		if hasTotal {
+			sum = total
+		}
+		if sum > 0 {
+			return time.Duration(sum * float64(time.Millisecond))
+		}
+	}
+
+	// X-Process-Time: 1.234（FastAPI 常见写法，单位为秒）
+	if v := h.Get("X-Process-Time"); v != "" {
+		if sec, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return time.Duration(sec * float64(time.Second))
+		}
+	}
+
+	// X-Response-Time: 123.4ms 或 123.4
+	if v := strings.TrimSpace(h.Get("X-Response-Time")); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+		if ms, err := strconv.ParseFloat(v, 64); err == nil {
+			return time.Duration(ms * float64(time.Millisecond))
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,110 @@
+package gpt_sovits_go_sdk
+
+// 提供响应体读取中断时通过 Range 请求续传，避免不稳定的连接导致大文件需要重新合成
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WithRangeResume 设置读取响应体中断时通过 Range 请求续传的最大次数，<=0 表示不续传
+// 仅在响应头声明 Accept-Ranges: bytes 时生效，适用于缓存合成结果并支持范围请求的网关；
+// 续传请求与原请求相同并附加 Range 头，响应携带 ETag 或 Last-Modified 时同时附加 If-Range，
+// 内容已变化时网关返回完整响应并重新读取；经过透明解压的响应无法按字节续传
+func WithRangeResume(maxResumes int) ClientOption {
+	return func(c *Client) {
+		c.rangeResumes = maxResumes
+	}
+}
+
+// resumeBody 在读取响应体中断后续传剩余部分，返回拼接后的音频与最终的读取错误
+func (c *Client) resumeBody(httpReq *http.Request, first *http.Response, data []byte, readErr error, o *callOptions, start time.Time) ([]byte, error) {
+	if c.rangeResumes <= 0 || first.Uncompressed || !acceptsRanges(first.Header) {
+		return data, readErr
+	}
+	if httpReq.Body != nil && httpReq.Body != http.NoBody && httpReq.GetBody == nil {
+		return data, readErr
+	}
+	validator := rangeValidator(first.Header)
+
+	ctx := httpReq.Context()
+	for i := 0; i < c.rangeResumes && readErr != nil; i++ {
+		if ctx.Err() != nil {
+			break
+		}
+
+		// 重新发送原请求并只请求尚未接收的部分
+		req := httpReq.Clone(ctx)
+		if httpReq.GetBody != nil {
+			body, err := httpReq.GetBody()
+			if err != nil {
+				break
+			}
+			req.Body = body
+		}
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", len(data)))
+		if validator != "" {
+			req.Header.Set("If-Range", validator)
+		}
+
+		resp, err := c.do(req)
+		if err != nil {
+			readErr = err
+			continue
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusPartialContent && contentRangeStart(resp.Header) == int64(len(data)):
+			var more []byte
+			more, readErr = io.ReadAll(wrapProgressFrom(resp.Body, o.progress, start, int64(len(data))))
+			data = append(data, more...)
+		case resp.StatusCode == http.StatusOK:
+			// 内容已变化或网关忽略了 Range，重新读取完整响应
+			data, readErr = io.ReadAll(wrapProgress(resp.Body, o.progress, start))
+		default:
+			resp.Body.Close()
+			return data, readErr
+		}
+		resp.Body.Close()
+	}
+	return data, readErr
+}
+
+// acceptsRanges 判断响应是否支持按字节的范围请求
+func acceptsRanges(h http.Header) bool {
+	for _, v := range strings.Split(h.Get("Accept-Ranges"), ",") {
+		if strings.EqualFold(strings.TrimSpace(v), "bytes") {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeValidator 返回用于 If-Range 的强 ETag 或 Last-Modified，均不可用时返回空字符串
+func rangeValidator(h http.Header) string {
+	if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return h.Get("Last-Modified")
+}
+
+// contentRangeStart 解析 Content-Range 中的起始偏移，如 "bytes 100-199/200"，无法解析时返回 -1
+func contentRangeStart(h http.Header) int64 {
+	v, ok := strings.CutPrefix(h.Get("Content-Range"), "bytes ")
+	if !ok {
+		return -1
+	}
+	first, _, ok := strings.Cut(v, "-")
+	if !ok {
+		return -1
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(first), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
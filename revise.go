@@ -0,0 +1,217 @@
+package gpt_sovits_go_sdk
+
+// 提供稿件修订后的增量重新合成：按句比较新旧稿件，只合成变化的句子并拼接回原有音频
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/audio"
+)
+
+// ScriptOp 代表句级差异中的操作
+type ScriptOp int
+
+const (
+	ScriptKeep   ScriptOp = iota // 句子未变化
+	ScriptInsert                 // 新稿件中新增的句子
+	ScriptDelete                 // 旧稿件中被删除的句子
+)
+
+// String 返回操作名称
+func (op ScriptOp) String() string {
+	switch op {
+	case ScriptKeep:
+		return "keep"
+	case ScriptInsert:
+		return "insert"
+	case ScriptDelete:
+		return "delete"
+	default:
+		return fmt.Sprintf("ScriptOp(%d)", int(op))
+	}
+}
+
+// ScriptEdit 代表句级差异中的一项，修改的句子表示为删除旧句与插入新句
+type ScriptEdit struct {
+	Op       ScriptOp // 操作
+	OldIndex int      // 在旧稿件中的句子序号，插入时为 -1
+	NewIndex int      // 在新稿件中的句子序号，删除时为 -1
+	Text     string   // 句子文本
+}
+
+// DiffScripts 使用切分器将新旧稿件切分为句子并比较，返回按新稿件顺序排列的句级差异
+// splitter 为空时使用默认切分器
+func DiffScripts(oldText, newText string, splitter *TextSplitter) []ScriptEdit {
+	return diffSentences(splitter.Split(oldText), splitter.Split(newText))
+}
+
+// diffSentences 基于最长公共子序列比较两组句子
+func diffSentences(a, b []string) []ScriptEdit {
+	// 跳过公共前缀与后缀，缩小需要比较的范围
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] 为 midA[i:] 与 midB[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	edits := make([]ScriptEdit, 0, len(a)+len(b)-prefix-suffix)
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, ScriptEdit{Op: ScriptKeep, OldIndex: i, NewIndex: i, Text: a[i]})
+	}
+
+	// 回溯得到差异，同一位置的删除排在插入之前
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			edits = append(edits, ScriptEdit{Op: ScriptKeep, OldIndex: prefix + i, NewIndex: prefix + j, Text: midA[i]})
+			i++
+			j++
+		case i < len(midA) && (j == len(midB) || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, ScriptEdit{Op: ScriptDelete, OldIndex: prefix + i, NewIndex: -1, Text: midA[i]})
+			i++
+		default:
+			edits = append(edits, ScriptEdit{Op: ScriptInsert, OldIndex: -1, NewIndex: prefix + j, Text: midB[j]})
+			j++
+		}
+	}
+
+	for k := 0; k < suffix; k++ {
+		oi, ni := len(a)-suffix+k, len(b)-suffix+k
+		edits = append(edits, ScriptEdit{Op: ScriptKeep, OldIndex: oi, NewIndex: ni, Text: a[oi]})
+	}
+	return edits
+}
+
+// revisePiece 代表修订后音频中的一段：连续未变化的句子整段取自原音频，新句单独合成
+type revisePiece struct {
+	seg     *audio.WAV
+	chunks  []Chunk // 段内各句，时间相对于段起点
+	lastOld int     // 未变化段中最后一句在旧稿件中的序号，新句为 -1
+}
+
+// TTSRevise 将 req.Text 作为新稿件，与 prev 中各块的文本按句比较，只合成新增或修改的句子
+// 连续未变化的句子整段从 prev.Audio 中截取，保留原有的衔接；各段之间按 opts 的 Gap 与 Crossfade 重新拼接
+// prev 通常为 TTSLong 或 ApplyChunks 的结果，opts 应与生成 prev 时一致，否则新旧句子之间的间隔会不一致
+func (c *Client) TTSRevise(ctx context.Context, prev *LongTextResult, req TTSRequest, opts *LongTextOptions) (*LongTextResult, []ScriptEdit, error) {
+	if opts == nil {
+		opts = &LongTextOptions{}
+	}
+	if prev == nil || len(prev.Chunks) == 0 {
+		return nil, nil, fmt.Errorf("原有合成结果为空")
+	}
+
+	texts := opts.Splitter.Split(req.Text)
+	if len(texts) == 0 {
+		return nil, nil, fmt.Errorf("待合成文本为空")
+	}
+
+	prevWAV, err := audio.ParseWAV(prev.Audio)
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析原有音频失败: %w", err)
+	}
+
+	old := make([]string, len(prev.Chunks))
+	for i, ch := range prev.Chunks {
+		old[i] = ch.Text
+	}
+	edits := diffSentences(old, texts)
+
+	var pieces []*revisePiece
+	for _, e := range edits {
+		switch e.Op {
+		case ScriptKeep:
+			orig := prev.Chunks[e.OldIndex]
+			last := len(pieces) - 1
+			if last < 0 || pieces[last].lastOld < 0 || pieces[last].lastOld != e.OldIndex-1 {
+				pieces = append(pieces, &revisePiece{lastOld: e.OldIndex})
+				last++
+			}
+
+			// 段内时间相对于段内第一句的起点
+			p := pieces[last]
+			base := orig.Start
+			if len(p.chunks) > 0 {
+				base = prev.Chunks[e.OldIndex-len(p.chunks)].Start
+			}
+			p.chunks = append(p.chunks, Chunk{
+				Index:        e.NewIndex,
+				Text:         e.Text,
+				Start:        orig.Start - base,
+				End:          orig.End - base,
+				RefAudioPath: orig.RefAudioPath,
+			})
+			p.lastOld = e.OldIndex
+			p.seg = audio.Slice(prevWAV, base, orig.End)
+
+		case ScriptInsert:
+			chunkReq := req
+			if opts.Voice != nil {
+				opts.Voice.ApplyRef(&chunkReq, e.NewIndex)
+			}
+			seg, err := c.synthesizeChunk(ctx, chunkReq, e.Text)
+			if err != nil {
+				return nil, nil, fmt.Errorf("第 %d 块: %w", e.NewIndex+1, err)
+			}
+			if opts.Trim != nil {
+				if seg, err = audio.Trim(seg, *opts.Trim); err != nil {
+					return nil, nil, fmt.Errorf("第 %d 块: %w", e.NewIndex+1, err)
+				}
+			}
+			pieces = append(pieces, &revisePiece{
+				seg:     seg,
+				chunks:  []Chunk{{Index: e.NewIndex, Text: e.Text, End: seg.Duration(), RefAudioPath: chunkReq.RefAudioPath}},
+				lastOld: -1,
+			})
+		}
+	}
+
+	segments := make([]*audio.WAV, len(pieces))
+	for i, p := range pieces {
+		segments[i] = p.seg
+	}
+	stitched, spans, err := audio.ConcatWithSpans(segments, audio.ConcatOptions{Gap: opts.Gap, Crossfade: opts.Crossfade})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 将段内时间换算为拼接音频中的时间
+	chunks := make([]Chunk, 0, len(texts))
+	for i, p := range pieces {
+		for _, ch := range p.chunks {
+			ch.Start, ch.End = spans[i].Start+ch.Start, spans[i].Start+ch.End
+			chunks = append(chunks, ch)
+		}
+	}
+
+	return &LongTextResult{Audio: stitched.Bytes(), Chunks: chunks}, edits, nil
+}
+
+// Result 将 ApplyChunks 写出的清单与拼接音频组合为 LongTextResult，供 TTSRevise 使用
+func (m *ChunkManifest) Result(stitched []byte) *LongTextResult {
+	chunks := make([]Chunk, len(m.Chunks))
+	for i, rec := range m.Chunks {
+		chunks[i] = Chunk{Index: rec.Index, Text: rec.Text, Start: rec.Start, End: rec.End, RefAudioPath: rec.RefAudioPath}
+	}
+	return &LongTextResult{Audio: stitched, Chunks: chunks}
+}
@@ -0,0 +1,15 @@
+package gpt_sovits_go_sdk
+
+// 提供客户端随机种子生成，使每次合成使用的种子都可以被记录与复现
+
+import (
+	"math/rand/v2"
+)
+
+// maxSeed 为生成随机种子的上限，服务器会将种子传给 numpy，须小于 2^32
+const maxSeed = 1<<31 - 1
+
+// randomSeed 生成非0的随机种子
+func randomSeed() int {
+	return rand.IntN(maxSeed) + 1
+}
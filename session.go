@@ -0,0 +1,138 @@
+package gpt_sovits_go_sdk
+
+// 提供会话，固定音色、模型权重与默认参数，避免在应用代码中重复配置
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrInterrupted 表示会话的合成被 Interrupt 打断
+var ErrInterrupted = errors.New("合成已被打断")
+
+// ModelWeights 代表一组 GPT/SoVITS 模型权重
+type ModelWeights struct {
+	GPT    string `json:"gpt,omitempty"`    // GPT 权重路径，为空时不要求
+	SoVITS string `json:"sovits,omitempty"` // SoVITS 权重路径，为空时不要求
+}
+
+// Session 代表固定音色、模型权重与默认参数的合成会话，可被多个 goroutine 同时使用
+// 同一客户端的会话通过 Client.Models 共享的模型管理器协调：合成前确保会话的权重处于激活状态，
+// 合成期间阻止其他会话切换权重；使用不同权重的会话交替合成时每次都需要切换，应尽量按权重分批调用
+type Session struct {
+	Voice    *Voice       // 音色，为 nil 时使用 Defaults 中的参考音频
+	Model    ModelWeights // 模型权重，零值时使用音色绑定的权重，均为空时不切换
+	Defaults TTSRequest   // 默认参数，优先级高于客户端默认参数，低于音色
+
+	client *Client
+
+	mu     sync.Mutex
+	active map[*context.CancelCauseFunc]struct{} // 进行中调用的取消函数，Interrupt 时全部取消
+}
+
+// NewSession 创建一个新的合成会话
+func (c *Client) NewSession(voice *Voice, model ModelWeights, defaults TTSRequest) *Session {
+	if model == (ModelWeights{}) && voice != nil {
+		model = ModelWeights{GPT: voice.GPTWeights, SoVITS: voice.SoVITSWeights}
+	}
+	return &Session{Voice: voice, Model: model, Defaults: defaults, client: c}
+}
+
+// Speak 使用会话的音色与默认参数合成文本
+func (s *Session) Speak(ctx context.Context, text string, opts ...CallOption) (*TTSResponse, error) {
+	return s.SpeakStyle(ctx, "", text, opts...)
+}
+
+// SpeakStyle 使用会话音色的指定风格合成文本
+func (s *Session) SpeakStyle(ctx context.Context, style, text string, opts ...CallOption) (*TTSResponse, error) {
+	req, opts, err := s.request(style, text, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := s.bind(ctx)
+	defer done()
+
+	// 确保会话的模型处于激活状态，合成完成前不允许切换
+	release, err := s.client.Models().acquire(ctx, s.Model.GPT, s.Model.SoVITS)
+	if err != nil {
+		return nil, s.interrupted(ctx, fmt.Errorf("切换会话模型失败: %w", err))
+	}
+	defer release()
+
+	resp, err := s.client.TTS(ctx, req, opts...)
+	if resp != nil && resp.Error != nil {
+		resp.Error = s.interrupted(ctx, resp.Error)
+	}
+	return resp, err
+}
+
+// Interrupt 打断会话中进行中与排队中的全部合成，用于语音助手被用户插话等场景
+// 被打断的调用返回 ErrInterrupted，TokenBridge 与 SpeakConversational 不再交付尚未交付的音频；
+// Interrupt 之后发起的调用不受影响，可被多个 goroutine 同时调用
+func (s *Session) Interrupt() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 同步取消，使 Interrupt 返回后不再交付音频
+	for cancel := range s.active {
+		(*cancel)(ErrInterrupted)
+	}
+	clear(s.active)
+}
+
+// bind 返回在会话被打断时取消的上下文，调用方完成后须调用 done
+func (s *Session) bind(ctx context.Context) (bound context.Context, done func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	key := &cancel
+
+	s.mu.Lock()
+	if s.active == nil {
+		s.active = make(map[*context.CancelCauseFunc]struct{})
+	}
+	s.active[key] = struct{}{}
+	s.mu.Unlock()
+
+	return ctx, func() {
+		s.mu.Lock()
+		delete(s.active, key)
+		s.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+// interrupted 在上下文因会话被打断而取消时将 err 替换为 ErrInterrupted
+func (s *Session) interrupted(ctx context.Context, err error) error {
+	if err != nil && errors.Is(context.Cause(ctx), ErrInterrupted) {
+		return ErrInterrupted
+	}
+	return err
+}
+
+// request 按会话配置构建请求
+func (s *Session) request(style, text string, opts []CallOption) (TTSRequest, []CallOption, error) {
+	req := s.Defaults
+	req.Text = text
+	if s.Voice == nil {
+		if style != "" {
+			return req, nil, fmt.Errorf("%w: 会话未设置音色", ErrStyleNotFound)
+		}
+		return req, opts, nil
+	}
+
+	if err := s.Voice.ApplyStyle(&req, style); err != nil {
+		return req, nil, err
+	}
+
+	// 应用音色专用的发音词典
+	var err error
+	if req.Text, err = s.Voice.ProcessText(req.Text, req.TextLang); err != nil {
+		return req, nil, err
+	}
+
+	// 用量记录按音色名称统计
+	opts = append([]CallOption{withUsageVoice(s.Voice, s.Model.GPT)}, opts...)
+	return req, opts, nil
+}
@@ -0,0 +1,167 @@
+package gpt_sovits_go_sdk
+
+// 提供客户端的优雅关闭，配合上下文截止时间与系统信号在部署切换时完成进行中的任务
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// WithShutdownStore 设置关闭时保存未执行异步任务的存储
+// Shutdown 超时时仍在排队的 TTSAsync 任务以等待状态保存为 Job，可由使用同一存储的 JobQueue 在下次启动时执行；
+// 保存的任务只包含请求参数，不包含单次调用的可选项
+func WithShutdownStore(store JobStore) ClientOption {
+	return func(c *Client) {
+		c.shutdownStore = store
+	}
+}
+
+// registerQueue 登记以该客户端创建的任务队列
+func (c *Client) registerQueue(q *JobQueue) {
+	c.queuesMu.Lock()
+	defer c.queuesMu.Unlock()
+
+	c.queues = append(c.queues, q)
+}
+
+// Shutdown 优雅地关闭客户端，返回所有步骤的错误
+//
+// 关闭时依次：
+//   - 停止接受新的异步任务，并关闭以该客户端创建的任务队列
+//   - 等待排队与执行中的异步任务、任务队列中执行中的任务完成
+//   - 等待进行中的同步请求完成
+//   - 调用 Close 释放资源
+//
+// 上下文到期时不再等待：执行中的任务被取消，任务队列中被中断的任务恢复为等待状态，
+// 仍在排队的异步任务以 ErrClientClosed 完成，设置了 WithShutdownStore 时保存至存储
+func (c *Client) Shutdown(ctx context.Context) error {
+	// 占用初始化，使关闭后不再启动调度器
+	c.dispatcherOnce.Do(func() {})
+	d := c.dispatcher
+
+	c.queuesMu.Lock()
+	queues := c.queues
+	c.queuesMu.Unlock()
+
+	// 并行关闭调度器与任务队列
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	addErr := func(err error) {
+		if err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+	}
+
+	if d != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			rest, err := d.shutdown(ctx)
+			addErr(err)
+			addErr(c.saveUnsent(rest))
+		}()
+	}
+	for _, q := range queues {
+		wg.Add(1)
+		go func(q *JobQueue) {
+			defer wg.Done()
+			addErr(q.Shutdown(ctx))
+		}(q)
+	}
+	wg.Wait()
+
+	// 等待进行中的同步请求
+	c.gate.close()
+	if err := c.gate.idle(ctx); err != nil {
+		addErr(fmt.Errorf("等待进行中的请求完成超时: %w", err))
+	}
+
+	addErr(c.Close())
+	return errors.Join(errs...)
+}
+
+// saveUnsent 将未执行的异步任务保存至关闭存储，并以 ErrClientClosed 完成其 Future
+func (c *Client) saveUnsent(jobs []*asyncJob) error {
+	var errs []error
+	for _, job := range jobs {
+		if c.shutdownStore != nil {
+			now := time.Now()
+			err := c.shutdownStore.Save(&Job{
+				ID:        newJobID(job.queued),
+				Request:   job.req,
+				Status:    JobPending,
+				CreatedAt: job.queued,
+				UpdatedAt: now,
+			})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("保存未执行的异步任务失败: %w", err))
+			}
+		}
+		job.finish(&TTSResponse{Error: ErrClientClosed}, nil)
+	}
+	return errors.Join(errs...)
+}
+
+// shutdown 停止接受新任务并等待队列中的任务执行完毕
+// 上下文到期时取消执行中的任务，返回尚未开始的任务，其 Future 由调用方完成
+func (d *dispatcher) shutdown(ctx context.Context) ([]*asyncJob, error) {
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+	d.cond.Broadcast()
+
+	done := make(chan struct{})
+	go func() {
+		d.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil, nil
+	case <-ctx.Done():
+	}
+
+	// 超时后取出排队中的任务，并取消执行中的任务
+	d.mu.Lock()
+	var rest []*asyncJob
+	for _, q := range d.queues {
+		rest = append(rest, q...)
+	}
+	d.queues = [3][]*asyncJob{}
+	for job := range d.running {
+		job.future.cancel()
+	}
+	d.mu.Unlock()
+
+	<-done
+	return rest, fmt.Errorf("等待异步任务完成超时，%d 个任务未执行: %w", len(rest), ctx.Err())
+}
+
+// ShutdownOnSignal 阻塞直至收到信号或 ctx 取消，然后在 timeout 内优雅地关闭客户端
+// sigs 为空时监听 SIGINT 与 SIGTERM，适用于 Kubernetes 等在终止前发送 SIGTERM 的环境；
+// timeout 应小于部署环境的终止宽限期
+func (c *Client) ShutdownOnSignal(ctx context.Context, timeout time.Duration, sigs ...os.Signal) error {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	sigCtx, stop := signal.NotifyContext(ctx, sigs...)
+	<-sigCtx.Done()
+	stop()
+
+	// 使用独立的上下文，使关闭过程不受已取消的 ctx 影响
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+	return c.Shutdown(shutdownCtx)
+}
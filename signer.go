@@ -0,0 +1,109 @@
+package gpt_sovits_go_sdk
+
+// 提供请求签名钩子，用于需要签名认证的 API 网关
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Signer 代表请求签名器，在请求发送前被调用以附加签名
+// bodyHash 为请求体 SHA-256 摘要的十六进制小写形式，无请求体时为空内容的摘要
+type Signer interface {
+	Sign(req *http.Request, bodyHash string) error
+}
+
+// SignerFunc 允许将普通函数用作 Signer
+type SignerFunc func(req *http.Request, bodyHash string) error
+
+// Sign 实现 Signer 接口
+func (f SignerFunc) Sign(req *http.Request, bodyHash string) error {
+	return f(req, bodyHash)
+}
+
+// WithSigner 在每个请求发送前调用签名器
+// 请求体不可重复读取时（如参考音频上传）会先完整读入内存以计算摘要
+func WithSigner(s Signer) ClientOption {
+	return func(c *Client) {
+		c.Use(func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				hash, err := hashBody(req)
+				if err != nil {
+					return nil, fmt.Errorf("计算请求体摘要失败: %w", err)
+				}
+				if err := s.Sign(req, hash); err != nil {
+					return nil, fmt.Errorf("请求签名失败: %w", err)
+				}
+				return next(req)
+			}
+		})
+	}
+}
+
+// hashBody 计算请求体的 SHA-256 摘要，必要时替换为可重复读取的请求体
+func hashBody(req *http.Request) (string, error) {
+	h := sha256.New()
+
+	switch {
+	case req.Body == nil || req.Body == http.NoBody:
+		// 无请求体
+	case req.GetBody != nil:
+		// 通过副本计算，不消耗原请求体
+		body, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, body)
+		body.Close()
+		if err != nil {
+			return "", err
+		}
+	default:
+		// 读入内存并替换为可重复读取的请求体
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return "", err
+		}
+		h.Write(data)
+		req.Body = io.NopCloser(bytes.NewReader(data))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}
+		req.ContentLength = int64(len(data))
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// HMACSigner 代表通用的 HMAC-SHA256 签名器
+//
+// 待签名字符串为 "方法\n路径\n时间戳\n请求体摘要"，路径包含查询参数，
+// 签名以十六进制写入 X-Signature 请求头，时间戳（Unix 秒）写入 X-Timestamp，KeyID 非空时写入 X-Key-Id
+type HMACSigner struct {
+	KeyID  string // 密钥标识
+	Secret []byte // 签名密钥
+}
+
+// Sign 实现 Signer 接口
+func (s *HMACSigner) Sign(req *http.Request, bodyHash string) error {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	payload := req.Method + "\n" + req.URL.RequestURI() + "\n" + ts + "\n" + bodyHash
+
+	mac := hmac.New(sha256.New, s.Secret)
+	mac.Write([]byte(payload))
+
+	req.Header.Set("X-Timestamp", ts)
+	req.Header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+	if s.KeyID != "" {
+		req.Header.Set("X-Key-Id", s.KeyID)
+	}
+	return nil
+}
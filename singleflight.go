@@ -0,0 +1,126 @@
+package gpt_sovits_go_sdk
+
+// 提供相同请求的合并，多个 goroutine 同时发起相同的合成时服务器只合成一次
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// WithSingleflight 合并同时发起的相同 TTS 请求，所有调用方共享同一次合成的结果
+// 相同请求的判定与 WithCache 的缓存键一致；共享的 AudioData 不应被修改
+// 进度回调与请求 ID 等单次调用可选项只对实际发送请求的调用生效，用量只记录一次
+func WithSingleflight() ClientOption {
+	return func(c *Client) {
+		c.singleflight = true
+	}
+}
+
+// flightCall 代表一次进行中的合成
+type flightCall struct {
+	done chan struct{}
+	resp *TTSResponse
+	err  error
+}
+
+// flightGroup 代表按键合并的进行中合成，零值可直接使用
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// do 执行 fn 或等待相同键的进行中调用完成，shared 表示结果来自其他调用
+// 执行调用的上下文被取消而当前调用的上下文仍有效时，当前调用重新执行
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (*TTSResponse, error)) (resp *TTSResponse, err error, shared bool) {
+	for {
+		g.mu.Lock()
+		if g.calls == nil {
+			g.calls = make(map[string]*flightCall)
+		}
+		if call, ok := g.calls[key]; ok {
+			g.mu.Unlock()
+
+			select {
+			case <-ctx.Done():
+				return &TTSResponse{Error: fmt.Errorf("请求失败: %w", ctx.Err())}, nil, false
+			case <-call.done:
+			}
+			if call.err == nil && call.resp != nil && isContextError(call.resp.Error) && ctx.Err() == nil {
+				continue
+			}
+			if call.resp == nil {
+				return nil, call.err, true
+			}
+			cp := *call.resp
+			return &cp, call.err, true
+		}
+
+		call := &flightCall{done: make(chan struct{})}
+		g.calls[key] = call
+		g.mu.Unlock()
+
+		call.resp, call.err = fn()
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+		return call.resp, call.err, false
+	}
+}
+
+// isContextError 判断错误是否由上下文取消或超时引起
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// ttsShared 经过缓存与请求合并发送 TTS 请求
+func (c *Client) ttsShared(ctx context.Context, req TTSRequest, opts []CallOption) (*TTSResponse, error) {
+	o := newCallOptions(opts)
+	start := time.Now()
+
+	// 文本长度、内容过滤与配额检查须在读取缓存前完成，使缓存命中与合并的调用同样受限
+	if err := c.checkShared(ctx, req, o); err != nil {
+		return &TTSResponse{Error: err, TextLimit: c.textLimit(req.Text)}, nil
+	}
+
+	key, err := c.cacheKey(req, o)
+	if err != nil {
+		return &TTSResponse{Error: err}, nil
+	}
+	if c.cache != nil {
+		if ttsResp := c.cachedResponse(ctx, key, o, start); ttsResp != nil {
+			ttsResp.TextLimit = c.textLimit(req.Text)
+			return ttsResp, nil
+		}
+	}
+
+	resp, err, shared := c.flights.do(ctx, key, func() (*TTSResponse, error) {
+		// 内容已在上面检查过，实际发送时不再重复调用过滤器
+		resp, err := c.tts(withContentChecked(ctx), req, opts...)
+		if err == nil && c.cache != nil {
+			c.storeResponse(ctx, key, req, resp)
+		}
+		return resp, err
+	})
+	if resp != nil && shared {
+		resp.Shared = true
+		resp.Elapsed = time.Since(start)
+	}
+	return resp, err
+}
+
+// checkShared 对经过缓存与合并的请求执行不依赖服务器的检查
+func (c *Client) checkShared(ctx context.Context, req TTSRequest, o *callOptions) error {
+	req = c.applyDefaults(req)
+	if err := c.limitText(&req); err != nil {
+		return err
+	}
+	if err := c.filterContent(ctx, req.Text, req.TextLang); err != nil {
+		return err
+	}
+	return c.checkQuota(req, o)
+}
@@ -0,0 +1,75 @@
+package gpt_sovits_go_sdk
+
+// 提供服务器端文本切分方法（text_split_method）的常量、校验与解析
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SplitMethod 代表服务器端的文本切分方法
+type SplitMethod string
+
+// 服务器支持的文本切分方法
+const (
+	Cut0 SplitMethod = "cut0" // 不切分
+	Cut1 SplitMethod = "cut1" // 每四句切分一次
+	Cut2 SplitMethod = "cut2" // 每 50 字切分一次
+	Cut3 SplitMethod = "cut3" // 按中文句号切分
+	Cut4 SplitMethod = "cut4" // 按英文句号切分
+	Cut5 SplitMethod = "cut5" // 按标点符号切分
+)
+
+// splitMethods 为全部切分方法及其说明与别名
+var splitMethods = []struct {
+	method      SplitMethod
+	alias       string
+	description string
+}{
+	{Cut0, "none", "不切分"},
+	{Cut1, "four_sentences", "每四句切分一次"},
+	{Cut2, "fifty_chars", "每 50 字切分一次"},
+	{Cut3, "chinese_period", "按中文句号切分"},
+	{Cut4, "english_period", "按英文句号切分"},
+	{Cut5, "punctuation", "按标点符号切分"},
+}
+
+// SplitMethods 返回服务器支持的全部切分方法
+func SplitMethods() []SplitMethod {
+	out := make([]SplitMethod, len(splitMethods))
+	for i, m := range splitMethods {
+		out[i] = m.method
+	}
+	return out
+}
+
+// Validate 校验切分方法是否受服务器支持
+func (m SplitMethod) Validate() error {
+	for _, sm := range splitMethods {
+		if sm.method == m {
+			return nil
+		}
+	}
+	return fmt.Errorf("不支持的文本切分方法 %q，支持 cut0~cut5", string(m))
+}
+
+// Description 返回切分方法的说明，未知方法返回空字符串
+func (m SplitMethod) Description() string {
+	for _, sm := range splitMethods {
+		if sm.method == m {
+			return sm.description
+		}
+	}
+	return ""
+}
+
+// ParseSplitMethod 解析切分方法，接受 "cut5"、"5" 或 "punctuation" 等别名，不区分大小写
+func ParseSplitMethod(s string) (SplitMethod, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, sm := range splitMethods {
+		if s == string(sm.method) || s == strings.TrimPrefix(string(sm.method), "cut") || s == sm.alias {
+			return sm.method, nil
+		}
+	}
+	return "", fmt.Errorf("不支持的文本切分方法 %q，支持 cut0~cut5", s)
+}
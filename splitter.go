@@ -0,0 +1,94 @@
+package gpt_sovits_go_sdk
+
+// 提供长文本切分，用于分块合成
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultMaxChunkChars 为默认的每块最大字符数
+const defaultMaxChunkChars = 100
+
+// TextSplitter 代表按句子边界将长文本切分为若干块的切分器
+type TextSplitter struct {
+	MaxChars int // 每块最大字符数（按 Unicode 字符计），<=0 时为 100
+}
+
+// sentenceEnds 为句末标点
+const sentenceEnds = "。！？!?.;；…\n"
+
+// clauseEnds 为句中可断开的标点
+const clauseEnds = "，,、：:"
+
+// Split 将文本按句子切分，并在不超过 MaxChars 的前提下合并相邻句子
+// 超长的单句会在逗号等标点处断开，仍然过长时按字符数硬切
+func (s *TextSplitter) Split(text string) []string {
+	maxChars := defaultMaxChunkChars
+	if s != nil && s.MaxChars > 0 {
+		maxChars = s.MaxChars
+	}
+
+	var chunks []string
+	var buf strings.Builder
+	bufLen := 0
+
+	flush := func() {
+		if t := strings.TrimSpace(buf.String()); t != "" {
+			chunks = append(chunks, t)
+		}
+		buf.Reset()
+		bufLen = 0
+	}
+
+	for _, sentence := range splitAfterAny(text, sentenceEnds) {
+		for _, piece := range limitLength(sentence, maxChars) {
+			n := utf8.RuneCountInString(piece)
+			if bufLen > 0 && bufLen+n > maxChars {
+				flush()
+			}
+			buf.WriteString(piece)
+			bufLen += n
+		}
+	}
+	flush()
+
+	return chunks
+}
+
+// limitLength 将超过 maxChars 的句子依次在句中标点处与按字符数切开
+func limitLength(sentence string, maxChars int) []string {
+	if utf8.RuneCountInString(sentence) <= maxChars {
+		return []string{sentence}
+	}
+
+	var pieces []string
+	for _, clause := range splitAfterAny(sentence, clauseEnds) {
+		runes := []rune(clause)
+		for len(runes) > maxChars {
+			pieces = append(pieces, string(runes[:maxChars]))
+			runes = runes[maxChars:]
+		}
+		if len(runes) > 0 {
+			pieces = append(pieces, string(runes))
+		}
+	}
+	return pieces
+}
+
+// splitAfterAny 在 seps 中任一字符之后切分文本，分隔符保留在前一段末尾
+func splitAfterAny(text, seps string) []string {
+	var parts []string
+	start := 0
+	for i, r := range text {
+		if strings.ContainsRune(seps, r) {
+			end := i + utf8.RuneLen(r)
+			parts = append(parts, text[start:end])
+			start = end
+		}
+	}
+	if start < len(text) {
+		parts = append(parts, text[start:])
+	}
+	return parts
+}
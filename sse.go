@@ -0,0 +1,146 @@
+package gpt_sovits_go_sdk
+
+// 提供将流式合成转换为 Server-Sent Events 的桥接，供浏览器 EventSource 消费
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SSEOptions 代表 SSE 桥接的配置
+type SSEOptions struct {
+	KeepAlive time.Duration // 无数据时发送保活注释的间隔，默认 15 秒，用于防止代理断开空闲连接
+	MaxChunk  int           // 每个音频事件携带的最大原始字节数，默认 16 KiB，超出时拆分为多个事件
+	Buffer    int           // 等待写出的片段数，默认 8，写满时暂停读取上游以形成背压
+
+	// ErrorMessage 返回错误事件中的错误信息，为空时使用 err.Error()
+	ErrorMessage func(err error) string
+}
+
+// SSE 事件名称
+const (
+	SSEEventAudio = "audio" // 音频片段，data 为 base64 编码的音频数据，id 为片段序号
+	SSEEventEnd   = "end"   // 合成完成，data 为 JSON 格式的 StreamStats
+	SSEEventError = "error" // 合成失败，data 为 {"error": "..."}
+)
+
+// TTSStreamSSE 以流式模式合成，并将音频片段以 SSE 事件写出到 w
+// 合成失败时先写出 error 事件再返回错误；w 必须支持 http.Flusher
+func (c *Client) TTSStreamSSE(ctx context.Context, req TTSRequest, w http.ResponseWriter, sse SSEOptions, opts ...CallOption) (*StreamStats, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errors.New("ResponseWriter 不支持 Flush，无法发送 SSE 事件")
+	}
+	if sse.KeepAlive <= 0 {
+		sse.KeepAlive = 15 * time.Second
+	}
+	if sse.MaxChunk <= 0 {
+		sse.MaxChunk = 16 << 10
+	}
+	if sse.Buffer <= 0 {
+		sse.Buffer = 8
+	}
+
+	// 写出响应头，禁用代理缓冲
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// 写出失败（客户端断开）时取消上游合成
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// 在后台读取上游，片段通过有界通道传递
+	chunks := make(chan []byte, sse.Buffer)
+	type result struct {
+		stats *StreamStats
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		stats, err := c.TTSStreamChunks(ctx, req, func(chunk []byte, _ int) error {
+			select {
+			case chunks <- chunk:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}, opts...)
+		close(chunks)
+		done <- result{stats, err}
+	}()
+
+	ticker := time.NewTicker(sse.KeepAlive)
+	defer ticker.Stop()
+
+	seq := 0
+	for chunks != nil {
+		select {
+		case chunk, ok := <-chunks:
+			if !ok {
+				chunks = nil
+				continue
+			}
+			// 按 MaxChunk 拆分，避免单个事件过大
+			for len(chunk) > 0 {
+				n := min(len(chunk), sse.MaxChunk)
+				if err := writeSSEEvent(w, SSEEventAudio, strconv.Itoa(seq), base64.StdEncoding.EncodeToString(chunk[:n])); err != nil {
+					cancel()
+					<-done
+					return nil, fmt.Errorf("写出 SSE 事件失败: %w", err)
+				}
+				chunk = chunk[n:]
+				seq++
+			}
+			flusher.Flush()
+			ticker.Reset(sse.KeepAlive)
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				cancel()
+				<-done
+				return nil, fmt.Errorf("写出 SSE 保活注释失败: %w", err)
+			}
+			flusher.Flush()
+		}
+	}
+
+	// 写出结束或错误事件
+	res := <-done
+	if res.err != nil {
+		msg := res.err.Error()
+		if sse.ErrorMessage != nil {
+			msg = sse.ErrorMessage(res.err)
+		}
+		data, _ := json.Marshal(map[string]string{"error": msg})
+		_ = writeSSEEvent(w, SSEEventError, "", string(data))
+		flusher.Flush()
+		return res.stats, res.err
+	}
+	data, _ := json.Marshal(res.stats)
+	if err := writeSSEEvent(w, SSEEventEnd, "", string(data)); err != nil {
+		return res.stats, fmt.Errorf("写出 SSE 事件失败: %w", err)
+	}
+	flusher.Flush()
+	return res.stats, nil
+}
+
+// writeSSEEvent 写出一个 SSE 事件，data 不得包含换行
+func writeSSEEvent(w http.ResponseWriter, event, id, data string) error {
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
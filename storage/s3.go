@@ -0,0 +1,284 @@
+package storage
+
+// 提供兼容 S3 协议的对象存储（AWS S3、MinIO 等），使用 AWS Signature V4 签名
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3 代表兼容 S3 协议的对象存储
+type S3 struct {
+	Endpoint     string        // 服务地址，如 https://s3.us-east-1.amazonaws.com 或 http://127.0.0.1:9000
+	Region       string        // 区域，MinIO 通常为 us-east-1
+	Bucket       string        // 存储桶
+	AccessKey    string        // 访问密钥 ID
+	SecretKey    string        // 访问密钥
+	SessionToken string        // 临时凭证的会话令牌，可选
+	PathStyle    bool          // 使用路径形式（endpoint/bucket/key）访问，MinIO 需要开启
+	Prefix       string        // 对象键前缀
+	PublicURL    string        // 公开访问的基础 URL（如 CDN 地址），设置时 URL 返回该地址下的链接
+	URLExpiry    time.Duration // 未设置 PublicURL 时预签名 URL 的有效期，默认 7 天（S3 允许的最大值）
+	HTTPClient   *http.Client  // 发送请求的 HTTP 客户端，为空时使用 http.DefaultClient
+}
+
+// s3Service 为签名使用的服务名称
+const s3Service = "s3"
+
+// unsignedPayload 表示不对请求体签名，用于预签名 URL
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// objectURL 返回对象的请求 URL
+func (s *S3) objectURL(key string) (*url.URL, error) {
+	k, err := cleanKey(s.Prefix + key)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(s.Endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("S3 服务地址无效: %q", s.Endpoint)
+	}
+
+	if s.PathStyle {
+		u.Path = "/" + s.Bucket + "/" + k
+	} else {
+		u.Host = s.Bucket + "." + u.Host
+		u.Path = "/" + k
+	}
+	// 按签名规范严格转义路径，使发送的路径与签名一致
+	u.RawPath = awsPath(u.Path)
+	return u, nil
+}
+
+// Put 实现 Storage，对象内容先读入内存以计算签名所需的摘要
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("读取对象内容失败: %w", err)
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, key, data, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("上传对象 %s 失败，状态码 %d: %s", key, resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// Get 实现 Storage
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	default:
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("下载对象 %s 失败，状态码 %d: %s", key, resp.StatusCode, string(body))
+	}
+}
+
+// URL 实现 Storage，设置了 PublicURL 时返回公开链接，否则返回预签名的 GET 链接
+func (s *S3) URL(ctx context.Context, key string) (string, error) {
+	if s.PublicURL != "" {
+		k, err := cleanKey(s.Prefix + key)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(s.PublicURL, "/") + "/" + escapePath(k), nil
+	}
+
+	u, err := s.objectURL(key)
+	if err != nil {
+		return "", err
+	}
+
+	expiry := s.URLExpiry
+	if expiry <= 0 {
+		expiry = 7 * 24 * time.Hour
+	}
+
+	return s.presign(u, time.Now().UTC(), expiry), nil
+}
+
+// presign 将签名参数放在查询字符串中，返回预签名的 GET 链接
+func (s *S3) presign(u *url.URL, now time.Time, expiry time.Duration) string {
+	amzDate := now.Format("20060102T150405Z")
+	scope := s.scope(now)
+	q := u.Query()
+	q.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	q.Set("X-Amz-Credential", s.AccessKey+"/"+scope)
+	q.Set("X-Amz-Date", amzDate)
+	q.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	q.Set("X-Amz-SignedHeaders", "host")
+	if s.SessionToken != "" {
+		q.Set("X-Amz-Security-Token", s.SessionToken)
+	}
+	u.RawQuery = canonicalQuery(q)
+
+	canonical := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		u.RawQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+	u.RawQuery += "&X-Amz-Signature=" + s.signature(now, amzDate, scope, canonical)
+	return u.String()
+}
+
+// do 发送带 Signature V4 签名的对象请求
+func (s *S3) do(ctx context.Context, method, key string, body []byte, contentType string) (*http.Response, error) {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, body)
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求对象存储失败: %w", err)
+	}
+	return resp, nil
+}
+
+// sign 为请求添加 Authorization 等签名请求头
+func (s *S3) sign(req *http.Request, body []byte) {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.SessionToken)
+	}
+
+	// 参与签名的请求头按名称排序
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := s.scope(now)
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKey, scope, signedHeaders, s.signature(now, amzDate, scope, canonical)))
+}
+
+// scope 返回签名的凭证范围
+func (s *S3) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.Region + "/" + s3Service + "/aws4_request"
+}
+
+// signature 计算规范请求的签名
+func (s *S3) signature(now time.Time, amzDate, scope, canonical string) string {
+	sum := sha256.Sum256([]byte(canonical))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(sum[:])
+
+	// 逐级派生签名密钥
+	key := hmacSHA256([]byte("AWS4"+s.SecretKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// hmacSHA256 计算 HMAC-SHA256
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// canonicalQuery 按签名规范编码并排序查询参数
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		values := append([]string(nil), q[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, awsEscape(k)+"="+awsEscape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsPath 逐段转义对象路径，保留路径分隔符
+func awsPath(p string) string {
+	segs := strings.Split(p, "/")
+	for i, seg := range segs {
+		segs[i] = awsEscape(seg)
+	}
+	return strings.Join(segs, "/")
+}
+
+// awsEscape 按 RFC 3986 转义，仅保留非保留字符
+func awsEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
@@ -0,0 +1,131 @@
+// Package storage 提供合成结果的存储后端，批量任务可将较大的音频输出存放于此并返回稳定的 URL
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound 表示对象不存在
+var ErrNotFound = errors.New("对象不存在")
+
+// Storage 代表按键存取音频对象的存储后端，实现必须是并发安全的
+type Storage interface {
+	// Put 写入对象，已存在时覆盖
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	// Get 读取对象，不存在时返回 ErrNotFound，调用方负责关闭
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// URL 返回访问对象的 URL
+	URL(ctx context.Context, key string) (string, error)
+}
+
+// cleanKey 规范化对象键，拒绝空键与越出根目录的路径
+func cleanKey(key string) (string, error) {
+	k := path.Clean("/" + strings.ReplaceAll(key, "\\", "/"))
+	k = strings.TrimPrefix(k, "/")
+	if k == "" || k == "." {
+		return "", fmt.Errorf("对象键无效: %q", key)
+	}
+	return k, nil
+}
+
+// Local 代表本地文件系统上的存储，对象保存在 Dir 下与键对应的路径中
+type Local struct {
+	Dir     string // 根目录
+	BaseURL string // 对外访问的基础 URL（如静态文件服务地址），为空时返回 file:// URL
+}
+
+// NewLocal 创建一个本地存储，目录不存在时自动创建
+func NewLocal(dir, baseURL string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("创建存储目录失败: %w", err)
+	}
+	return &Local{Dir: dir, BaseURL: baseURL}, nil
+}
+
+// path 返回对象的文件路径
+func (s *Local) path(key string) (string, string, error) {
+	k, err := cleanKey(key)
+	if err != nil {
+		return "", "", err
+	}
+	return k, filepath.Join(s.Dir, filepath.FromSlash(k)), nil
+}
+
+// Put 实现 Storage，先写入临时文件再重命名，避免读取到不完整的对象
+func (s *Local) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	_, p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("创建目录失败: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("创建临时文件失败: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("写入临时文件失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("关闭临时文件失败: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return fmt.Errorf("重命名文件失败: %w", err)
+	}
+	return nil
+}
+
+// Get 实现 Storage
+func (s *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	_, p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("打开文件失败: %w", err)
+	}
+	return f, nil
+}
+
+// URL 实现 Storage
+func (s *Local) URL(ctx context.Context, key string) (string, error) {
+	k, p, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	if s.BaseURL != "" {
+		return strings.TrimSuffix(s.BaseURL, "/") + "/" + escapePath(k), nil
+	}
+
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return "", fmt.Errorf("获取绝对路径失败: %w", err)
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String(), nil
+}
+
+// escapePath 按段转义对象键，保留路径分隔符
+func escapePath(key string) string {
+	segs := strings.Split(key, "/")
+	for i, seg := range segs {
+		segs[i] = url.PathEscape(seg)
+	}
+	return strings.Join(segs, "/")
+}
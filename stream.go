@@ -0,0 +1,178 @@
+package gpt_sovits_go_sdk
+
+// 提供流式响应的增量处理
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// TTSStreamTo 以流式模式发送 TTS 请求，并将音频数据边接收边写入 w，返回写入的字节数
+func (c *Client) TTSStreamTo(ctx context.Context, req TTSRequest, w io.Writer, opts ...CallOption) (int64, error) {
+	o := newCallOptions(opts)
+	start := time.Now()
+
+	// 应用单次调用超时
+	ctx, cancel := o.withTimeout(ctx)
+	defer cancel()
+
+	// 登记进行中的请求，切换模型期间在此等待
+	if err := c.gate.enter(ctx); err != nil {
+		return 0, fmt.Errorf("请求失败: %w", err)
+	}
+	defer c.gate.leave()
+
+	// 打开流式响应
+	resp, _, err := c.openStream(ctx, req, o)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// 边读边写，避免在内存中缓存完整音频
+	dst := w
+	if o.progress != nil {
+		dst = &progressWriter{w: w, fn: o.progress, start: start}
+	}
+
+	n, err := io.Copy(dst, resp.Body)
+	if err != nil && n > 0 && ctx.Err() != nil {
+		// 上下文取消时已写出的音频仍可使用
+		return n, &PartialError{Bytes: n, Err: ctx.Err()}
+	}
+	if err != nil {
+		return n, fmt.Errorf("写出音频数据失败: %w", err)
+	}
+
+	return n, nil
+}
+
+// StreamStats 代表流式合成的统计信息
+type StreamStats struct {
+	Bytes     int64  `json:"bytes"`      // 接收的总字节数
+	Chunks    int    `json:"chunks"`     // 接收的片段数
+	RequestID string `json:"request_id"` // 请求 ID
+	Seed      int    `json:"seed"`       // 实际发送的随机种子
+}
+
+// TTSStreamChunks 以流式模式发送 TTS 请求，每收到一个音频片段调用一次 fn
+// 片段的生成节奏由请求中的 FragmentInterval 控制，fn 返回错误时停止接收并返回该错误
+func (c *Client) TTSStreamChunks(ctx context.Context, req TTSRequest, fn func(chunk []byte, seq int) error, opts ...CallOption) (*StreamStats, error) {
+	o := newCallOptions(opts)
+	stats := &StreamStats{}
+	start := time.Now()
+
+	// 应用单次调用超时
+	ctx, cancel := o.withTimeout(ctx)
+	defer cancel()
+
+	// 登记进行中的请求，切换模型期间在此等待
+	if err := c.gate.enter(ctx); err != nil {
+		return stats, fmt.Errorf("请求失败: %w", err)
+	}
+	defer c.gate.leave()
+
+	// 打开流式响应
+	resp, seed, err := c.openStream(ctx, req, o)
+	if err != nil {
+		return stats, err
+	}
+	stats.Seed = seed
+	defer resp.Body.Close()
+	stats.RequestID = resp.Header.Get(RequestIDHeader)
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			// 复制片段，使回调可以安全持有数据
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+
+			if err := fn(chunk, stats.Chunks); err != nil {
+				return stats, err
+			}
+			stats.Bytes += int64(n)
+			stats.Chunks++
+
+			if o.progress != nil {
+				o.progress(stats.Bytes, time.Since(start))
+			}
+		}
+
+		if readErr == io.EOF {
+			return stats, nil
+		}
+		if readErr != nil && stats.Bytes > 0 {
+			// 已交给回调的片段仍可使用
+			return stats, &PartialError{Bytes: stats.Bytes, Err: readErr}
+		}
+		if readErr != nil {
+			return stats, fmt.Errorf("读取音频片段失败: %w", readErr)
+		}
+	}
+}
+
+// openStream 以流式模式发送 TTS 请求并检查响应状态，返回实际发送的随机种子，调用方负责关闭响应体
+func (c *Client) openStream(ctx context.Context, req TTSRequest, o *callOptions) (*http.Response, int, error) {
+	resp, seed, err := c.sendStream(ctx, req, o)
+
+	// 流式调用在响应开始时记录审计
+	var ttsResp *TTSResponse
+	if err == nil {
+		ttsResp = &TTSResponse{StatusCode: resp.StatusCode, RequestID: resp.Header.Get(RequestIDHeader)}
+	}
+	c.audit(ctx, req, o, ttsResp, err, true)
+	return resp, seed, err
+}
+
+// sendStream 发送流式 TTS 请求并检查响应状态
+func (c *Client) sendStream(ctx context.Context, req TTSRequest, o *callOptions) (*http.Response, int, error) {
+	// 强制启用流式响应
+	req.StreamingMode = true
+
+	// 构建HTTP请求
+	httpReq, sent, err := c.newTTSRequest(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := c.authorizeVoice(ctx, sent, o); err != nil {
+		return nil, 0, err
+	}
+	if err := c.checkQuota(sent, o); err != nil {
+		return nil, 0, err
+	}
+
+	// 附加自定义请求头与请求 ID
+	setCallHeaders(httpReq, o)
+	requestID := setRequestID(httpReq, o)
+
+	// 发送请求
+	resp, err := c.do(httpReq)
+	if err != nil {
+		err = fmt.Errorf("请求失败（请求 ID %s）: %w", requestID, err)
+		c.recordUsage(sent, o, &TTSResponse{Error: err}, true)
+		return nil, 0, err
+	}
+
+	// 检查响应状态
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		err := readAPIError(resp, responseRequestID(resp.Header, requestID))
+		c.recordUsage(sent, o, &TTSResponse{Error: err}, true)
+		return nil, 0, err
+	}
+
+	// 服务器未回显时记录发送的请求 ID
+	if resp.Header.Get(RequestIDHeader) == "" {
+		resp.Header.Set(RequestIDHeader, requestID)
+	}
+
+	// 流式调用在响应开始时按字符数记录用量
+	c.recordUsage(sent, o, nil, true)
+
+	return resp, sent.Seed, nil
+}
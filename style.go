@@ -0,0 +1,104 @@
+package gpt_sovits_go_sdk
+
+// 提供音色的情感/风格参数配置
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrStyleNotFound 表示音色未定义该风格
+var ErrStyleNotFound = errors.New("风格未定义")
+
+// Style 代表音色的一种情感或风格，打包采样参数与可选的参考音频变体
+// 零值字段保持请求中的原值
+type Style struct {
+	Temperature      float64  `json:"temperature,omitempty"`         // 温度
+	TopK             int      `json:"top_k,omitempty"`               // top-k 采样
+	TopP             float64  `json:"top_p,omitempty"`               // top-p 采样
+	SpeedFactor      float64  `json:"speed_factor,omitempty"`        // 语速
+	RefAudioPath     string   `json:"ref_audio_path,omitempty"`      // 该风格的参考音频，为空时使用音色默认参考音频
+	AuxRefAudioPaths []string `json:"aux_ref_audio_paths,omitempty"` // 该风格的辅助参考音频
+	PromptText       string   `json:"prompt_text,omitempty"`         // 该风格参考音频的提示文本
+	PromptLang       string   `json:"prompt_lang,omitempty"`         // 该风格参考音频提示文本的语言
+}
+
+// Apply 将风格参数写入请求
+func (s *Style) Apply(req *TTSRequest) {
+	if s.Temperature != 0 {
+		req.Temperature = s.Temperature
+	}
+	if s.TopK != 0 {
+		req.TopK = s.TopK
+	}
+	if s.TopP != 0 {
+		req.TopP = s.TopP
+	}
+	if s.SpeedFactor != 0 {
+		req.SpeedFactor = s.SpeedFactor
+	}
+
+	// 切换参考音频时同时替换提示文本，避免与原参考音频不匹配
+	if s.RefAudioPath != "" {
+		req.RefAudioPath = s.RefAudioPath
+		req.AuxRefAudioPaths = s.AuxRefAudioPaths
+		req.PromptText = s.PromptText
+		if s.PromptLang != "" {
+			req.PromptLang = s.PromptLang
+		}
+	}
+}
+
+// ApplyStyle 将音色及其指定风格写入请求，style 为空时仅应用音色
+func (v *Voice) ApplyStyle(req *TTSRequest, style string) error {
+	v.Apply(req)
+	if style == "" {
+		return nil
+	}
+
+	s, ok := v.Styles[style]
+	if !ok {
+		return fmt.Errorf("%w: 音色 %s 没有风格 %s", ErrStyleNotFound, v.Name, style)
+	}
+	s.Apply(req)
+	return nil
+}
+
+// WithVoices 为客户端设置音色注册表，供 Speak 与 SpeakStyle 按名称查找音色
+func WithVoices(r *VoiceRegistry) ClientOption {
+	return func(c *Client) {
+		c.voices = r
+	}
+}
+
+// Speak 使用注册表中的音色合成文本
+func (c *Client) Speak(ctx context.Context, voice, text string, opts ...CallOption) (*TTSResponse, error) {
+	return c.SpeakStyle(ctx, voice, "", text, opts...)
+}
+
+// SpeakStyle 使用注册表中的音色及其指定风格合成文本，文本语言取客户端默认参数
+func (c *Client) SpeakStyle(ctx context.Context, voice, style, text string, opts ...CallOption) (*TTSResponse, error) {
+	if c.voices == nil {
+		return nil, fmt.Errorf("客户端未设置音色注册表，请使用 WithVoices")
+	}
+
+	v, err := c.voices.Get(voice)
+	if err != nil {
+		return nil, err
+	}
+
+	req := TTSRequest{Text: text}
+	if err := v.ApplyStyle(&req, style); err != nil {
+		return nil, err
+	}
+
+	// 应用音色专用的发音词典
+	if req.Text, err = v.ProcessText(req.Text, req.TextLang); err != nil {
+		return nil, err
+	}
+
+	// 用量记录按音色名称统计
+	opts = append([]CallOption{withUsageVoice(v, v.GPTWeights)}, opts...)
+	return c.TTS(ctx, req, opts...)
+}
@@ -0,0 +1,83 @@
+package gpt_sovits_go_sdk
+
+// 提供 SRT 与 WebVTT 字幕生成
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Cue 代表一条字幕
+type Cue struct {
+	Start time.Duration // 起始时间
+	End   time.Duration // 结束时间
+	Text  string        // 字幕文本
+}
+
+// Cues 返回长文本合成结果中每块对应的字幕
+func (r *LongTextResult) Cues() []Cue {
+	cues := make([]Cue, len(r.Chunks))
+	for i, ch := range r.Chunks {
+		cues[i] = Cue{Start: ch.Start, End: ch.End, Text: ch.Text}
+	}
+	return cues
+}
+
+// Cues 返回对话合成结果中每行台词对应的字幕
+func (r *DialogueResult) Cues() []Cue {
+	cues := make([]Cue, len(r.Lines))
+	for i, line := range r.Lines {
+		cues[i] = Cue{Start: line.Start, End: line.End, Text: line.Text}
+	}
+	return cues
+}
+
+// WriteSRT 将字幕以 SRT 格式写入 w
+func WriteSRT(w io.Writer, cues []Cue) error {
+	for i, cue := range cues {
+		_, err := fmt.Fprintf(w, "%d\n%s --> %s\n%s\n\n",
+			i+1, formatTimestamp(cue.Start, ","), formatTimestamp(cue.End, ","), cueText(cue.Text))
+		if err != nil {
+			return fmt.Errorf("写入字幕失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// WriteVTT 将字幕以 WebVTT 格式写入 w
+func WriteVTT(w io.Writer, cues []Cue) error {
+	if _, err := io.WriteString(w, "WEBVTT\n\n"); err != nil {
+		return fmt.Errorf("写入字幕失败: %w", err)
+	}
+	for _, cue := range cues {
+		_, err := fmt.Fprintf(w, "%s --> %s\n%s\n\n",
+			formatTimestamp(cue.Start, "."), formatTimestamp(cue.End, "."), cueText(cue.Text))
+		if err != nil {
+			return fmt.Errorf("写入字幕失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// formatTimestamp 将时间格式化为 HH:MM:SS<sep>mmm
+func formatTimestamp(d time.Duration, sep string) string {
+	if d < 0 {
+		d = 0
+	}
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// cueText 清理字幕文本，空行会提前结束字幕块，因此需要折叠
+func cueText(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,133 @@
+package gpt_sovits_go_sdk
+
+// 提供参数网格扫描，用于快速调试新音色模型的采样参数
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SweepResult 代表参数网格中一组取值的合成结果
+type SweepResult struct {
+	Label    string         // 参数标签，如 "temperature=0.8,top_k=5"
+	Params   map[string]any // 本组参数取值，键为 JSON 字段名
+	Request  TTSRequest     // 实际提交的请求
+	Response *TTSResponse   // 合成响应
+	Err      error          // 构建请求或合成失败的原因
+}
+
+// Sweep 以 base 为基础，对 grid 中各参数取值的笛卡尔积逐组合成同一文本，返回带标签的结果
+// grid 的键为 TTSRequest 的 JSON 字段名（如 "temperature"、"top_k"、"speed_factor"），
+// 结果按键名排序后的取值顺序排列；并发数由 WithAsyncConcurrency 限制
+// base 未设置随机种子时所有组合共用一个随机种子，使差异只来自被扫描的参数
+func (c *Client) Sweep(ctx context.Context, base TTSRequest, grid map[string][]any, opts ...CallOption) ([]SweepResult, error) {
+	// 校验参数名
+	keys := make([]string, 0, len(grid))
+	fields := jsonFieldNames()
+	for k, values := range grid {
+		if !fields[k] {
+			return nil, fmt.Errorf("未知的请求参数: %s", k)
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("参数 %s 没有取值", k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if base.Seed <= 0 {
+		base.Seed = randomSeed()
+	}
+
+	// 生成所有组合并提交给异步调度器
+	combos := cartesian(keys, grid)
+	results := make([]SweepResult, len(combos))
+	futures := make([]*TTSFuture, len(combos))
+	for i, params := range combos {
+		results[i].Params = params
+		results[i].Label = sweepLabel(keys, params)
+
+		req, err := withParams(base, params)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Request = req
+		futures[i] = c.TTSAsync(ctx, req, opts...)
+	}
+
+	// 收集结果
+	for i, f := range futures {
+		if f == nil {
+			continue
+		}
+		resp, err := f.Result()
+		results[i].Response = resp
+		if err == nil && resp != nil {
+			err = resp.Err()
+		}
+		results[i].Err = err
+	}
+
+	return results, ctx.Err()
+}
+
+// cartesian 按键顺序生成参数取值的笛卡尔积
+func cartesian(keys []string, grid map[string][]any) []map[string]any {
+	combos := []map[string]any{{}}
+	for _, k := range keys {
+		var next []map[string]any
+		for _, combo := range combos {
+			for _, v := range grid[k] {
+				m := make(map[string]any, len(combo)+1)
+				for ck, cv := range combo {
+					m[ck] = cv
+				}
+				m[k] = v
+				next = append(next, m)
+			}
+		}
+		combos = next
+	}
+	return combos
+}
+
+// sweepLabel 生成参数标签
+func sweepLabel(keys []string, params map[string]any) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%v", k, params[k])
+	}
+	return strings.Join(parts, ",")
+}
+
+// withParams 以 JSON 字段名覆盖请求中的参数
+func withParams(base TTSRequest, params map[string]any) (TTSRequest, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return base, fmt.Errorf("参数序列化失败: %w", err)
+	}
+
+	req := base
+	if err := json.Unmarshal(data, &req); err != nil {
+		return base, fmt.Errorf("参数类型不匹配: %w", err)
+	}
+	return req, nil
+}
+
+// jsonFieldNames 返回 TTSRequest 的全部 JSON 字段名
+func jsonFieldNames() map[string]bool {
+	names := make(map[string]bool)
+	t := reflect.TypeOf(TTSRequest{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,86 @@
+package gpt_sovits_go_sdk
+
+// 定义合成器接口，便于下游代码在单元测试中替换 SDK，或在单机客户端、连接池与自定义实现之间切换
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Synthesizer 代表文本转语音服务，*Client 与 *PoolClient 均实现该接口
+// 实现必须是并发安全的；错误约定与 Client 相同：请求无法构建等调用方错误通过第二个返回值返回，
+// 合成失败（含服务器错误与网络错误）通过 TTSResponse.Error 返回
+type Synthesizer interface {
+	// TTS 发送文本转语音请求并读取完整音频
+	TTS(ctx context.Context, req TTSRequest, opts ...CallOption) (*TTSResponse, error)
+	// TTSStreamTo 以流式模式合成并将音频边接收边写入 w，返回写入的字节数
+	TTSStreamTo(ctx context.Context, req TTSRequest, w io.Writer, opts ...CallOption) (int64, error)
+	// Control 发送控制命令
+	Control(ctx context.Context, command ControlCommand) error
+	// SetGPTWeights 切换 GPT 模型权重
+	SetGPTWeights(ctx context.Context, weightsPath string) error
+	// SetSoVITSWeights 切换 SoVITS 模型权重
+	SetSoVITSWeights(ctx context.Context, weightsPath string) error
+}
+
+var (
+	_ Synthesizer = (*Client)(nil)
+	_ Synthesizer = (*PoolClient)(nil)
+)
+
+// TTSStreamTo 选择一个后端以流式模式合成，已开始写出音频后无法切换后端，因此不重试
+func (p *PoolClient) TTSStreamTo(ctx context.Context, req TTSRequest, w io.Writer, opts ...CallOption) (int64, error) {
+	b := p.pick(nil)
+	if b == nil {
+		return 0, ErrNoBackend
+	}
+
+	b.pending.Add(1)
+	defer b.pending.Add(-1)
+	return b.client.TTSStreamTo(ctx, req, w, opts...)
+}
+
+// Control 向全部后端发送控制命令
+func (p *PoolClient) Control(ctx context.Context, command ControlCommand) error {
+	return p.broadcast(func(c *Client) error {
+		return c.Control(ctx, command)
+	})
+}
+
+// SetGPTWeights 为全部后端切换 GPT 模型权重
+func (p *PoolClient) SetGPTWeights(ctx context.Context, weightsPath string) error {
+	return p.broadcast(func(c *Client) error {
+		return c.SetGPTWeights(ctx, weightsPath)
+	})
+}
+
+// SetSoVITSWeights 为全部后端切换 SoVITS 模型权重
+func (p *PoolClient) SetSoVITSWeights(ctx context.Context, weightsPath string) error {
+	return p.broadcast(func(c *Client) error {
+		return c.SetSoVITSWeights(ctx, weightsPath)
+	})
+}
+
+// broadcast 对全部后端并行执行 fn，返回各后端错误的合并
+func (p *PoolClient) broadcast(fn func(c *Client) error) error {
+	if len(p.backends) == 0 {
+		return ErrNoBackend
+	}
+
+	errs := make([]error, len(p.backends))
+	var wg sync.WaitGroup
+	for i, b := range p.backends {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(b.client); err != nil {
+				errs[i] = fmt.Errorf("后端 %s: %w", b.client.BaseURL, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,149 @@
+package gpt_sovits_go_sdk
+
+// 提供基于 text/template 的动态文本合成，适用于语音导航与通知播报
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/textproc"
+)
+
+// TemplateFuncs 返回模板中可用的规范化函数，读法按 lang 选择中文或英文
+//
+//   - spell: 逐字读出，数字逐位转换，如 {{spell .Code}} 将 "A12" 读作 "A 一 二"
+//   - number: 将整数或小数转换为读法，如 {{number .Amount}} 将 3.5 读作 "三点五"
+//   - pause: 插入停顿标点，{{pause}} 为短停顿，{{pause 2}} 为句末停顿，3 及以上为长停顿
+func TemplateFuncs(lang string) template.FuncMap {
+	english := strings.TrimPrefix(lang, "all_") == "en"
+
+	return template.FuncMap{
+		"spell": func(v any) string {
+			return spell(fmt.Sprint(v), english)
+		},
+		"number": func(v any) (string, error) {
+			return numberWords(v, english)
+		},
+		"pause": func(level ...int) string {
+			return pause(level, english)
+		},
+	}
+}
+
+// RenderTemplate 使用 TemplateFuncs 渲染模板，引用数据中不存在的键时返回错误
+func RenderTemplate(lang, tmpl string, data any) (string, error) {
+	t, err := template.New("tts").Funcs(TemplateFuncs(lang)).Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("解析模板失败: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("渲染模板失败: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// TTSTemplate 渲染模板并合成结果，渲染的文本取代 req.Text
+// 函数读法按 req.TextLang 选择，未设置时使用客户端默认参数中的语言
+func (c *Client) TTSTemplate(ctx context.Context, tmpl string, data any, req TTSRequest, opts ...CallOption) (*TTSResponse, error) {
+	lang := req.TextLang
+	if lang == "" {
+		lang = c.Defaults().TextLang
+	}
+
+	text, err := RenderTemplate(lang, tmpl, data)
+	if err != nil {
+		return nil, err
+	}
+	if text == "" {
+		return nil, fmt.Errorf("模板渲染结果为空")
+	}
+
+	req.Text = text
+	return c.TTS(ctx, req, opts...)
+}
+
+// spell 将字符串逐字分开读出，数字逐位转换为读法，空白与分隔符被忽略
+func spell(s string, english bool) string {
+	parts := make([]string, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			if english {
+				parts = append(parts, textproc.EnglishNumber(int64(r-'0')))
+			} else {
+				parts = append(parts, textproc.ChineseDigits(string(r)))
+			}
+		case unicode.IsLetter(r):
+			parts = append(parts, strings.ToUpper(string(r)))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// numberWords 将整数、浮点数或数字串转换为读法
+func numberWords(v any, english bool) (string, error) {
+	var s string
+	switch n := v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		s = fmt.Sprint(n)
+	case float32:
+		s = strconv.FormatFloat(float64(n), 'f', -1, 32)
+	case float64:
+		s = strconv.FormatFloat(n, 'f', -1, 64)
+	case string:
+		s = strings.ReplaceAll(strings.TrimSpace(n), ",", "")
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return "", fmt.Errorf("number: 无效的数字 %q", n)
+		}
+	default:
+		return "", fmt.Errorf("number: 不支持的类型 %T", v)
+	}
+
+	// 负数单独处理符号
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimLeft(s, "+-")
+
+	var words string
+	if english {
+		words = textproc.EnglishDecimal(s)
+		if negative {
+			words = "minus " + words
+		}
+	} else {
+		words = textproc.ChineseDecimal(s)
+		if negative {
+			words = "负" + words
+		}
+	}
+	return words, nil
+}
+
+// pause 按停顿级别返回标点，GPT-SoVITS 在标点处切分并产生停顿
+func pause(level []int, english bool) string {
+	n := 1
+	if len(level) > 0 {
+		n = level[0]
+	}
+
+	switch {
+	case n <= 1 && english:
+		return ", "
+	case n <= 1:
+		return "，"
+	case n == 2 && english:
+		return ". "
+	case n == 2:
+		return "。"
+	case english:
+		return "... "
+	default:
+		return "……"
+	}
+}
@@ -0,0 +1,152 @@
+package gpt_sovits_go_sdk
+
+// 提供单次请求的文本长度限制，过长的请求可能耗尽服务器显存，发送前按策略报错、截断或自动分块
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/audio"
+)
+
+// ErrTextTooLong 表示请求文本超过 WithMaxTextLength 设置的长度
+var ErrTextTooLong = errors.New("文本过长")
+
+// TextTooLongError 代表请求文本超过长度限制，可用 errors.Is(err, ErrTextTooLong) 判断
+type TextTooLongError struct {
+	Length int    // 文本字符数
+	Max    int    // 允许的最大字符数
+	Reason string // 无法按策略处理时的原因，为空时表示策略为报错
+}
+
+// Error 实现 error 接口
+func (e *TextTooLongError) Error() string {
+	msg := fmt.Sprintf("文本过长: %d 字，上限 %d 字", e.Length, e.Max)
+	if e.Reason != "" {
+		msg += "，" + e.Reason
+	}
+	return msg
+}
+
+// Is 使 errors.Is(err, ErrTextTooLong) 成立
+func (e *TextTooLongError) Is(target error) bool {
+	return target == ErrTextTooLong
+}
+
+// TextLimitPolicy 代表文本超过长度限制时的处理策略
+type TextLimitPolicy string
+
+// 文本长度限制的处理策略
+const (
+	TextLimitError    TextLimitPolicy = "error"    // 返回 *TextTooLongError，不发送请求
+	TextLimitTruncate TextLimitPolicy = "truncate" // 在不超过上限的最后一个句子边界处截断，单句过长时在逗号处或按字数截断
+	TextLimitChunk    TextLimitPolicy = "chunk"    // 按句子边界切分为多个请求依次合成并拼接，仅 TTS 的 wav 与 raw 输出支持
+)
+
+// WithMaxTextLength 限制单次请求的文本字符数（按调用方传入的文本计算，不含文本预处理的改写），超过时按 policy 处理
+// 触发的策略记录在 TTSResponse.TextLimit 中；流式接口与 TTSGet 不支持自动分块，对其 TextLimitChunk 按 TextLimitError 处理
+func WithMaxTextLength(maxChars int, policy TextLimitPolicy) ClientOption {
+	return func(c *Client) {
+		c.maxTextLength = maxChars
+		c.textLimitPolicy = policy
+	}
+}
+
+// textLimit 返回文本将触发的策略，未超过限制时返回空字符串
+func (c *Client) textLimit(text string) TextLimitPolicy {
+	if c.maxTextLength <= 0 || utf8.RuneCountInString(text) <= c.maxTextLength {
+		return ""
+	}
+	if c.textLimitPolicy == "" {
+		return TextLimitError
+	}
+	return c.textLimitPolicy
+}
+
+// limitText 按策略处理过长的请求文本，自动分块须在调用前完成
+func (c *Client) limitText(req *TTSRequest) error {
+	switch c.textLimit(req.Text) {
+	case "":
+		return nil
+	case TextLimitTruncate:
+		req.Text = (&TextSplitter{MaxChars: c.maxTextLength}).Split(req.Text)[0]
+		return nil
+	case TextLimitChunk:
+		return &TextTooLongError{Length: utf8.RuneCountInString(req.Text), Max: c.maxTextLength, Reason: "该接口不支持自动分块"}
+	default:
+		return &TextTooLongError{Length: utf8.RuneCountInString(req.Text), Max: c.maxTextLength}
+	}
+}
+
+// ttsChunked 将过长的文本切分为多个请求依次合成，并将音频拼接为一个响应
+func (c *Client) ttsChunked(ctx context.Context, req TTSRequest, opts []CallOption) (*TTSResponse, error) {
+	start := time.Now()
+	mediaType := c.applyDefaults(req).MediaType
+	if mediaType != "" && mediaType != MediaTypeWAV && mediaType != MediaTypeRAW {
+		return &TTSResponse{Error: &TextTooLongError{
+			Length: utf8.RuneCountInString(req.Text),
+			Max:    c.maxTextLength,
+			Reason: fmt.Sprintf("%s 格式不支持自动分块拼接", mediaType),
+		}}, nil
+	}
+
+	var (
+		first    *TTSResponse
+		raw      []byte
+		segments []*audio.WAV
+	)
+	for i, text := range (&TextSplitter{MaxChars: c.maxTextLength}).Split(req.Text) {
+		chunkReq := req
+		chunkReq.Text = text
+		resp, err := c.TTS(ctx, chunkReq, opts...)
+		if err != nil {
+			return resp, err
+		}
+		if err := resp.Err(); err != nil {
+			resp.Error = fmt.Errorf("第 %d 块: %w", i+1, err)
+			resp.TextLimit = TextLimitChunk
+			return resp, nil
+		}
+		if first == nil {
+			first = resp
+		}
+
+		if resp.Format == MediaTypeRAW {
+			raw = append(raw, resp.AudioData...)
+			continue
+		}
+		seg, err := audio.ParseWAV(resp.AudioData)
+		if err != nil {
+			return &TTSResponse{Error: fmt.Errorf("第 %d 块: %w", i+1, err), TextLimit: TextLimitChunk}, nil
+		}
+		segments = append(segments, seg)
+	}
+
+	// 拼接各块音频，沿用第一块的响应头与种子
+	ttsResp := *first
+	ttsResp.TextLimit = TextLimitChunk
+	ttsResp.Timing = nil
+	ttsResp.FromCache, ttsResp.Shared = false, false
+	ttsResp.AudioData = raw
+	if raw == nil {
+		stitched, err := audio.Concat(segments, audio.ConcatOptions{})
+		if err != nil {
+			return &TTSResponse{Error: err, TextLimit: TextLimitChunk}, nil
+		}
+		ttsResp.AudioData = stitched.Bytes()
+	}
+	c.fillAudioInfo(&ttsResp)
+	ttsResp.Samples, ttsResp.Duration, _ = audioLength(&ttsResp)
+	ttsResp.Elapsed = time.Since(start)
+	ttsResp.RTF = 0
+	if ttsResp.Duration > 0 {
+		ttsResp.RTF = ttsResp.Elapsed.Seconds() / ttsResp.Duration.Seconds()
+	}
+	if ttsResp.SHA256 != "" {
+		fillChecksum(&ttsResp)
+	}
+	return &ttsResp, nil
+}
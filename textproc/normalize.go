@@ -0,0 +1,265 @@
+package textproc
+
+// 提供数字、日期、货币与单位的规范化，将其转换为口语读法
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Normalizer 代表数字、日期、货币与单位的规范化处理阶段
+// 中文（含粤语）与英文使用各自的规则，其余语言原样返回
+type Normalizer struct{}
+
+// NewNormalizer 创建一个新的规范化处理阶段
+func NewNormalizer() *Normalizer {
+	return &Normalizer{}
+}
+
+// Process 实现 Processor
+func (n *Normalizer) Process(text, lang string) (string, error) {
+	switch strings.TrimPrefix(lang, "all_") {
+	case "zh", "yue", "auto", "auto_yue":
+		return NormalizeChinese(text), nil
+	case "en":
+		return NormalizeEnglish(text), nil
+	default:
+		return text, nil
+	}
+}
+
+// 中文规范化使用的正则表达式
+var (
+	zhDateRe     = regexp.MustCompile(`(\d{4})\s*年\s*(\d{1,2})\s*月\s*(\d{1,2})\s*[日号]`)
+	zhISODateRe  = regexp.MustCompile(`\b(\d{4})[-/](\d{1,2})[-/](\d{1,2})\b`)
+	zhYearRe     = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*年`)
+	zhMonthDayRe = regexp.MustCompile(`(\d{1,2})\s*月\s*(\d{1,2})\s*[日号]`)
+	zhTimeRe     = regexp.MustCompile(`\b(\d{1,2}):(\d{2})\b`)
+	zhPercentRe  = regexp.MustCompile(`(负?)(\d+(?:\.\d+)?)\s*[%％]`)
+	zhYuanRe     = regexp.MustCompile(`[¥￥](\d+(?:\.\d{1,2})?)|(\d+(?:\.\d{1,2})?)\s*元`)
+	zhForeignRe  = regexp.MustCompile(`([$€£])(\d+(?:\.\d+)?)`)
+	zhUnitRe     = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(km/h|km|kg|cm|mm|ml|mg|m²|m|g|℃|°C|L)(?:\b|$|[^A-Za-z])`)
+	zhNumberRe   = regexp.MustCompile(`\d{1,3}(?:,\d{3})+(?:\.\d+)?|\d+(?:\.\d+)?`)
+)
+
+// signRe 匹配数字前的负号，负号前为数字或字母时视为连字符（如 "1-2"、"COVID-19"）
+var signRe = regexp.MustCompile(`(^|[^0-9A-Za-z_.])[-−](\d)`)
+
+// zhUnits 为单位符号的中文读法
+var zhUnits = map[string]string{
+	"km/h": "公里每小时",
+	"km":   "公里",
+	"kg":   "千克",
+	"cm":   "厘米",
+	"mm":   "毫米",
+	"ml":   "毫升",
+	"mg":   "毫克",
+	"m²":   "平方米",
+	"m":    "米",
+	"g":    "克",
+	"℃":    "摄氏度",
+	"°C":   "摄氏度",
+	"L":    "升",
+}
+
+// zhCurrencies 为货币符号的中文读法
+var zhCurrencies = map[string]string{"$": "美元", "€": "欧元", "£": "英镑"}
+
+// NormalizeChinese 将中文文本中的数字、日期、货币与单位转换为中文读法
+// 例如 "2024年03月05日" 转换为 "二零二四年三月五日"，"50%" 转换为 "百分之五十"，"-5度" 转换为 "负五度"
+func NormalizeChinese(text string) string {
+	text = replaceSubmatch(zhDateRe, text, func(m []string) string {
+		return ChineseDigits(m[1]) + "年" + chineseCount(m[2]) + "月" + chineseCount(m[3]) + "日"
+	})
+	text = replaceSubmatch(zhISODateRe, text, func(m []string) string {
+		return ChineseDigits(m[1]) + "年" + chineseCount(m[2]) + "月" + chineseCount(m[3]) + "日"
+	})
+	text = replaceSubmatch(zhYearRe, text, func(m []string) string {
+		// 四位数按年份逐位读出，其余按数值读出，如 "30年" 读作 "三十年"
+		if len(m[1]) == 4 {
+			return ChineseDigits(m[1]) + "年"
+		}
+		return ChineseDecimal(m[1]) + "年"
+	})
+	text = replaceSubmatch(zhMonthDayRe, text, func(m []string) string {
+		return chineseCount(m[1]) + "月" + chineseCount(m[2]) + "日"
+	})
+	text = replaceSubmatch(zhTimeRe, text, func(m []string) string {
+		hour := chineseCount(m[1]) + "点"
+		if m[2] == "00" {
+			return hour
+		}
+		minute := chineseCount(m[2])
+		if m[2][0] == '0' {
+			minute = "零" + minute
+		}
+		return hour + minute + "分"
+	})
+	text = signRe.ReplaceAllString(text, "${1}负${2}")
+	text = replaceSubmatch(zhPercentRe, text, func(m []string) string {
+		return "百分之" + m[1] + ChineseDecimal(m[2])
+	})
+	text = replaceSubmatch(zhYuanRe, text, func(m []string) string {
+		amount := m[1]
+		if amount == "" {
+			amount = m[2]
+		}
+		return chineseYuan(amount)
+	})
+	text = replaceSubmatch(zhForeignRe, text, func(m []string) string {
+		return ChineseDecimal(m[2]) + zhCurrencies[m[1]]
+	})
+	text = replaceSubmatch(zhUnitRe, text, func(m []string) string {
+		// 保留单位之后被正则一并匹配的分隔字符
+		rest := strings.TrimPrefix(m[0], m[0][:strings.Index(m[0], m[2])+len(m[2])])
+		return ChineseDecimal(m[1]) + zhUnits[m[2]] + rest
+	})
+	return zhNumberRe.ReplaceAllStringFunc(text, ChineseDecimal)
+}
+
+// chineseCount 将月、日、时、分等计数字段转换为中文读法，忽略前导零，如 "03" 转换为 "三"
+func chineseCount(s string) string {
+	n, _ := strconv.Atoi(s)
+	return ChineseNumber(int64(n))
+}
+
+// chineseYuan 将人民币金额转换为 "元角分" 读法，如 "12.50" 转换为 "十二元五角"
+func chineseYuan(amount string) string {
+	intPart, frac, _ := strings.Cut(amount, ".")
+	result := chineseInteger(intPart) + "元"
+
+	frac = (frac + "00")[:2]
+	if frac[0] != '0' {
+		result += chineseDigits[frac[0]-'0'] + "角"
+	}
+	if frac[1] != '0' {
+		if frac[0] == '0' {
+			result += "零"
+		}
+		result += chineseDigits[frac[1]-'0'] + "分"
+	}
+	return result
+}
+
+// 英文规范化使用的正则表达式
+var (
+	enISODateRe   = regexp.MustCompile(`\b(\d{4})-(\d{1,2})-(\d{1,2})\b`)
+	enMonthDateRe = regexp.MustCompile(`\b(January|February|March|April|May|June|July|August|September|October|November|December)\s+(\d{1,2})(?:st|nd|rd|th)?(?:,?\s+(\d{4}))?\b`)
+	enCurrencyRe  = regexp.MustCompile(`([$€£])(\d{1,3}(?:,\d{3})+|\d+)(?:\.(\d{1,2}))?`)
+	enPercentRe   = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%`)
+	enOrdinalRe   = regexp.MustCompile(`\b(\d+)(st|nd|rd|th)\b`)
+	enTimeRe      = regexp.MustCompile(`\b(\d{1,2}):(\d{2})\b`)
+	enUnitRe      = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(km/h|mph|km|kg|cm|mm|ml|lb|lbs|°C|°F|m|g)\b`)
+	enNumberRe    = regexp.MustCompile(`\d{1,3}(?:,\d{3})+(?:\.\d+)?|\d+(?:\.\d+)?`)
+)
+
+// enMonths 为英文月份名称
+var enMonths = []string{"", "January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December"}
+
+// enUnits 为单位符号的英文读法（单数与复数）
+var enUnits = map[string][2]string{
+	"km/h": {"kilometer per hour", "kilometers per hour"},
+	"mph":  {"mile per hour", "miles per hour"},
+	"km":   {"kilometer", "kilometers"},
+	"kg":   {"kilogram", "kilograms"},
+	"cm":   {"centimeter", "centimeters"},
+	"mm":   {"millimeter", "millimeters"},
+	"ml":   {"milliliter", "milliliters"},
+	"lb":   {"pound", "pounds"},
+	"lbs":  {"pound", "pounds"},
+	"°C":   {"degree Celsius", "degrees Celsius"},
+	"°F":   {"degree Fahrenheit", "degrees Fahrenheit"},
+	"m":    {"meter", "meters"},
+	"g":    {"gram", "grams"},
+}
+
+// enCurrencies 为货币符号的英文读法（主单位与辅单位的单复数）
+var enCurrencies = map[string][4]string{
+	"$": {"dollar", "dollars", "cent", "cents"},
+	"€": {"euro", "euros", "cent", "cents"},
+	"£": {"pound", "pounds", "penny", "pence"},
+}
+
+// NormalizeEnglish 将英文文本中的数字、日期、货币与单位转换为英文读法
+// 例如 "$12.50" 转换为 "twelve dollars fifty cents"，"2024-03-05" 转换为 "March fifth, twenty twenty-four"，"-3" 转换为 "minus three"
+func NormalizeEnglish(text string) string {
+	text = replaceSubmatch(enISODateRe, text, func(m []string) string {
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		year, _ := strconv.Atoi(m[1])
+		if month < 1 || month > 12 || day < 1 || day > 31 {
+			return m[0]
+		}
+		return enMonths[month] + " " + EnglishOrdinal(int64(day)) + ", " + EnglishYear(year)
+	})
+	text = signRe.ReplaceAllString(text, "${1}minus ${2}")
+	text = replaceSubmatch(enMonthDateRe, text, func(m []string) string {
+		day, _ := strconv.Atoi(m[2])
+		result := m[1] + " " + EnglishOrdinal(int64(day))
+		if m[3] != "" {
+			year, _ := strconv.Atoi(m[3])
+			result += ", " + EnglishYear(year)
+		}
+		return result
+	})
+	text = replaceSubmatch(enCurrencyRe, text, func(m []string) string {
+		names := enCurrencies[m[1]]
+		major := atoi(strings.ReplaceAll(m[2], ",", ""))
+		minor := int64(0)
+		if m[3] != "" {
+			minor = atoi((m[3] + "0")[:2])
+		}
+
+		var parts []string
+		if major > 0 || minor == 0 {
+			parts = append(parts, EnglishNumber(major)+" "+plural(major, names[0], names[1]))
+		}
+		if minor > 0 {
+			parts = append(parts, EnglishNumber(minor)+" "+plural(minor, names[2], names[3]))
+		}
+		return strings.Join(parts, " ")
+	})
+	text = replaceSubmatch(enPercentRe, text, func(m []string) string {
+		return EnglishDecimal(m[1]) + " percent"
+	})
+	text = replaceSubmatch(enOrdinalRe, text, func(m []string) string {
+		return EnglishOrdinal(atoi(m[1]))
+	})
+	text = replaceSubmatch(enTimeRe, text, func(m []string) string {
+		hour := EnglishNumber(atoi(m[1]))
+		switch minute := atoi(m[2]); {
+		case minute == 0:
+			return hour + " o'clock"
+		case minute < 10:
+			return hour + " oh " + EnglishNumber(minute)
+		default:
+			return hour + " " + EnglishNumber(minute)
+		}
+	})
+	text = replaceSubmatch(enUnitRe, text, func(m []string) string {
+		names := enUnits[m[2]]
+		name := names[1]
+		if m[1] == "1" {
+			name = names[0]
+		}
+		return EnglishDecimal(m[1]) + " " + name
+	})
+	return enNumberRe.ReplaceAllStringFunc(text, EnglishDecimal)
+}
+
+// plural 根据数量选择单数或复数形式
+func plural(n int64, singular, pluralForm string) string {
+	if n == 1 {
+		return singular
+	}
+	return pluralForm
+}
+
+// replaceSubmatch 使用子匹配回调替换所有匹配
+func replaceSubmatch(re *regexp.Regexp, text string, fn func(m []string) string) string {
+	return re.ReplaceAllStringFunc(text, func(s string) string {
+		return fn(re.FindStringSubmatch(s))
+	})
+}
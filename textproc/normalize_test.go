@@ -0,0 +1,107 @@
+package textproc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalizeChinese(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		// 日期
+		{"2024年3月5日", "二零二四年三月五日"},
+		{"2024年03月05日", "二零二四年三月五日"},
+		{"2024-03-05", "二零二四年三月五日"},
+		{"2024/12/31", "二零二四年十二月三十一日"},
+		{"03月15号", "三月十五日"},
+		{"1998年", "一九九八年"},
+		{"过去30年里", "过去三十年里"},
+		{"5年", "五年"},
+		{"100年", "一百年"},
+		{"1.5年", "一点五年"},
+		{"08年", "零八年"},
+
+		// 时间
+		{"08:30", "八点三十分"},
+		{"09:00", "九点"},
+		{"08:05", "八点零五分"},
+		{"23:59", "二十三点五十九分"},
+
+		// 负数
+		{"-5度", "负五度"},
+		{"气温-3℃", "气温负三摄氏度"},
+		{"-3.5%", "百分之负三点五"},
+		{"1-2", "一-二"},
+
+		// 数字、百分比、货币与单位
+		{"1234", "一千二百三十四"},
+		{"10005", "一万零五"},
+		{"3.14", "三点一四"},
+		{"1,000,000", "一百万"},
+		{"007", "零零七"},
+		{"50%", "百分之五十"},
+		{"¥12.50", "十二元五角"},
+		{"$20", "二十美元"},
+		{"5km", "五公里"},
+		{"2.5kg", "二点五千克"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeChinese(tt.in); got != tt.want {
+			t.Errorf("NormalizeChinese(%q) = %q，期望 %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeEnglish(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"2024-03-05", "March fifth, twenty twenty-four"},
+		{"March 21st, 1905", "March twenty-first, nineteen oh five"},
+		{"$12.50", "twelve dollars fifty cents"},
+		{"$1", "one dollar"},
+		{"£3.01", "three pounds one penny"},
+		{"50%", "fifty percent"},
+		{"3rd", "third"},
+		{"08:30", "eight thirty"},
+		{"09:00", "nine o'clock"},
+		{"10:05", "ten oh five"},
+		{"1 km", "one kilometer"},
+		{"5kg", "five kilograms"},
+		{"-3", "minus three"},
+		{"-10°C", "minus ten degrees Celsius"},
+		{"COVID-19", "COVID-nineteen"},
+		{"1,234", "one thousand two hundred thirty-four"},
+		{"2000", "two thousand"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeEnglish(tt.in); got != tt.want {
+			t.Errorf("NormalizeEnglish(%q) = %q，期望 %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestChineseNumber(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{0, "零"},
+		{10, "十"},
+		{15, "十五"},
+		{101, "一百零一"},
+		{1010, "一千零一十"},
+		{100000000, "一亿"},
+		{-42, "负四十二"},
+		{math.MinInt64, "负九二二三三七二零三六八五四七七五八零八"},
+	}
+
+	for _, tt := range tests {
+		if got := ChineseNumber(tt.in); got != tt.want {
+			t.Errorf("ChineseNumber(%d) = %q，期望 %q", tt.in, got, tt.want)
+		}
+	}
+}
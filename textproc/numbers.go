@@ -0,0 +1,285 @@
+package textproc
+
+// 提供数字到中英文读法的转换
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// chineseDigits 为中文数字
+var chineseDigits = []string{"零", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+
+// chineseSectionUnits 为四位以内的数位单位
+var chineseSectionUnits = []string{"", "十", "百", "千"}
+
+// chineseGroupUnits 为每四位一组的组单位
+var chineseGroupUnits = []string{"", "万", "亿", "万亿"}
+
+// ChineseNumber 将整数转换为中文读法，如 1234 转换为 "一千二百三十四"
+func ChineseNumber(n int64) string {
+	if n == 0 {
+		return chineseDigits[0]
+	}
+
+	var sb strings.Builder
+	if n < 0 {
+		// -n 会溢出，最小值逐位读出
+		if n == math.MinInt64 {
+			return "负" + ChineseDigits(strconv.FormatInt(n, 10)[1:])
+		}
+		sb.WriteString("负")
+		n = -n
+	}
+
+	// 按四位一组从低到高拆分
+	var groups []int
+	for n > 0 {
+		groups = append(groups, int(n%10000))
+		n /= 10000
+	}
+	if len(groups) > len(chineseGroupUnits) {
+		// 超出范围时逐位读出
+		return ChineseDigits(reverseGroups(groups))
+	}
+
+	var out strings.Builder
+	zeroPending := false
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		if g == 0 {
+			zeroPending = true
+			continue
+		}
+		// 非最高组不足千位时需要补 "零"
+		if out.Len() > 0 && (zeroPending || g < 1000) {
+			out.WriteString(chineseDigits[0])
+		}
+		out.WriteString(chineseSection(g))
+		out.WriteString(chineseGroupUnits[i])
+		zeroPending = false
+	}
+
+	// 以 "一十" 开头时读作 "十"，如 15 读作 "十五"
+	result := strings.TrimPrefix(out.String(), "一十")
+	if result != out.String() {
+		result = "十" + result
+	}
+
+	sb.WriteString(result)
+	return sb.String()
+}
+
+// chineseSection 将 1~9999 的整数转换为中文读法
+func chineseSection(n int) string {
+	var sb strings.Builder
+	zeroPending := false
+	for pos := 3; pos >= 0; pos-- {
+		div := 1
+		for i := 0; i < pos; i++ {
+			div *= 10
+		}
+		d := n / div % 10
+		if d == 0 {
+			if sb.Len() > 0 {
+				zeroPending = true
+			}
+			continue
+		}
+		if zeroPending {
+			sb.WriteString(chineseDigits[0])
+			zeroPending = false
+		}
+		sb.WriteString(chineseDigits[d])
+		sb.WriteString(chineseSectionUnits[pos])
+	}
+	return sb.String()
+}
+
+// reverseGroups 将低位在前的四位分组还原为数字字符串
+func reverseGroups(groups []int) string {
+	var sb strings.Builder
+	for i := len(groups) - 1; i >= 0; i-- {
+		s := strconv.Itoa(groups[i])
+		if i != len(groups)-1 {
+			s = strings.Repeat("0", 4-len(s)) + s
+		}
+		sb.WriteString(s)
+	}
+	return sb.String()
+}
+
+// ChineseDigits 将数字串逐位转换为中文，如 "2024" 转换为 "二零二四"，非数字字符原样保留
+func ChineseDigits(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			sb.WriteString(chineseDigits[r-'0'])
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// ChineseDecimal 将十进制数字串转换为中文读法，如 "3.14" 转换为 "三点一四"
+func ChineseDecimal(s string) string {
+	intPart, fracPart, hasFrac := strings.Cut(strings.ReplaceAll(s, ",", ""), ".")
+	result := chineseInteger(intPart)
+	if hasFrac && fracPart != "" {
+		result += "点" + ChineseDigits(fracPart)
+	}
+	return result
+}
+
+// chineseInteger 将整数字符串转换为中文，过长或以 0 开头的数字串逐位读出
+func chineseInteger(s string) string {
+	if s == "" {
+		return chineseDigits[0]
+	}
+	if len(s) > 16 || (len(s) > 1 && s[0] == '0') {
+		return ChineseDigits(s)
+	}
+	return ChineseNumber(atoi(s))
+}
+
+// englishOnes 为 0~19 的英文
+var englishOnes = []string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+	"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+}
+
+// englishTens 为整十的英文
+var englishTens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+
+// englishScales 为每三位一组的组单位
+var englishScales = []string{"", "thousand", "million", "billion", "trillion", "quadrillion"}
+
+// EnglishNumber 将整数转换为英文读法，如 1234 转换为 "one thousand two hundred thirty-four"
+func EnglishNumber(n int64) string {
+	if n == 0 {
+		return englishOnes[0]
+	}
+	if n < 0 {
+		// -n 会溢出，最小值逐位读出
+		if n == math.MinInt64 {
+			return "minus " + englishInteger(strconv.FormatInt(n, 10)[1:])
+		}
+		return "minus " + EnglishNumber(-n)
+	}
+
+	var parts []string
+	for scale := 0; n > 0; scale++ {
+		g := int(n % 1000)
+		n /= 1000
+		if g == 0 {
+			continue
+		}
+		words := englishHundreds(g)
+		if englishScales[scale] != "" {
+			words += " " + englishScales[scale]
+		}
+		parts = append([]string{words}, parts...)
+	}
+	return strings.Join(parts, " ")
+}
+
+// englishHundreds 将 1~999 的整数转换为英文
+func englishHundreds(n int) string {
+	var parts []string
+	if n >= 100 {
+		parts = append(parts, englishOnes[n/100]+" hundred")
+		n %= 100
+	}
+	switch {
+	case n == 0:
+	case n < 20:
+		parts = append(parts, englishOnes[n])
+	case n%10 == 0:
+		parts = append(parts, englishTens[n/10])
+	default:
+		parts = append(parts, englishTens[n/10]+"-"+englishOnes[n%10])
+	}
+	return strings.Join(parts, " ")
+}
+
+// EnglishOrdinal 将整数转换为英文序数词，如 21 转换为 "twenty-first"
+func EnglishOrdinal(n int64) string {
+	words := EnglishNumber(n)
+
+	// 只需变换最后一个单词
+	cut := strings.LastIndexAny(words, " -") + 1
+	head, last := words[:cut], words[cut:]
+
+	irregular := map[string]string{
+		"one": "first", "two": "second", "three": "third", "five": "fifth",
+		"eight": "eighth", "nine": "ninth", "twelve": "twelfth",
+	}
+	switch {
+	case irregular[last] != "":
+		last = irregular[last]
+	case strings.HasSuffix(last, "y"):
+		last = strings.TrimSuffix(last, "y") + "ieth"
+	default:
+		last += "th"
+	}
+	return head + last
+}
+
+// EnglishDecimal 将十进制数字串转换为英文读法，如 "3.14" 转换为 "three point one four"
+func EnglishDecimal(s string) string {
+	intPart, fracPart, hasFrac := strings.Cut(strings.ReplaceAll(s, ",", ""), ".")
+	result := englishInteger(intPart)
+	if hasFrac && fracPart != "" {
+		digits := make([]string, 0, len(fracPart))
+		for _, r := range fracPart {
+			if r >= '0' && r <= '9' {
+				digits = append(digits, englishOnes[r-'0'])
+			}
+		}
+		result += " point " + strings.Join(digits, " ")
+	}
+	return result
+}
+
+// englishInteger 将整数字符串转换为英文，过长的数字串逐位读出
+func englishInteger(s string) string {
+	if s == "" {
+		return englishOnes[0]
+	}
+	if len(s) > 18 {
+		digits := make([]string, 0, len(s))
+		for _, r := range s {
+			digits = append(digits, englishOnes[r-'0'])
+		}
+		return strings.Join(digits, " ")
+	}
+	return EnglishNumber(atoi(s))
+}
+
+// EnglishYear 按年份习惯读出四位年份，如 2024 读作 "twenty twenty-four"，1905 读作 "nineteen oh five"
+func EnglishYear(year int) string {
+	if year < 1000 || year > 9999 {
+		return EnglishNumber(int64(year))
+	}
+
+	high, low := year/100, year%100
+	switch {
+	case year%1000 < 10 && high%10 == 0:
+		// 2000~2009 读作 "two thousand (five)"
+		return EnglishNumber(int64(year))
+	case low == 0:
+		return englishHundreds(high) + " hundred"
+	case low < 10:
+		return englishHundreds(high) + " oh " + englishOnes[low]
+	default:
+		return englishHundreds(high) + " " + englishHundreds(low)
+	}
+}
+
+// atoi 将纯数字字符串转换为整数，调用方保证长度不会溢出
+func atoi(s string) int64 {
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
@@ -0,0 +1,148 @@
+// Package textproc 提供合成前的文本预处理流水线，包括发音词典替换、数字规范化与文本清理
+package textproc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Processor 代表文本处理阶段，lang 为 GPT-SoVITS 语言参数（如 "all_zh"、"en"）
+type Processor interface {
+	Process(text, lang string) (string, error)
+}
+
+// ProcessorFunc 将普通函数适配为 Processor
+type ProcessorFunc func(text, lang string) (string, error)
+
+// Process 实现 Processor
+func (f ProcessorFunc) Process(text, lang string) (string, error) {
+	return f(text, lang)
+}
+
+// Pipeline 代表按顺序执行的多个处理阶段
+type Pipeline struct {
+	stages []Processor
+}
+
+// NewPipeline 创建一个新的处理流水线
+func NewPipeline(stages ...Processor) *Pipeline {
+	return &Pipeline{stages: stages}
+}
+
+// Add 在流水线末尾追加处理阶段
+func (p *Pipeline) Add(stages ...Processor) *Pipeline {
+	p.stages = append(p.stages, stages...)
+	return p
+}
+
+// Process 依次执行所有处理阶段
+func (p *Pipeline) Process(text, lang string) (string, error) {
+	for i, stage := range p.stages {
+		var err error
+		text, err = stage.Process(text, lang)
+		if err != nil {
+			return "", fmt.Errorf("文本处理第 %d 阶段失败: %w", i+1, err)
+		}
+	}
+	return text, nil
+}
+
+// Rule 代表一条替换规则
+type Rule struct {
+	Pattern    string   `json:"pattern"`               // 匹配内容，Regex 为 true 时为正则表达式
+	Replace    string   `json:"replace"`               // 替换内容，正则模式下支持 $1 等引用
+	Regex      bool     `json:"regex,omitempty"`       // 是否为正则表达式
+	IgnoreCase bool     `json:"ignore_case,omitempty"` // 是否忽略大小写
+	Langs      []string `json:"langs,omitempty"`       // 适用的语言，为空时适用于所有语言
+}
+
+// compiledRule 代表编译后的替换规则
+type compiledRule struct {
+	rule Rule
+	re   *regexp.Regexp
+}
+
+// Replacer 代表按顺序应用替换规则的处理阶段，可用作发音词典
+type Replacer struct {
+	rules []compiledRule
+}
+
+// NewReplacer 编译替换规则并创建 Replacer
+func NewReplacer(rules ...Rule) (*Replacer, error) {
+	r := &Replacer{}
+	for i, rule := range rules {
+		if rule.Pattern == "" {
+			return nil, fmt.Errorf("第 %d 条规则的匹配内容为空", i+1)
+		}
+
+		pattern := rule.Pattern
+		if !rule.Regex {
+			pattern = regexp.QuoteMeta(pattern)
+		}
+		if rule.IgnoreCase {
+			pattern = "(?i)" + pattern
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 条规则的正则表达式无效: %w", i+1, err)
+		}
+		r.rules = append(r.rules, compiledRule{rule: rule, re: re})
+	}
+	return r, nil
+}
+
+// LoadRules 从 JSON 文件加载替换规则，文件内容为 Rule 数组
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取规则文件失败: %w", err)
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("解析规则文件 %s 失败: %w", path, err)
+	}
+	return rules, nil
+}
+
+// LoadReplacer 从 JSON 文件加载替换规则并创建 Replacer
+func LoadReplacer(path string) (*Replacer, error) {
+	rules, err := LoadRules(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplacer(rules...)
+}
+
+// Process 实现 Processor，仅应用适用于 lang 的规则
+func (r *Replacer) Process(text, lang string) (string, error) {
+	for _, cr := range r.rules {
+		if !matchLang(cr.rule.Langs, lang) {
+			continue
+		}
+		if cr.rule.Regex {
+			text = cr.re.ReplaceAllString(text, cr.rule.Replace)
+		} else {
+			text = cr.re.ReplaceAllLiteralString(text, cr.rule.Replace)
+		}
+	}
+	return text, nil
+}
+
+// matchLang 判断规则是否适用于给定语言，"zh" 同时匹配 "zh" 与 "all_zh"
+func matchLang(langs []string, lang string) bool {
+	if len(langs) == 0 {
+		return true
+	}
+	base := strings.TrimPrefix(lang, "all_")
+	for _, l := range langs {
+		if l == lang || strings.TrimPrefix(l, "all_") == base {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package textproc
+
+// 提供文本清理，移除 emoji、零宽字符、Markdown 标记与 URL
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Sanitizer 代表文本清理处理阶段，适用于从聊天或网页抓取的文本
+type Sanitizer struct {
+	EmojiMap       map[rune]string // emoji 到读法的映射，未映射的 emoji 将被移除
+	URLReplacement string          // URL 的替换文本（如 "link"、"链接"），为空时直接移除
+	KeepMarkdown   bool            // 是否保留 Markdown 标记
+}
+
+// NewSanitizer 创建一个默认配置的文本清理处理阶段
+func NewSanitizer() *Sanitizer {
+	return &Sanitizer{}
+}
+
+// 文本清理使用的正则表达式
+var (
+	urlRe          = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>()\[\]{}"'，。！？、]+`)
+	mdImageRe      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLinkRe       = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+	mdFenceRe      = regexp.MustCompile("(?m)^\\s*```[^\\n]*$")
+	mdInlineCodeRe = regexp.MustCompile("`([^`]*)`")
+	mdHeadingRe    = regexp.MustCompile(`(?m)^\s{0,3}#{1,6}\s+`)
+	mdQuoteRe      = regexp.MustCompile(`(?m)^\s*>\s?`)
+	mdBulletRe     = regexp.MustCompile(`(?m)^\s*(?:[-*+]|\d+\.)\s+`)
+	mdRuleRe       = regexp.MustCompile(`(?m)^\s*(?:[-*_]\s*){3,}$`)
+	mdEmphasisRe   = regexp.MustCompile(`(\*\*|__|~~)(.+?)(\*\*|__|~~)|\*([^*\n]+)\*`)
+	mdTablePipeRe  = regexp.MustCompile(`(?m)^\s*\|?(?:\s*:?-+:?\s*\|)+\s*:?-*:?\s*$`)
+	htmlTagRe      = regexp.MustCompile(`</?[A-Za-z][^>]*>`)
+	spacesRe       = regexp.MustCompile(`[ \t]+`)
+	blankLinesRe   = regexp.MustCompile(`\n{3,}`)
+)
+
+// Process 实现 Processor
+func (s *Sanitizer) Process(text, lang string) (string, error) {
+	// 先移除 Markdown 标记，使链接只保留文本而丢弃链接目标
+	if !s.KeepMarkdown {
+		text = stripMarkdown(text)
+	}
+	text = urlRe.ReplaceAllString(text, s.URLReplacement)
+
+	var sb strings.Builder
+	for _, r := range text {
+		switch {
+		case isZeroWidth(r):
+			// 移除零宽字符与变体选择符
+		case isEmoji(r):
+			sb.WriteString(s.EmojiMap[r])
+		case r == '\n' || r == '\t':
+			sb.WriteRune(r)
+		case unicode.IsControl(r):
+			// 移除其余控制字符
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	// 折叠多余的空白
+	lines := strings.Split(spacesRe.ReplaceAllString(sb.String(), " "), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = blankLinesRe.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text), nil
+}
+
+// stripMarkdown 移除常见的 Markdown 与 HTML 标记，保留可读文本
+func stripMarkdown(text string) string {
+	text = mdFenceRe.ReplaceAllString(text, "")
+	text = mdImageRe.ReplaceAllString(text, "$1")
+	text = mdLinkRe.ReplaceAllString(text, "$1")
+	text = mdInlineCodeRe.ReplaceAllString(text, "$1")
+	text = mdRuleRe.ReplaceAllString(text, "")
+	text = mdTablePipeRe.ReplaceAllString(text, "")
+	text = mdHeadingRe.ReplaceAllString(text, "")
+	text = mdQuoteRe.ReplaceAllString(text, "")
+	text = mdBulletRe.ReplaceAllString(text, "")
+	text = mdEmphasisRe.ReplaceAllString(text, "$2$4")
+	text = htmlTagRe.ReplaceAllString(text, "")
+	text = strings.ReplaceAll(text, "|", " ")
+	return text
+}
+
+// isZeroWidth 判断是否为零宽字符或变体选择符
+func isZeroWidth(r rune) bool {
+	switch {
+	case r >= 0x200B && r <= 0x200F, r == 0x2060, r == 0xFEFF, r == 0x00AD:
+		return true
+	case r >= 0xFE00 && r <= 0xFE0F, r >= 0xE0100 && r <= 0xE01EF:
+		return true
+	default:
+		return false
+	}
+}
+
+// isEmoji 判断是否为 emoji 或图形符号
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // 表情、符号、交通与旗帜等
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // 杂项符号与装饰符号
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // 箭头与几何图形
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // 旗帜标签
+		return true
+	case r == 0x20E3: // 组合键帽
+		return true
+	default:
+		return false
+	}
+}
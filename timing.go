@@ -0,0 +1,231 @@
+package gpt_sovits_go_sdk
+
+// 提供对部分服务器分支随音频返回的时间戳与音素对齐信息的解析，用于口型同步与卡拉OK式高亮
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"time"
+)
+
+// maxTimingParts 为 multipart 响应中最多读取的部分数
+const maxTimingParts = 16
+
+// TimedUnit 代表带时间位置的一个单位，如一个词、一个字或一个音素
+type TimedUnit struct {
+	Text  string        `json:"text"`  // 文本或音素
+	Start time.Duration `json:"start"` // 在音频中的起始时间
+	End   time.Duration `json:"end"`   // 在音频中的结束时间
+}
+
+// Timing 代表音频的时间对齐信息
+type Timing struct {
+	Words    []TimedUnit `json:"words,omitempty"`    // 词级（中文为字级）时间戳
+	Phonemes []TimedUnit `json:"phonemes,omitempty"` // 音素级时间戳
+
+	Estimated  bool    `json:"estimated,omitempty"` // 是否为客户端估计值（见 EstimateTiming）
+	Confidence float64 `json:"confidence"`          // 置信度，服务器返回的对齐信息为 1，估计值不超过 0.5
+}
+
+// timingKeys 为对齐信息常见的字段名
+var timingKeys = struct {
+	words, phonemes, audio, text, start, end []string
+}{
+	words:    []string{"words", "word_timestamps", "timestamps", "alignment", "segments"},
+	phonemes: []string{"phonemes", "phones", "phoneme_timestamps"},
+	audio:    []string{"audio", "audio_base64", "wav", "data"},
+	text:     []string{"text", "word", "token", "char", "phoneme", "phone", "ph"},
+	start:    []string{"start", "start_time", "begin", "from"},
+	end:      []string{"end", "end_time", "stop", "to"},
+}
+
+// extractTiming 从 multipart 或 JSON 响应中拆分音频与对齐信息
+// 响应为普通音频时原样返回；无法识别的 JSON 或 multipart 响应同样原样返回
+func extractTiming(body []byte, contentType string) (audioData []byte, audioType string, timing *Timing, err error) {
+	mediaType, params, perr := mime.ParseMediaType(contentType)
+	if perr != nil {
+		return body, contentType, nil, nil
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		return parseTimingMultipart(body, params["boundary"], contentType)
+	case mediaType == "application/json":
+		var payload map[string]json.RawMessage
+		if json.Unmarshal(body, &payload) != nil {
+			return body, contentType, nil, nil
+		}
+		audioField, ok := firstField(payload, timingKeys.audio)
+		if !ok {
+			return body, contentType, nil, nil
+		}
+		var encoded string
+		if err := json.Unmarshal(audioField, &encoded); err != nil {
+			return nil, "", nil, fmt.Errorf("解析响应中的音频失败: %w", err)
+		}
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("解码响应中的音频失败: %w", err)
+		}
+		timing, err := parseTimingJSON(payload)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		return data, DetectMediaType(data).ContentType(), timing, nil
+	default:
+		return body, contentType, nil, nil
+	}
+}
+
+// parseTimingMultipart 解析由音频部分与 JSON 对齐信息部分组成的 multipart 响应
+func parseTimingMultipart(body []byte, boundary, contentType string) ([]byte, string, *Timing, error) {
+	if boundary == "" {
+		return body, contentType, nil, nil
+	}
+
+	var (
+		audioData []byte
+		audioType string
+		timing    *Timing
+	)
+	mr := multipart.NewReader(bytes.NewReader(body), boundary)
+	for i := 0; i < maxTimingParts; i++ {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("解析 multipart 响应失败: %w", err)
+		}
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("读取 multipart 响应失败: %w", err)
+		}
+
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch {
+		case partType == "application/json":
+			var payload map[string]json.RawMessage
+			if err := json.Unmarshal(data, &payload); err != nil {
+				return nil, "", nil, fmt.Errorf("解析对齐信息失败: %w", err)
+			}
+			if timing, err = parseTimingJSON(payload); err != nil {
+				return nil, "", nil, err
+			}
+		case audioData == nil:
+			audioData, audioType = data, part.Header.Get("Content-Type")
+		}
+	}
+
+	if audioData == nil {
+		return nil, "", nil, fmt.Errorf("multipart 响应中没有音频")
+	}
+	if audioType == "" {
+		audioType = DetectMediaType(audioData).ContentType()
+	}
+	return audioData, audioType, timing, nil
+}
+
+// parseTimingJSON 从 JSON 对象中读取词级与音素级时间戳，均不存在时返回 nil
+func parseTimingJSON(payload map[string]json.RawMessage) (*Timing, error) {
+	timing := &Timing{Confidence: 1}
+
+	var err error
+	if raw, ok := firstField(payload, timingKeys.words); ok {
+		if timing.Words, err = parseTimedUnits(raw); err != nil {
+			return nil, fmt.Errorf("解析词级时间戳失败: %w", err)
+		}
+	}
+	if raw, ok := firstField(payload, timingKeys.phonemes); ok {
+		if timing.Phonemes, err = parseTimedUnits(raw); err != nil {
+			return nil, fmt.Errorf("解析音素时间戳失败: %w", err)
+		}
+	}
+
+	if timing.Words == nil && timing.Phonemes == nil {
+		return nil, nil
+	}
+	return timing, nil
+}
+
+// parseTimedUnits 解析时间戳列表，元素可为 {"text", "start", "end"} 对象或 [text, start, end] 数组
+// 时间以秒为单位，字段名以 _ms 结尾时以毫秒为单位
+func parseTimedUnits(raw json.RawMessage) ([]TimedUnit, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, err
+	}
+
+	units := make([]TimedUnit, 0, len(items))
+	for _, item := range items {
+		var u TimedUnit
+
+		var tuple []json.RawMessage
+		if json.Unmarshal(item, &tuple) == nil {
+			if len(tuple) < 3 {
+				return nil, fmt.Errorf("时间戳数组应为 [文本, 起始, 结束]")
+			}
+			var start, end float64
+			if json.Unmarshal(tuple[0], &u.Text) != nil || json.Unmarshal(tuple[1], &start) != nil || json.Unmarshal(tuple[2], &end) != nil {
+				return nil, fmt.Errorf("无法解析时间戳 %s", item)
+			}
+			u.Start, u.End = seconds(start), seconds(end)
+			units = append(units, u)
+			continue
+		}
+
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(item, &obj); err != nil {
+			return nil, fmt.Errorf("无法解析时间戳 %s", item)
+		}
+		if text, ok := firstField(obj, timingKeys.text); ok {
+			_ = json.Unmarshal(text, &u.Text)
+		}
+		var ok bool
+		if u.Start, ok = timeField(obj, timingKeys.start); !ok {
+			return nil, fmt.Errorf("时间戳缺少起始时间: %s", item)
+		}
+		if u.End, ok = timeField(obj, timingKeys.end); !ok {
+			return nil, fmt.Errorf("时间戳缺少结束时间: %s", item)
+		}
+		units = append(units, u)
+	}
+	return units, nil
+}
+
+// timeField 读取以秒或毫秒（字段名带 _ms 后缀）表示的时间字段
+func timeField(obj map[string]json.RawMessage, keys []string) (time.Duration, bool) {
+	for _, key := range keys {
+		var v float64
+		if raw, ok := obj[key]; ok && json.Unmarshal(raw, &v) == nil {
+			return seconds(v), true
+		}
+		if raw, ok := obj[key+"_ms"]; ok && json.Unmarshal(raw, &v) == nil {
+			return time.Duration(math.Round(v * float64(time.Millisecond))), true
+		}
+	}
+	return 0, false
+}
+
+// firstField 返回 keys 中第一个存在的字段
+func firstField(obj map[string]json.RawMessage, keys []string) (json.RawMessage, bool) {
+	for _, key := range keys {
+		if raw, ok := obj[key]; ok && string(raw) != "null" {
+			return raw, true
+		}
+	}
+	return nil, false
+}
+
+// seconds 将秒数转换为 time.Duration
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Round(s * float64(time.Second)))
+}
@@ -0,0 +1,54 @@
+package gpt_sovits_go_sdk
+
+// 提供流式合成结果的边接收边转码
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/audio"
+)
+
+// TTSStreamEncoded 以流式模式发送 TTS 请求，将接收到的音频经 enc 转码后写入 w
+// 请求的 MediaType 须为 wav（默认）或 raw，raw 按客户端的 RawSampleRate、单声道 16 位解析
+func (c *Client) TTSStreamEncoded(ctx context.Context, req TTSRequest, w io.Writer, enc audio.Encoder, opts ...CallOption) error {
+	// 确定输入格式
+	var transcode func(dst io.Writer, src io.Reader) error
+	switch req.MediaType {
+	case "", MediaTypeWAV:
+		transcode = func(dst io.Writer, src io.Reader) error {
+			return audio.TranscodeWAV(dst, src, enc)
+		}
+	case MediaTypeRAW:
+		sampleRate := c.RawSampleRate
+		if sampleRate <= 0 {
+			sampleRate = defaultRawSampleRate
+		}
+		f := audio.Format{SampleRate: sampleRate, Channels: 1, BitDepth: 16}
+		transcode = func(dst io.Writer, src io.Reader) error {
+			return audio.TranscodePCM(dst, src, f, enc)
+		}
+	default:
+		return fmt.Errorf("转码仅支持 wav 或 raw 输入，当前为 %s", req.MediaType)
+	}
+
+	// 接收与转码通过管道并行进行
+	pr, pw := io.Pipe()
+	errCh := make(chan error, 1)
+	go func() {
+		err := transcode(w, pr)
+		// 转码提前结束时中止接收
+		pr.CloseWithError(err)
+		errCh <- err
+	}()
+
+	_, streamErr := c.TTSStreamTo(ctx, req, pw, opts...)
+	pw.CloseWithError(streamErr)
+	transcodeErr := <-errCh
+
+	if streamErr != nil {
+		return streamErr
+	}
+	return transcodeErr
+}
@@ -0,0 +1,190 @@
+package gpt_sovits_go_sdk
+
+// 提供参考音频自动转写，未提供 prompt_text 时由转写器根据参考音频生成
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/lang"
+)
+
+// Transcriber 代表语音转写器，实现必须是并发安全的
+type Transcriber interface {
+	// Transcribe 转写音频，name 为音频文件名，lang 为 GPT-SoVITS 语言参数（可能为空或 auto）
+	Transcribe(ctx context.Context, audio []byte, name, lang string) (string, error)
+}
+
+// TranscriberFunc 将函数适配为 Transcriber
+type TranscriberFunc func(ctx context.Context, audio []byte, name, lang string) (string, error)
+
+// Transcribe 实现 Transcriber
+func (f TranscriberFunc) Transcribe(ctx context.Context, audio []byte, name, lang string) (string, error) {
+	return f(ctx, audio, name, lang)
+}
+
+// transcriptCache 缓存参考音频的转写结果，避免每次合成都重新转写
+type transcriptCache struct {
+	t       Transcriber
+	mu      sync.Mutex
+	entries map[string]string
+}
+
+// WithTranscriber 设置转写器，请求未提供 PromptText 时转写参考音频作为提示文本
+// RefAudioPath 需要在客户端本地可读（与服务器共享文件系统），或通过 RefAudioData 内嵌参考音频；转写结果按参考音频缓存
+func WithTranscriber(t Transcriber) ClientOption {
+	return func(c *Client) {
+		c.transcripts = &transcriptCache{t: t, entries: make(map[string]string)}
+	}
+}
+
+// fillPromptText 在请求缺少提示文本时转写参考音频并填入
+func (c *Client) fillPromptText(ctx context.Context, req *TTSRequest) error {
+	if c.transcripts == nil || req.PromptText != "" {
+		return nil
+	}
+
+	// 内嵌音频按内容缓存，路径按路径缓存
+	data, name, key := req.RefAudioData, filepath.Base(req.RefAudioPath), "path:"+req.RefAudioPath
+	if len(data) > 0 {
+		sum := sha256.Sum256(data)
+		name, key = "ref.wav", "data:"+hex.EncodeToString(sum[:])
+	} else if req.RefAudioPath == "" {
+		return nil
+	}
+
+	tc := c.transcripts
+	tc.mu.Lock()
+	text, ok := tc.entries[key]
+	tc.mu.Unlock()
+	if ok {
+		req.PromptText = text
+		return nil
+	}
+
+	if len(data) == 0 {
+		var err error
+		if data, err = os.ReadFile(req.RefAudioPath); err != nil {
+			return fmt.Errorf("读取参考音频以转写提示文本失败: %w", err)
+		}
+	}
+	text, err := tc.t.Transcribe(ctx, data, name, req.PromptLang)
+	if err != nil {
+		return fmt.Errorf("转写参考音频失败: %w", err)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return fmt.Errorf("转写参考音频失败: 转写结果为空")
+	}
+
+	tc.mu.Lock()
+	tc.entries[key] = text
+	tc.mu.Unlock()
+	req.PromptText = text
+	return nil
+}
+
+// WhisperTranscriber 代表基于 OpenAI 兼容 /audio/transcriptions 接口的转写器
+// 适用于 OpenAI Whisper API 以及 faster-whisper-server、LocalAI 等兼容服务
+type WhisperTranscriber struct {
+	BaseURL    string       // 接口基础URL，默认 https://api.openai.com/v1
+	APIKey     string       // API 密钥，本地服务可为空
+	Model      string       // 模型名称，默认 whisper-1
+	HTTPClient *http.Client // 发送请求的 HTTP 客户端，为空时使用 http.DefaultClient
+}
+
+// NewWhisperTranscriber 创建一个使用 OpenAI Whisper API 的转写器
+func NewWhisperTranscriber(apiKey string) *WhisperTranscriber {
+	return &WhisperTranscriber{APIKey: apiKey}
+}
+
+// Transcribe 实现 Transcriber
+func (w *WhisperTranscriber) Transcribe(ctx context.Context, audio []byte, name, promptLang string) (string, error) {
+	baseURL := w.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.openai.com/v1"
+	}
+	model := w.Model
+	if model == "" {
+		model = "whisper-1"
+	}
+
+	// 构建 multipart 请求体
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", name)
+	if err != nil {
+		return "", fmt.Errorf("构建转写请求失败: %w", err)
+	}
+	part.Write(audio)
+	mw.WriteField("model", model)
+	mw.WriteField("response_format", "json")
+	if code := whisperLanguage(promptLang); code != "" {
+		mw.WriteField("language", code)
+	}
+	if err := mw.Close(); err != nil {
+		return "", fmt.Errorf("构建转写请求失败: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(baseURL, "/")+"/audio/transcriptions", &body)
+	if err != nil {
+		return "", fmt.Errorf("创建转写请求失败: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", mw.FormDataContentType())
+	if w.APIKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+w.APIKey)
+	}
+
+	client := w.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("转写请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("读取转写响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("转写请求失败，状态码 %d: %s", resp.StatusCode, string(data))
+	}
+
+	var result struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("解析转写响应失败: %w", err)
+	}
+	return result.Text, nil
+}
+
+// whisperLanguage 将 GPT-SoVITS 语言参数转换为 Whisper 使用的 ISO-639-1 语言代码，自动识别或无法转换时返回空字符串
+func whisperLanguage(code string) string {
+	switch code {
+	case lang.Chinese, lang.MixedChinese, lang.Cantonese, lang.MixedCantonese:
+		return "zh"
+	case lang.English:
+		return "en"
+	case lang.Japanese, lang.MixedJapanese:
+		return "ja"
+	case lang.Korean, lang.MixedKorean:
+		return "ko"
+	default:
+		return ""
+	}
+}
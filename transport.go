@@ -0,0 +1,35 @@
+package gpt_sovits_go_sdk
+
+// 定义可替换的传输层接口，便于接入 gRPC 网关、Unix 套接字或自定义代理等传输方式
+
+import (
+	"net/http"
+)
+
+// Transport 代表客户端发送请求所用的传输层
+//
+// 实现需满足以下约定：
+//   - 请求的 URL、方法、请求头与请求体均已由客户端构造完毕，实现负责将其送达服务器
+//   - 返回的 *http.Response 必须包含状态码、响应头与可读取的 Body，调用方负责关闭 Body
+//   - 应遵守请求上下文的取消与截止时间
+//   - 必须是并发安全的
+//
+// *http.Client 满足该接口，为默认实现；非 HTTP 传输可在内部完成协议转换后构造 *http.Response
+type Transport interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// TransportFunc 允许将普通函数用作 Transport
+type TransportFunc func(req *http.Request) (*http.Response, error)
+
+// Do 实现 Transport 接口
+func (f TransportFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithTransport 设置自定义传输层，设置后 HTTPClient 及其超时配置不再生效
+func WithTransport(t Transport) ClientOption {
+	return func(c *Client) {
+		c.Transport = t
+	}
+}
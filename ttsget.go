@@ -0,0 +1,123 @@
+package gpt_sovits_go_sdk
+
+// 提供类型安全的 GET /tts 接口
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TTSGet 以 GET 方式发送 TTS 请求，请求字段按类型格式化为查询参数
+func (c *Client) TTSGet(ctx context.Context, req TTSRequest, opts ...CallOption) (*TTSResponse, error) {
+	o := newCallOptions(opts)
+	start := time.Now()
+
+	// 应用单次调用超时
+	ctx, cancel := o.withTimeout(ctx)
+	defer cancel()
+
+	// 构建HTTP请求
+	httpReq, sent, err := c.newTTSGetRequest(ctx, req)
+	if err != nil {
+		return &TTSResponse{Error: err}, nil
+	}
+	if err := c.authorizeVoice(ctx, sent, o); err != nil {
+		return &TTSResponse{Error: err}, nil
+	}
+	if err := c.checkQuota(sent, o); err != nil {
+		return &TTSResponse{Error: err}, nil
+	}
+
+	ttsResp := c.sendTTSWithAutoWeights(httpReq, o, start)
+	ttsResp.Seed = sent.Seed
+	ttsResp.TextLimit = c.textLimit(req.Text)
+	c.recordUsage(sent, o, ttsResp, false)
+	c.audit(ctx, req, o, ttsResp, nil, false)
+	return ttsResp, nil
+}
+
+// newTTSGetRequest 将 TTS 请求编码为带上下文的 GET 请求，同时返回合并默认参数后实际发送的请求
+func (c *Client) newTTSGetRequest(ctx context.Context, req TTSRequest) (*http.Request, TTSRequest, error) {
+	req, err := c.prepareRequest(ctx, req)
+	if err != nil {
+		return nil, req, err
+	}
+	if c.apiVersion == APIV1 {
+		httpReq, err := c.newV1Request(ctx, req, true)
+		return httpReq, req, err
+	}
+	if len(req.RefAudioData) > 0 {
+		return nil, req, fmt.Errorf("GET 接口不支持内嵌参考音频，请使用 TTS")
+	}
+
+	// 构建请求URL
+	u := fmt.Sprintf("%s/tts?%s", c.BaseURL, req.QueryValues().Encode())
+
+	// 创建GET请求
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, req, fmt.Errorf("创建请求失败: %w", err)
+	}
+	return httpReq, req, nil
+}
+
+// QueryValues 将请求字段映射为 GET /tts 的查询参数，可选字段为零值时省略
+func (r TTSRequest) QueryValues() url.Values {
+	v := url.Values{}
+
+	// 必填字段与 JSON 编码保持一致，始终发送
+	v.Set("text", r.Text)
+	v.Set("text_lang", r.TextLang)
+	v.Set("ref_audio_path", r.RefAudioPath)
+	v.Set("prompt_text", r.PromptText)
+	v.Set("prompt_lang", r.PromptLang)
+	v.Set("media_type", string(r.MediaType))
+	v.Set("streaming_mode", strconv.FormatBool(r.StreamingMode))
+
+	// 可选字段
+	for _, p := range r.AuxRefAudioPaths {
+		v.Add("aux_ref_audio_paths", p)
+	}
+	setInt(v, "top_k", r.TopK)
+	setFloat(v, "top_p", r.TopP)
+	setFloat(v, "temperature", r.Temperature)
+	if r.TextSplitMethod != "" {
+		v.Set("text_split_method", string(r.TextSplitMethod))
+	}
+	setInt(v, "batch_size", r.BatchSize)
+	setFloat(v, "batch_threshold", r.BatchThreshold)
+	if r.SplitBucket != nil {
+		v.Set("split_bucket", strconv.FormatBool(*r.SplitBucket))
+	}
+	setFloat(v, "speed_factor", r.SpeedFactor)
+	setFloat(v, "fragment_interval", r.FragmentInterval)
+	setInt(v, "seed", r.Seed)
+	if r.ParallelInfer != nil {
+		v.Set("parallel_infer", strconv.FormatBool(*r.ParallelInfer))
+	}
+	setFloat(v, "repetition_penalty", r.RepetitionPenalty)
+	setInt(v, "sample_steps", r.SampleSteps)
+	if r.SuperSampling {
+		v.Set("super_sampling", "true")
+	}
+
+	return v
+}
+
+// setInt 在值非零时设置整数查询参数
+func setInt(v url.Values, key string, n int) {
+	if n != 0 {
+		v.Set(key, strconv.Itoa(n))
+	}
+}
+
+// setFloat 在值非零时设置浮点数查询参数，使用最短的精确表示
+func setFloat(v url.Values, key string, f float64) {
+	if f != 0 {
+		v.Set(key, strconv.FormatFloat(f, 'f', -1, 64))
+	}
+}
@@ -0,0 +1,84 @@
+package gpt_sovits_go_sdk
+
+// 提供参考音频上传功能，适用于服务器与客户端不共享文件系统的部署
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// UploadResponse 代表上传端点的响应
+type UploadResponse struct {
+	Path string `json:"path"` // 上传文件在服务器上的路径
+}
+
+// UploadRefAudio 以 multipart 形式上传参考音频，返回可填入 TTSRequest.RefAudioPath 的服务器端路径
+// 上传端点应返回 {"path": "..."} 形式的JSON，或直接以纯文本返回路径
+func (c *Client) UploadRefAudio(ctx context.Context, r io.Reader, name string) (string, error) {
+	// 构建请求URL
+	url := c.UploadURL
+	if url == "" {
+		url = fmt.Sprintf("%s/upload_ref_audio", c.BaseURL)
+	}
+
+	// 通过管道流式写入 multipart 请求体，避免缓存整个文件
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		part, err := mw.CreateFormFile("file", name)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if _, err := io.Copy(part, r); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.CloseWithError(mw.Close())
+	}()
+
+	// 创建HTTP请求
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, pr)
+	if err != nil {
+		pr.Close()
+		return "", fmt.Errorf("创建上传请求失败: %w", err)
+	}
+
+	// 设置请求头
+	httpReq.Header.Set("Content-Type", mw.FormDataContentType())
+
+	// 发送请求
+	resp, err := c.do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("上传参考音频失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 读取响应体
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("读取上传响应失败: %w", err)
+	}
+
+	// 检查响应状态
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("上传参考音频失败，状态码 %d: %s", resp.StatusCode, string(body))
+	}
+
+	// 优先解析JSON响应，否则将响应体视为路径
+	var uploadResp UploadResponse
+	if err := json.Unmarshal(body, &uploadResp); err == nil && uploadResp.Path != "" {
+		return uploadResp.Path, nil
+	}
+
+	path := strings.TrimSpace(string(body))
+	if path == "" {
+		return "", fmt.Errorf("上传响应中缺少文件路径")
+	}
+	return path, nil
+}
@@ -0,0 +1,295 @@
+package gpt_sovits_go_sdk
+
+// 提供用量统计，记录合成的字符数与音频时长，供按量计费使用
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// UsageRecord 代表一次合成调用的用量
+type UsageRecord struct {
+	Time         time.Time `json:"time"`               // 调用完成时间
+	Tenant       string    `json:"tenant,omitempty"`   // 租户名称
+	Key          string    `json:"key,omitempty"`      // 调用方标识
+	Voice        string    `json:"voice,omitempty"`    // 音色名称，未通过音色合成时为参考音频路径
+	Model        string    `json:"model,omitempty"`    // 音色指定的 GPT 权重路径
+	Characters   int       `json:"characters"`         // 实际发送的文本字符数
+	AudioSeconds float64   `json:"audio_seconds"`      // 生成的音频秒数，流式调用与无法计算时长的格式为 0
+	Failed       bool      `json:"failed,omitempty"`   // 调用是否失败
+	Streamed     bool      `json:"streamed,omitempty"` // 是否为流式调用
+}
+
+// UsageStore 代表用量记录的存储，实现必须是并发安全的
+type UsageStore interface {
+	Add(rec UsageRecord) error                          // 追加一条记录
+	List(since, until time.Time) ([]UsageRecord, error) // 列出时间在 [since, until) 内的记录，零值表示不限制
+}
+
+// MemoryUsageStore 代表保存在内存中的用量存储
+type MemoryUsageStore struct {
+	mu      sync.Mutex
+	records []UsageRecord
+}
+
+// NewMemoryUsageStore 创建一个新的内存用量存储
+func NewMemoryUsageStore() *MemoryUsageStore {
+	return &MemoryUsageStore{}
+}
+
+// Add 实现 UsageStore
+func (s *MemoryUsageStore) Add(rec UsageRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = append(s.records, rec)
+	return nil
+}
+
+// List 实现 UsageStore
+func (s *MemoryUsageStore) List(since, until time.Time) ([]UsageRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []UsageRecord
+	for _, rec := range s.records {
+		if inRange(rec.Time, since, until) {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+// FileUsageStore 代表以 JSON Lines 文件追加保存的用量存储
+type FileUsageStore struct {
+	Path string // 记录文件路径
+
+	mu sync.Mutex
+}
+
+// NewFileUsageStore 创建一个基于文件的用量存储
+func NewFileUsageStore(path string) *FileUsageStore {
+	return &FileUsageStore{Path: path}
+}
+
+// Add 实现 UsageStore
+func (s *FileUsageStore) Add(rec UsageRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("用量记录序列化失败: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("打开用量文件失败: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("写入用量记录失败: %w", err)
+	}
+	return f.Close()
+}
+
+// List 实现 UsageStore
+func (s *FileUsageStore) List(since, until time.Time) ([]UsageRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("打开用量文件失败: %w", err)
+	}
+	defer f.Close()
+
+	var out []UsageRecord
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var rec UsageRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("解析用量记录第 %d 行失败: %w", line, err)
+		}
+		if inRange(rec.Time, since, until) {
+			out = append(out, rec)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("读取用量文件失败: %w", err)
+	}
+	return out, nil
+}
+
+// inRange 判断时间是否位于 [since, until) 内，零值表示不限制
+func inRange(t, since, until time.Time) bool {
+	return (since.IsZero() || !t.Before(since)) && (until.IsZero() || t.Before(until))
+}
+
+// UsageTotals 代表一组调用的用量合计
+type UsageTotals struct {
+	Requests     int     `json:"requests"`      // 调用次数
+	Failures     int     `json:"failures"`      // 失败次数
+	Characters   int     `json:"characters"`    // 字符数
+	AudioSeconds float64 `json:"audio_seconds"` // 音频秒数
+}
+
+// add 累加一条记录
+func (t *UsageTotals) add(rec UsageRecord) {
+	t.Requests++
+	if rec.Failed {
+		t.Failures++
+	}
+	t.Characters += rec.Characters
+	t.AudioSeconds += rec.AudioSeconds
+}
+
+// UsageReport 代表一段时间内的用量报告
+type UsageReport struct {
+	Since    time.Time              `json:"since"`     // 统计起始时间
+	Until    time.Time              `json:"until"`     // 统计截止时间
+	Total    UsageTotals            `json:"total"`     // 全部调用合计
+	ByTenant map[string]UsageTotals `json:"by_tenant"` // 按租户合计
+	ByKey    map[string]UsageTotals `json:"by_key"`    // 按调用方合计
+	ByVoice  map[string]UsageTotals `json:"by_voice"`  // 按音色合计
+	ByModel  map[string]UsageTotals `json:"by_model"`  // 按模型合计
+}
+
+// UsageTracker 代表用量统计器，可由多个客户端共享
+type UsageTracker struct {
+	Store   UsageStore      // 用量存储
+	OnError func(err error) // 写入存储失败时的回调，为空时忽略错误
+}
+
+// NewUsageTracker 创建一个新的用量统计器，store 为 nil 时使用内存存储
+func NewUsageTracker(store UsageStore) *UsageTracker {
+	if store == nil {
+		store = NewMemoryUsageStore()
+	}
+	return &UsageTracker{Store: store}
+}
+
+// Record 写入一条用量记录，未设置时间时使用当前时间
+func (t *UsageTracker) Record(rec UsageRecord) error {
+	if rec.Time.IsZero() {
+		rec.Time = time.Now()
+	}
+	if err := t.Store.Add(rec); err != nil {
+		if t.OnError != nil {
+			t.OnError(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// Report 汇总时间在 [since, until) 内的用量，零值表示不限制
+func (t *UsageTracker) Report(since, until time.Time) (*UsageReport, error) {
+	return t.report(since, until, "")
+}
+
+// report 汇总用量，tenant 非空时仅统计该租户
+func (t *UsageTracker) report(since, until time.Time, tenant string) (*UsageReport, error) {
+	records, err := t.Store.List(since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &UsageReport{
+		Since:    since,
+		Until:    until,
+		ByTenant: make(map[string]UsageTotals),
+		ByKey:    make(map[string]UsageTotals),
+		ByVoice:  make(map[string]UsageTotals),
+		ByModel:  make(map[string]UsageTotals),
+	}
+	for _, rec := range records {
+		if tenant != "" && rec.Tenant != tenant {
+			continue
+		}
+		r.Total.add(rec)
+		addUsage(r.ByTenant, rec.Tenant, rec)
+		addUsage(r.ByKey, rec.Key, rec)
+		addUsage(r.ByVoice, rec.Voice, rec)
+		addUsage(r.ByModel, rec.Model, rec)
+	}
+	return r, nil
+}
+
+// addUsage 将记录累加到分组合计中
+func addUsage(m map[string]UsageTotals, key string, rec UsageRecord) {
+	totals := m[key]
+	totals.add(rec)
+	m[key] = totals
+}
+
+// WithUsageTracker 为客户端设置用量统计器，每次合成调用完成后记录一条用量
+func WithUsageTracker(t *UsageTracker) ClientOption {
+	return func(c *Client) {
+		c.usage = t
+	}
+}
+
+// WithTenant 设置用量记录中的租户名称，ClientManager 创建的客户端自动设置为租户名
+func WithTenant(name string) ClientOption {
+	return func(c *Client) {
+		c.tenant = name
+	}
+}
+
+// UsageReport 汇总用量统计器中该客户端所属租户在 [since, until) 内的用量
+func (c *Client) UsageReport(since, until time.Time) (*UsageReport, error) {
+	if c.usage == nil {
+		return nil, errors.New("客户端未设置用量统计器，请使用 WithUsageTracker")
+	}
+
+	return c.usage.report(since, until, c.tenant)
+}
+
+// withUsageVoice 为用量记录标注音色名称与模型，并记录音色供授权检查
+func withUsageVoice(voice *Voice, model string) CallOption {
+	return func(o *callOptions) {
+		o.usageVoice = voice.Name
+		o.usageModel = model
+		o.voice = voice
+	}
+}
+
+// recordUsage 在设置了用量统计器时记录一次合成调用
+func (c *Client) recordUsage(sent TTSRequest, o *callOptions, ttsResp *TTSResponse, streamed bool) {
+	if c.usage == nil || o.noUsage {
+		return
+	}
+
+	rec := UsageRecord{
+		Tenant:     c.tenant,
+		Key:        o.usageKey,
+		Voice:      o.usageVoice,
+		Model:      o.usageModel,
+		Characters: utf8.RuneCountInString(sent.Text),
+		Streamed:   streamed,
+	}
+	if rec.Voice == "" {
+		rec.Voice = sent.RefAudioPath
+	}
+	if ttsResp != nil {
+		rec.Failed = ttsResp.Err() != nil
+		if !rec.Failed && ttsResp.StatusCode == http.StatusOK {
+			rec.AudioSeconds = ttsResp.Duration.Seconds()
+		}
+	}
+	_ = c.usage.Record(rec)
+}
@@ -0,0 +1,113 @@
+package gpt_sovits_go_sdk
+
+// 提供音色（参考音频及其提示文本）的抽象与注册表
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/textproc"
+)
+
+// ErrVoiceNotFound 表示音色未注册
+var ErrVoiceNotFound = errors.New("音色未注册")
+
+// Voice 代表一个音色，由参考音频及其提示文本组成，可选绑定模型权重
+type Voice struct {
+	Name             string           `json:"name"`                          // 音色名称
+	RefAudioPath     string           `json:"ref_audio_path"`                // 参考音频路径
+	AuxRefAudioPaths []string         `json:"aux_ref_audio_paths,omitempty"` // 辅助参考音频路径
+	PromptText       string           `json:"prompt_text"`                   // 参考音频的提示文本
+	PromptLang       string           `json:"prompt_lang"`                   // 参考音频提示文本的语言
+	GPTWeights       string           `json:"gpt_weights,omitempty"`         // 该音色使用的 GPT 权重路径，为空时不切换
+	SoVITSWeights    string           `json:"sovits_weights,omitempty"`      // 该音色使用的 SoVITS 权重路径，为空时不切换
+	Lexicon          []textproc.Rule  `json:"lexicon,omitempty"`             // 该音色专用的发音词典
+	Styles           map[string]Style `json:"styles,omitempty"`              // 按名称定义的情感/风格参数，如 "calm"、"excited"
+	RefAudios        []RefAudio       `json:"ref_audios,omitempty"`          // 额外的参考音频，与默认参考音频一起参与轮换
+	Rotation         RefRotation      `json:"rotation,omitempty"`            // 多参考音频的选择策略
+	License          *VoiceLicense    `json:"license,omitempty"`             // 声音所有者的授权信息，见 WithVoicePolicy
+}
+
+// Apply 将音色的参考音频设置写入请求
+func (v *Voice) Apply(req *TTSRequest) {
+	req.RefAudioPath = v.RefAudioPath
+	req.AuxRefAudioPaths = v.AuxRefAudioPaths
+	req.PromptText = v.PromptText
+	req.PromptLang = v.PromptLang
+}
+
+// ProcessText 对文本应用音色专用的发音词典
+func (v *Voice) ProcessText(text, lang string) (string, error) {
+	if len(v.Lexicon) == 0 {
+		return text, nil
+	}
+	r, err := textproc.NewReplacer(v.Lexicon...)
+	if err != nil {
+		return "", fmt.Errorf("音色 %s 的发音词典无效: %w", v.Name, err)
+	}
+	return r.Process(text, lang)
+}
+
+// VoiceRegistry 代表按名称管理音色的注册表，并发安全
+type VoiceRegistry struct {
+	mu     sync.RWMutex
+	voices map[string]*Voice
+}
+
+// NewVoiceRegistry 创建一个新的音色注册表
+func NewVoiceRegistry(voices ...Voice) *VoiceRegistry {
+	r := &VoiceRegistry{voices: make(map[string]*Voice)}
+	for _, v := range voices {
+		_ = r.Register(v)
+	}
+	return r
+}
+
+// Register 注册音色，同名音色将被覆盖
+func (r *VoiceRegistry) Register(v Voice) error {
+	if v.Name == "" {
+		return fmt.Errorf("音色名称不能为空")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.voices[v.Name] = &v
+	return nil
+}
+
+// Get 按名称获取音色
+func (r *VoiceRegistry) Get(name string) (*Voice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	v, ok := r.voices[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrVoiceNotFound, name)
+	}
+	copied := *v
+	return &copied, nil
+}
+
+// Remove 移除音色
+func (r *VoiceRegistry) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.voices, name)
+}
+
+// Names 返回所有已注册音色的名称，按字母序排列
+func (r *VoiceRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.voices))
+	for name := range r.voices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
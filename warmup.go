@@ -0,0 +1,63 @@
+package gpt_sovits_go_sdk
+
+// 提供预热调用，启动或切换模型后发送一次极短的合成，使服务器完成缓存加载与内核编译
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// warmupTexts 为各语言的预热文本
+var warmupTexts = map[string]string{
+	"zh":  "你好。",
+	"en":  "Hello.",
+	"ja":  "こんにちは。",
+	"ko":  "안녕하세요.",
+	"yue": "你好。",
+}
+
+// warmupText 返回与语言参数对应的预热文本，未知语言使用中文
+func warmupText(textLang string) string {
+	code := strings.TrimPrefix(textLang, "all_")
+	if text, ok := warmupTexts[code]; ok {
+		return text
+	}
+	return warmupTexts["zh"]
+}
+
+// withoutUsage 使本次调用不记录用量且不检查配额
+func withoutUsage() CallOption {
+	return func(o *callOptions) {
+		o.noUsage = true
+	}
+}
+
+// Warmup 发送一次极短的合成并丢弃结果，返回本次合成的耗时，预热调用不计入用量与配额
+// voice 为 nil 时使用客户端默认参数；文本语言取默认参数的 TextLang，未设置时取参考音频的提示文本语言
+func (c *Client) Warmup(ctx context.Context, voice *Voice) (time.Duration, error) {
+	req := TTSRequest{MediaType: MediaTypeWAV}
+	if voice != nil {
+		voice.Apply(&req)
+	}
+
+	defaults := c.Defaults()
+	req.TextLang = defaults.TextLang
+	if req.TextLang == "" {
+		req.TextLang = req.PromptLang
+	}
+	if req.TextLang == "" {
+		req.TextLang = defaults.PromptLang
+	}
+	req.Text = warmupText(req.TextLang)
+
+	resp, err := c.TTS(ctx, req, withoutUsage(), withoutAuthorization(), WithoutCache())
+	if err != nil {
+		return 0, err
+	}
+	if err := resp.Err(); err != nil {
+		return resp.Elapsed, fmt.Errorf("预热失败: %w", err)
+	}
+	return resp.Elapsed, nil
+}
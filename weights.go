@@ -0,0 +1,138 @@
+package gpt_sovits_go_sdk
+
+// 提供可用模型权重文件的查询，需要服务器提供权重列表端点
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// WeightFile 代表服务器上的一个模型权重文件
+type WeightFile struct {
+	Name string `json:"name"` // 文件名
+	Path string `json:"path"` // 可传给 SetGPTWeights / SetSoVITSWeights 的路径
+}
+
+// WeightsList 代表服务器上可用的全部模型权重
+type WeightsList struct {
+	GPT    []WeightFile `json:"gpt"`    // GPT 权重
+	SoVITS []WeightFile `json:"sovits"` // SoVITS 权重
+}
+
+// ListGPTWeights 列出服务器上可用的 GPT 权重文件
+func (c *Client) ListGPTWeights(ctx context.Context) ([]WeightFile, error) {
+	url := c.GPTWeightsListURL
+	if url == "" {
+		url = fmt.Sprintf("%s/gpt_weights_list", c.BaseURL)
+	}
+	return c.listWeights(ctx, url, "GPT")
+}
+
+// ListSoVITSWeights 列出服务器上可用的 SoVITS 权重文件
+func (c *Client) ListSoVITSWeights(ctx context.Context) ([]WeightFile, error) {
+	url := c.SoVITSWeightsListURL
+	if url == "" {
+		url = fmt.Sprintf("%s/sovits_weights_list", c.BaseURL)
+	}
+	return c.listWeights(ctx, url, "SoVITS")
+}
+
+// ListWeights 列出服务器上可用的 GPT 与 SoVITS 权重文件
+func (c *Client) ListWeights(ctx context.Context) (*WeightsList, error) {
+	gpt, err := c.ListGPTWeights(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sovits, err := c.ListSoVITSWeights(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &WeightsList{GPT: gpt, SoVITS: sovits}, nil
+}
+
+// listWeights 请求权重列表端点并解析响应
+func (c *Client) listWeights(ctx context.Context, url, kind string) ([]WeightFile, error) {
+	// 创建GET请求
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建权重列表请求失败: %w", err)
+	}
+
+	// 发送请求
+	resp, err := c.do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("获取%s权重列表失败: %w", kind, err)
+	}
+	defer resp.Body.Close()
+
+	// 读取响应体
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取权重列表响应失败: %w", err)
+	}
+
+	// 检查响应状态
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("获取%s权重列表失败，状态码 %d: %s", kind, resp.StatusCode, string(body))
+	}
+
+	files, err := parseWeightFiles(body)
+	if err != nil {
+		return nil, fmt.Errorf("解析%s权重列表失败: %w", kind, err)
+	}
+	return files, nil
+}
+
+// parseWeightFiles 解析权重列表，兼容路径数组、对象数组以及将数组包装在 weights、files、data 等字段中的对象
+func parseWeightFiles(body []byte) ([]WeightFile, error) {
+	var raw json.RawMessage = body
+
+	// 取出包装对象中的数组
+	var wrapper map[string]json.RawMessage
+	if json.Unmarshal(body, &wrapper) == nil {
+		raw = nil
+		for _, key := range []string{"weights", "files", "data", "list", "models"} {
+			if v, ok := wrapper[key]; ok {
+				raw = v
+				break
+			}
+		}
+		if raw == nil {
+			return nil, fmt.Errorf("响应中缺少权重数组")
+		}
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, err
+	}
+
+	files := make([]WeightFile, 0, len(items))
+	for _, item := range items {
+		var f WeightFile
+		var p string
+		if json.Unmarshal(item, &p) == nil {
+			f.Path = p
+		} else if err := json.Unmarshal(item, &f); err != nil {
+			return nil, err
+		}
+		if f.Path == "" {
+			f.Path = f.Name
+		}
+		if f.Name == "" {
+			f.Name = path.Base(strings.ReplaceAll(f.Path, "\\", "/"))
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// Available 列出服务器上可用的模型权重，可据此选择传给 SetWeights 的路径
+func (m *ModelManager) Available(ctx context.Context) (*WeightsList, error) {
+	return m.client.ListWeights(ctx)
+}
@@ -0,0 +1,226 @@
+package gpt_sovits_go_sdk
+
+// 提供面向 WebSocket 流式接口的客户端，适用于提供低延迟 WebSocket 端点的 GPT-SoVITS 分支
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ssdomei232/gpt_sovits_go_sdk/internal/ws"
+)
+
+// wsEvent 代表服务器发送的文本事件
+type wsEvent struct {
+	Event   string `json:"event"`   // 事件类型，"end" 或 "done" 表示本次合成结束
+	Error   string `json:"error"`   // 错误信息
+	Message string `json:"message"` // 部分实现使用 message 字段返回错误信息
+}
+
+// WSClient 代表 WebSocket 流式合成客户端
+//
+// 协议约定：客户端以文本帧发送 JSON 格式的 TTSRequest；服务器以二进制帧返回音频片段，
+// 并以 {"event":"end"} 文本帧表示合成结束，以 {"error":"..."} 文本帧报告错误
+type WSClient struct {
+	URL           string        // WebSocket 地址，如 ws://127.0.0.1:9880/ws/tts
+	Header        http.Header   // 握手时附加的请求头
+	Base          TTSRequest    // Synthesize 使用的基础请求参数
+	PingInterval  time.Duration // 保活 ping 间隔，<=0 时不发送 ping
+	MaxReconnects int           // 连接断开时的最大重连次数
+	TLSConfig     *tls.Config   // wss 连接使用的 TLS 配置，nil 时使用默认配置
+
+	mu     sync.Mutex // 保证同一连接上同时只有一个合成
+	connMu sync.Mutex
+	conn   *ws.Conn
+	stop   chan struct{}
+}
+
+// NewWSClient 创建一个新的 WebSocket 客户端
+func NewWSClient(url string) *WSClient {
+	return &WSClient{
+		URL:           url,
+		PingInterval:  30 * time.Second,
+		MaxReconnects: 3,
+	}
+}
+
+// Connect 建立 WebSocket 连接并启动保活 ping
+func (c *WSClient) Connect(ctx context.Context) error {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	return c.connectLocked(ctx)
+}
+
+// connectLocked 建立连接，调用方必须持有 connMu
+func (c *WSClient) connectLocked(ctx context.Context) error {
+	c.closeLocked()
+
+	conn, err := ws.Dial(ctx, c.URL, c.Header, c.TLSConfig)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+	c.stop = make(chan struct{})
+
+	if c.PingInterval > 0 {
+		go c.keepalive(conn, c.stop)
+	}
+	return nil
+}
+
+// keepalive 按间隔发送 ping 直至连接关闭
+func (c *WSClient) keepalive(conn *ws.Conn, stop chan struct{}) {
+	ticker := time.NewTicker(c.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := conn.Ping(nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Synthesize 使用基础请求参数合成文本，按顺序对每个音频帧调用 fn
+func (c *WSClient) Synthesize(ctx context.Context, text string, fn func(frame []byte) error) error {
+	req := c.Base
+	req.Text = text
+	return c.SynthesizeRequest(ctx, req, fn)
+}
+
+// SynthesizeRequest 发送合成请求并按顺序对每个音频帧调用 fn，fn 返回错误时中止
+// 尚未收到任何音频帧时连接断开会自动重连并重试
+func (c *WSClient) SynthesizeRequest(ctx context.Context, req TTSRequest, fn func(frame []byte) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	req.StreamingMode = true
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("请求序列化失败: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		received, err := c.synthesizeOnce(ctx, payload, fn)
+		if err == nil {
+			return nil
+		}
+
+		// 只有连接错误且尚未收到数据时才重连重试
+		var ce *wsConnError
+		if !errors.As(err, &ce) || received || attempt >= c.MaxReconnects || ctx.Err() != nil {
+			return err
+		}
+
+		c.connMu.Lock()
+		err = c.connectLocked(ctx)
+		c.connMu.Unlock()
+		if err != nil {
+			return fmt.Errorf("重连失败: %w", err)
+		}
+	}
+}
+
+// wsConnError 代表连接层面的错误，可通过重连恢复
+type wsConnError struct {
+	err error
+}
+
+// Error 实现 error 接口
+func (e *wsConnError) Error() string { return e.err.Error() }
+
+// Unwrap 返回底层错误
+func (e *wsConnError) Unwrap() error { return e.err }
+
+// synthesizeOnce 在当前连接上执行一次合成，返回是否已收到音频数据
+func (c *WSClient) synthesizeOnce(ctx context.Context, payload []byte, fn func(frame []byte) error) (bool, error) {
+	c.connMu.Lock()
+	if c.conn == nil {
+		if err := c.connectLocked(ctx); err != nil {
+			c.connMu.Unlock()
+			return false, &wsConnError{err: err}
+		}
+	}
+	conn := c.conn
+	c.connMu.Unlock()
+
+	// 上下文取消时关闭连接以中断阻塞的读取
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.connMu.Lock()
+			if c.conn == conn {
+				c.closeLocked()
+			}
+			c.connMu.Unlock()
+		case <-done:
+		}
+	}()
+
+	if err := conn.WriteMessage(ws.OpText, payload); err != nil {
+		return false, &wsConnError{err: err}
+	}
+
+	received := false
+	for {
+		op, data, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return received, ctx.Err()
+			}
+			return received, &wsConnError{err: err}
+		}
+
+		switch op {
+		case ws.OpBinary:
+			received = true
+			if err := fn(data); err != nil {
+				return received, err
+			}
+		case ws.OpText:
+			var ev wsEvent
+			if err := json.Unmarshal(data, &ev); err != nil {
+				return received, fmt.Errorf("解析服务器事件失败: %w", err)
+			}
+			if ev.Event == "end" || ev.Event == "done" {
+				return received, nil
+			}
+			if msg := ev.Error + ev.Message; msg != "" {
+				return received, fmt.Errorf("服务器返回错误: %s", msg)
+			}
+		}
+	}
+}
+
+// Close 关闭 WebSocket 连接并停止保活
+func (c *WSClient) Close() error {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	c.closeLocked()
+	return nil
+}
+
+// closeLocked 关闭当前连接，调用方必须持有 connMu
+func (c *WSClient) closeLocked() {
+	if c.stop != nil {
+		close(c.stop)
+		c.stop = nil
+	}
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+}